@@ -8,12 +8,15 @@ import (
 	"syscall"
 
 	"github.com/grachmannico95/flip-test-be/internal/config"
+	"github.com/grachmannico95/flip-test-be/internal/domain"
 	"github.com/grachmannico95/flip-test-be/internal/eventbus"
 	"github.com/grachmannico95/flip-test-be/internal/handler"
+	"github.com/grachmannico95/flip-test-be/internal/notifier"
 	"github.com/grachmannico95/flip-test-be/internal/server"
 	"github.com/grachmannico95/flip-test-be/internal/service"
 	"github.com/grachmannico95/flip-test-be/internal/storage"
 	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/grachmannico95/flip-test-be/pkg/retry"
 )
 
 func main() {
@@ -36,6 +39,7 @@ func main() {
 	log.Info(ctx, "Event bus initialized")
 
 	reconciliationConsumer := eventbus.NewReconciliationConsumer(
+		bus,
 		repo,
 		log,
 		cfg.Worker.PoolSize,
@@ -51,6 +55,55 @@ func main() {
 		)
 	}
 
+	anomalyConsumer := eventbus.NewAnomalyConsumer(repo, log, domain.DefaultAnomalyRules(), cfg.Worker.PoolSize)
+	err = bus.Subscribe(eventbus.EventTypeTransactionStored, anomalyConsumer)
+	if err != nil {
+		log.Fatal(ctx, "Failed to subscribe consumer",
+			"error", err,
+		)
+	}
+
+	balanceCheckConsumer := eventbus.NewBalanceCheckConsumer(bus, repo, log, 1)
+	err = bus.Subscribe(eventbus.EventTypeUploadReconciled, balanceCheckConsumer)
+	if err != nil {
+		log.Fatal(ctx, "Failed to subscribe consumer",
+			"error", err,
+		)
+	}
+
+	webhookNotifier := notifier.NewWebhookNotifier(repo, log, cfg.Webhook.Timeout,
+		retry.WithMaxAttempts(cfg.Webhook.MaxAttempts),
+		retry.WithBaseDelay(cfg.Webhook.RetryDelay),
+	)
+
+	// Alert webhooks are delivered from their own queue so alert workers do
+	// not wait on subscribers
+	webhookQueue := notifier.NewWebhookQueue(webhookNotifier, log, cfg.Webhook.QueueSize, cfg.Webhook.Workers)
+	webhookQueue.Start(ctx)
+
+	// Per-row alert rules run on every stored row, upload-wide ones once the
+	// upload is reconciled
+	alertNotifier := notifier.NewMultiNotifier(notifier.NewLogNotifier(log), webhookQueue)
+	alertConsumer := eventbus.NewAlertConsumer(repo, alertNotifier, log, cfg.Worker.PoolSize)
+	for _, eventType := range []eventbus.EventType{eventbus.EventTypeTransactionStored, eventbus.EventTypeUploadReconciled} {
+		err = bus.Subscribe(eventType, alertConsumer)
+		if err != nil {
+			log.Fatal(ctx, "Failed to subscribe consumer",
+				"error", err,
+			)
+		}
+	}
+
+	webhookConsumer := eventbus.NewWebhookConsumer(repo, webhookNotifier, log, cfg.Worker.PoolSize)
+	for _, eventType := range []eventbus.EventType{eventbus.EventTypeUploadChecked, eventbus.EventTypeUploadFailed} {
+		err = bus.Subscribe(eventType, webhookConsumer)
+		if err != nil {
+			log.Fatal(ctx, "Failed to subscribe consumer",
+				"error", err,
+			)
+		}
+	}
+
 	err = bus.Start(ctx)
 	if err != nil {
 		log.Fatal(ctx, "Failed to start event bus",
@@ -60,13 +113,47 @@ func main() {
 
 	csvProcessor := service.NewCSVProcessor(bus, repo, log)
 	statementService := service.NewStatementService(repo, csvProcessor, log)
+	collectionService := service.NewCollectionService(repo, log)
+	accountService := service.NewAccountService(repo, log)
+	reconciliationService := service.NewReconciliationService(repo, log)
+	counterpartyService := service.NewCounterpartyService(repo, log)
+	categoryService := service.NewCategoryService(repo, log)
+	issueService := service.NewIssueService(repo, log)
+	settlementService := service.NewSettlementService(repo, log)
+	balanceRuleService := service.NewBalanceRuleService(repo, log)
+	adjustmentService := service.NewAdjustmentService(repo, log)
+	anomalyService := service.NewAnomalyService(repo, log)
+	recurringService := service.NewRecurringService(repo, log)
+	projectionService := service.NewProjectionService(repo, log, cfg.Projection.SettleProbability)
+	alertService := service.NewAlertService(repo, log)
+	watchlistService := service.NewWatchlistService(repo, log)
+	webhookService := service.NewWebhookService(repo, log)
 	log.Info(ctx, "Services initialized")
 
+	if cfg.Watchlist.File != "" {
+		loadWatchlist(ctx, log, watchlistService, cfg.Watchlist.File)
+	}
+
 	statementHandler := handler.NewStatementHandler(statementService, log)
+	collectionHandler := handler.NewCollectionHandler(collectionService, log)
+	accountHandler := handler.NewAccountHandler(accountService, log)
+	reconciliationHandler := handler.NewReconciliationHandler(reconciliationService, log)
+	counterpartyHandler := handler.NewCounterpartyHandler(counterpartyService, log)
+	categoryHandler := handler.NewCategoryHandler(categoryService, log)
+	issueHandler := handler.NewIssueHandler(issueService, log)
+	settlementHandler := handler.NewSettlementHandler(settlementService, log)
+	balanceRuleHandler := handler.NewBalanceRuleHandler(balanceRuleService, log)
+	adjustmentHandler := handler.NewAdjustmentHandler(adjustmentService, log)
+	anomalyHandler := handler.NewAnomalyHandler(anomalyService, log)
+	recurringHandler := handler.NewRecurringHandler(recurringService, log)
+	projectionHandler := handler.NewProjectionHandler(projectionService, log)
+	alertHandler := handler.NewAlertHandler(alertService, log)
+	watchlistHandler := handler.NewWatchlistHandler(watchlistService, log)
+	webhookHandler := handler.NewWebhookHandler(webhookService, log)
 	healthHandler := handler.NewHealthHandler()
 	log.Info(ctx, "Handlers initialized")
 
-	srv := server.New(cfg, log, statementHandler, healthHandler)
+	srv := server.New(cfg, log, statementHandler, collectionHandler, accountHandler, reconciliationHandler, counterpartyHandler, categoryHandler, issueHandler, settlementHandler, balanceRuleHandler, adjustmentHandler, anomalyHandler, recurringHandler, projectionHandler, alertHandler, watchlistHandler, webhookHandler, healthHandler)
 
 	go func() {
 		if err := srv.Start(); err != nil && err != http.ErrServerClosed {
@@ -105,5 +192,33 @@ func main() {
 		)
 	}
 
+	// 3. Deliver the webhooks the alert workers queued
+	if err := webhookQueue.Shutdown(shutdownCtx); err != nil {
+		log.Error(shutdownCtx, "Webhook queue shutdown error",
+			"error", err,
+		)
+	}
+
 	log.Info(ctx, "Application stopped gracefully")
 }
+
+// loadWatchlist seeds the watchlist from a file, a missing or unreadable file
+// stops the server as screening would silently be off
+func loadWatchlist(ctx context.Context, log *logger.Logger, watchlistService service.WatchlistService, path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal(ctx, "Failed to open watchlist file",
+			"path", path,
+			"error", err,
+		)
+	}
+	defer file.Close()
+
+	_, _, err = watchlistService.ImportEntries(ctx, file, domain.WatchlistSourceFile)
+	if err != nil {
+		log.Fatal(ctx, "Failed to load watchlist file",
+			"path", path,
+			"error", err,
+		)
+	}
+}
@@ -10,10 +10,13 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Worker   WorkerConfig
-	Logging  LoggingConfig
-	EventBus EventBusConfig
+	Server     ServerConfig
+	Worker     WorkerConfig
+	Logging    LoggingConfig
+	EventBus   EventBusConfig
+	Projection ProjectionConfig
+	Watchlist  WatchlistConfig
+	Webhook    WebhookConfig
 }
 
 type ServerConfig struct {
@@ -35,6 +38,22 @@ type EventBusConfig struct {
 	ChannelBufferSize int
 }
 
+type ProjectionConfig struct {
+	SettleProbability float64
+}
+
+type WatchlistConfig struct {
+	File string
+}
+
+type WebhookConfig struct {
+	Timeout     time.Duration
+	MaxAttempts int
+	RetryDelay  time.Duration
+	QueueSize   int
+	Workers     int
+}
+
 func Load() *Config {
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, using default values")
@@ -56,6 +75,19 @@ func Load() *Config {
 		EventBus: EventBusConfig{
 			ChannelBufferSize: getIntEnv("EVENT_CHANNEL_BUFFER_SIZE", 1000),
 		},
+		Projection: ProjectionConfig{
+			SettleProbability: getFloatEnv("PROJECTION_SETTLE_PROBABILITY", 0.8),
+		},
+		Watchlist: WatchlistConfig{
+			File: getEnv("WATCHLIST_FILE", ""),
+		},
+		Webhook: WebhookConfig{
+			Timeout:     getDurationEnv("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts: getIntEnv("WEBHOOK_MAX_ATTEMPTS", 5),
+			RetryDelay:  getDurationEnv("WEBHOOK_RETRY_DELAY", 1*time.Second),
+			QueueSize:   getIntEnv("WEBHOOK_QUEUE_SIZE", 1000),
+			Workers:     getIntEnv("WEBHOOK_WORKERS", 4),
+		},
 	}
 }
 
@@ -82,6 +114,21 @@ func getIntEnv(key string, defaultValue int) int {
 	return value
 }
 
+func getFloatEnv(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		log.Printf("Invalid value for %s: %s, using default: %g", key, valueStr, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	valueStr := os.Getenv(key)
 	if valueStr == "" {
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type Account struct {
+	ID            string    `json:"id"`
+	AccountNumber string    `json:"account_number"`
+	Bank          string    `json:"bank"`
+	Currency      string    `json:"currency"`
+	Owner         string    `json:"owner"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Normalize trims every field and upper-cases the ISO 4217 currency code
+func (a *Account) Normalize() {
+	a.AccountNumber = strings.TrimSpace(a.AccountNumber)
+	a.Bank = strings.TrimSpace(a.Bank)
+	a.Currency = strings.ToUpper(strings.TrimSpace(a.Currency))
+	a.Owner = strings.TrimSpace(a.Owner)
+}
+
+func (a Account) Validate() error {
+	if a.AccountNumber == "" || a.Bank == "" || a.Owner == "" {
+		return ErrInvalidAccount
+	}
+
+	if len(a.Currency) != 3 {
+		return ErrInvalidAccount
+	}
+	for _, r := range a.Currency {
+		if r < 'A' || r > 'Z' {
+			return ErrInvalidAccount
+		}
+	}
+
+	return nil
+}
+
+// AccountBalance is the deduplicated balance of every row routed to an account,
+// optionally cut off at AsOf
+type AccountBalance struct {
+	AccountID string `json:"account_id"`
+	AsOf      *int64 `json:"as_of,omitempty"`
+	ConsolidatedBalance
+}
+
+// AccountIssueQuery narrows an account's FAILED and PENDING rows to a time window
+type AccountIssueQuery struct {
+	AccountID string
+	From      *int64
+	To        *int64
+	Status    *TransactionStatus
+	Page      int
+	PerPage   int
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// TransactionSource tells statement rows apart from rows posted by hand
+type TransactionSource string
+
+const (
+	TransactionSourceStatement  TransactionSource = "statement"
+	TransactionSourceAdjustment TransactionSource = "adjustment"
+)
+
+func (s TransactionSource) IsValid() bool {
+	return s == TransactionSourceStatement || s == TransactionSourceAdjustment
+}
+
+// SourceOf treats rows stored without a source as statement rows
+func SourceOf(tx Transaction) TransactionSource {
+	if tx.Source == "" {
+		return TransactionSourceStatement
+	}
+	return tx.Source
+}
+
+type AdjustmentState string
+
+const (
+	AdjustmentStateProposed AdjustmentState = "proposed"
+	AdjustmentStateApproved AdjustmentState = "approved"
+	AdjustmentStateRejected AdjustmentState = "rejected"
+)
+
+func (s AdjustmentState) IsValid() bool {
+	return s == AdjustmentStateProposed || s == AdjustmentStateApproved || s == AdjustmentStateRejected
+}
+
+// Adjustment is a correcting entry posted against an upload. It only becomes
+// a row, and moves the balance, once a second user approves it.
+type Adjustment struct {
+	ID              string          `json:"id"`
+	UploadID        string          `json:"upload_id"`
+	Timestamp       int64           `json:"timestamp"`
+	Counterparty    string          `json:"counterparty"`
+	Type            TransactionType `json:"type"`
+	Amount          int64           `json:"amount"`
+	Description     string          `json:"description"`
+	State           AdjustmentState `json:"state"`
+	ProposedBy      string          `json:"proposed_by"`
+	ProposedAt      time.Time       `json:"proposed_at"`
+	ReviewedBy      string          `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time      `json:"reviewed_at,omitempty"`
+	RejectionReason string          `json:"rejection_reason,omitempty"`
+	LineNumber      int             `json:"line_number,omitempty"`
+}
+
+func (a *Adjustment) Normalize() {
+	a.Counterparty = strings.TrimSpace(a.Counterparty)
+	a.Type = TransactionType(strings.ToUpper(strings.TrimSpace(string(a.Type))))
+	a.Description = strings.TrimSpace(a.Description)
+	a.ProposedBy = strings.TrimSpace(a.ProposedBy)
+}
+
+// Validate checks a proposal. Only ADJUSTMENT entries may carry a negative
+// amount, and reversals are left to the statement since they need a link.
+func (a Adjustment) Validate() error {
+	if a.ProposedBy == "" || a.Timestamp <= 0 || a.Amount == 0 {
+		return ErrInvalidAdjustment
+	}
+
+	if !a.Type.IsValid() || a.Type == TransactionTypeReversal {
+		return ErrInvalidAdjustment
+	}
+
+	if a.Amount < 0 && a.Type != TransactionTypeAdjustment {
+		return ErrInvalidAdjustment
+	}
+
+	return nil
+}
+
+// Transaction is the row an approved adjustment is stored as
+func (a Adjustment) Transaction() Transaction {
+	return Transaction{
+		Timestamp:    a.Timestamp,
+		Counterparty: a.Counterparty,
+		Type:         a.Type,
+		Amount:       a.Amount,
+		Status:       TransactionStatusSuccess,
+		Description:  a.Description,
+		Source:       TransactionSourceAdjustment,
+		AdjustmentID: a.ID,
+	}
+}
+
+// AdjustmentReview approves or, with a reason, rejects a proposed adjustment
+type AdjustmentReview struct {
+	Approve bool   `json:"-"`
+	Actor   string `json:"actor"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func (r *AdjustmentReview) Normalize() {
+	r.Actor = strings.TrimSpace(r.Actor)
+	r.Reason = strings.TrimSpace(r.Reason)
+}
+
+func (r AdjustmentReview) Validate() error {
+	if r.Actor == "" || (!r.Approve && r.Reason == "") {
+		return ErrInvalidAdjustment
+	}
+	return nil
+}
+
+// Review returns the reviewed copy of a proposed adjustment, the original is
+// left untouched. The proposer can reject but never approve their own entry.
+func (a Adjustment) Review(review AdjustmentReview, now time.Time) (*Adjustment, error) {
+	if a.State != AdjustmentStateProposed {
+		return nil, ErrAdjustmentReviewed
+	}
+
+	if review.Approve && strings.EqualFold(review.Actor, a.ProposedBy) {
+		return nil, ErrSelfApproval
+	}
+
+	reviewed := a
+	reviewed.ReviewedBy = review.Actor
+	reviewed.ReviewedAt = &now
+	if review.Approve {
+		reviewed.State = AdjustmentStateApproved
+	} else {
+		reviewed.State = AdjustmentStateRejected
+		reviewed.RejectionReason = review.Reason
+	}
+
+	return &reviewed, nil
+}
@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type AlertRuleKind string
+
+const (
+	AlertRuleBalanceBelow     AlertRuleKind = "balance_below"
+	AlertRuleDebitAbove       AlertRuleKind = "debit_above"
+	AlertRuleWatchlist        AlertRuleKind = "counterparty_watchlist"
+	AlertRuleFailedRatioAbove AlertRuleKind = "failed_ratio_above"
+)
+
+func (k AlertRuleKind) IsValid() bool {
+	switch k {
+	case AlertRuleBalanceBelow, AlertRuleDebitAbove, AlertRuleWatchlist, AlertRuleFailedRatioAbove:
+		return true
+	}
+	return false
+}
+
+// PerRow reports whether the rule is checked against each row as it is
+// reconciled, the others need the whole upload
+func (k AlertRuleKind) PerRow() bool {
+	return k == AlertRuleDebitAbove || k == AlertRuleWatchlist
+}
+
+// AlertRule uses Amount for balance_below and debit_above, Ratio for
+// failed_ratio_above and Counterparties for counterparty_watchlist
+type AlertRule struct {
+	ID             string        `json:"id"`
+	Name           string        `json:"name"`
+	Kind           AlertRuleKind `json:"kind"`
+	Amount         int64         `json:"amount"`
+	Ratio          float64       `json:"ratio,omitempty"`
+	Counterparties []string      `json:"counterparties,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+func (r *AlertRule) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Kind = AlertRuleKind(strings.ToLower(strings.TrimSpace(string(r.Kind))))
+
+	seen := make(map[string]bool, len(r.Counterparties))
+	counterparties := make([]string, 0, len(r.Counterparties))
+	for _, name := range r.Counterparties {
+		name = NormalizeCounterparty(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		counterparties = append(counterparties, name)
+	}
+	r.Counterparties = counterparties
+}
+
+func (r AlertRule) Validate() error {
+	if r.Name == "" || !r.Kind.IsValid() {
+		return ErrInvalidAlertRule
+	}
+
+	switch r.Kind {
+	case AlertRuleDebitAbove:
+		if r.Amount <= 0 {
+			return ErrInvalidAlertRule
+		}
+	case AlertRuleWatchlist:
+		if len(r.Counterparties) == 0 {
+			return ErrInvalidAlertRule
+		}
+	case AlertRuleFailedRatioAbove:
+		if r.Ratio < 0 || r.Ratio >= 1 {
+			return ErrInvalidAlertRule
+		}
+	}
+
+	return nil
+}
+
+// EvaluateRow checks a per-row rule against one row, counterparties are
+// compared by canonical name and amounts signed by the balance rules
+func (r AlertRule) EvaluateRow(uploadID string, row IssueTransaction, aliases CounterpartyAliases, rules *BalanceRules) (Alert, bool) {
+	alert := Alert{
+		RuleID:      r.ID,
+		RuleName:    r.Name,
+		Kind:        r.Kind,
+		UploadID:    uploadID,
+		LineNumbers: []int{row.LineNumber},
+	}
+
+	switch r.Kind {
+	case AlertRuleDebitAbove:
+		// Any row the balance rules settle as an outflow counts, FEE rows
+		// included and rows that never moved money left out
+		outflow := -rules.Settled(row.Transaction)
+		if outflow <= r.Amount {
+			return Alert{}, false
+		}
+		alert.Value = float64(outflow)
+		alert.Message = fmt.Sprintf("outflow of %d on line %d is above %d", outflow, row.LineNumber, r.Amount)
+	case AlertRuleWatchlist:
+		name := aliases.Canonical(row.Counterparty)
+		watched := false
+		for _, counterparty := range r.Counterparties {
+			if aliases.Canonical(counterparty) == name {
+				watched = true
+				break
+			}
+		}
+		if !watched {
+			return Alert{}, false
+		}
+		alert.Message = fmt.Sprintf("%s on line %d is on the watchlist", name, row.LineNumber)
+	default:
+		return Alert{}, false
+	}
+
+	return alert, true
+}
+
+// EvaluateUpload checks an upload-wide rule against every row in timestamp
+// order. The balance starts at the declared opening balance, if any, and each
+// dip below the threshold reports the row that caused it.
+func (r AlertRule) EvaluateUpload(uploadID string, rows []IssueTransaction, opening int64, rules *BalanceRules) (Alert, bool) {
+	alert := Alert{
+		RuleID:      r.ID,
+		RuleName:    r.Name,
+		Kind:        r.Kind,
+		UploadID:    uploadID,
+		LineNumbers: []int{},
+	}
+
+	switch r.Kind {
+	case AlertRuleBalanceBelow:
+		balance := opening
+		lowest := opening
+		for _, row := range rows {
+			previous := balance
+			balance += rules.Settled(row.Transaction)
+			if balance < r.Amount && previous >= r.Amount {
+				alert.LineNumbers = append(alert.LineNumbers, row.LineNumber)
+			}
+			if balance < lowest {
+				lowest = balance
+			}
+		}
+		if len(alert.LineNumbers) == 0 {
+			return Alert{}, false
+		}
+		alert.Value = float64(lowest)
+		alert.Message = fmt.Sprintf("balance fell below %d %d time(s), lowest %d", r.Amount, len(alert.LineNumbers), lowest)
+	case AlertRuleFailedRatioAbove:
+		if len(rows) == 0 {
+			return Alert{}, false
+		}
+		for _, row := range rows {
+			if row.Status == TransactionStatusFailed {
+				alert.LineNumbers = append(alert.LineNumbers, row.LineNumber)
+			}
+		}
+		ratio := float64(len(alert.LineNumbers)) / float64(len(rows))
+		if ratio <= r.Ratio {
+			return Alert{}, false
+		}
+		alert.Value = float64(int(ratio*100+0.5)) / 100
+		alert.Message = fmt.Sprintf("%d of %d rows FAILED, above the %.2f ratio", len(alert.LineNumbers), len(rows), r.Ratio)
+	default:
+		return Alert{}, false
+	}
+
+	return alert, true
+}
+
+// Alert is a triggered rule. Value is the observed amount or ratio, per-row
+// rules raise one alert per offending row.
+type Alert struct {
+	ID          string        `json:"id"`
+	RuleID      string        `json:"rule_id"`
+	RuleName    string        `json:"rule_name"`
+	Kind        AlertRuleKind `json:"kind"`
+	UploadID    string        `json:"upload_id"`
+	LineNumbers []int         `json:"line_numbers"`
+	Value       float64       `json:"value"`
+	Message     string        `json:"message"`
+	TriggeredAt time.Time     `json:"triggered_at"`
+}
+
+// Key identifies an alert so a retried evaluation does not raise it twice
+func (a Alert) Key() string {
+	key := a.RuleID + "|" + a.UploadID
+	if a.Kind.PerRow() && len(a.LineNumbers) > 0 {
+		key += "|" + strconv.Itoa(a.LineNumbers[0])
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+type AlertQuery struct {
+	UploadID string
+	RuleID   string
+	Kinds    []AlertRuleKind
+}
+
+func (q AlertQuery) Validate() error {
+	for _, kind := range q.Kinds {
+		if !kind.IsValid() {
+			return ErrInvalidQuery
+		}
+	}
+	return nil
+}
+
+func (q AlertQuery) Matches(alert Alert) bool {
+	if q.UploadID != "" && alert.UploadID != q.UploadID {
+		return false
+	}
+
+	if q.RuleID != "" && alert.RuleID != q.RuleID {
+		return false
+	}
+
+	if len(q.Kinds) > 0 {
+		for _, kind := range q.Kinds {
+			if kind == alert.Kind {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// Notifier delivers triggered alerts, the event consumer calls it once per
+// new alert
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
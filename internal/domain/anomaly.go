@@ -0,0 +1,330 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+type AnomalyKind string
+
+const (
+	AnomalyKindAmountOutlier   AnomalyKind = "amount_outlier"
+	AnomalyKindBurst           AnomalyKind = "burst"
+	AnomalyKindFutureTimestamp AnomalyKind = "future_timestamp"
+	AnomalyKindStaleTimestamp  AnomalyKind = "stale_timestamp"
+	AnomalyKindStructuring     AnomalyKind = "structuring"
+)
+
+func (k AnomalyKind) IsValid() bool {
+	switch k {
+	case AnomalyKindAmountOutlier, AnomalyKindBurst, AnomalyKindFutureTimestamp, AnomalyKindStaleTimestamp, AnomalyKindStructuring:
+		return true
+	}
+	return false
+}
+
+// Anomaly is one finding on one row. Score runs from 0 to 1 and is 0.5 for a
+// row right at the rule's threshold.
+type Anomaly struct {
+	UploadID     string      `json:"upload_id"`
+	LineNumber   int         `json:"line_number"`
+	Kind         AnomalyKind `json:"kind"`
+	Score        float64     `json:"score"`
+	Reason       string      `json:"reason"`
+	Timestamp    int64       `json:"timestamp"`
+	Counterparty string      `json:"counterparty"`
+	Amount       int64       `json:"amount"`
+	DetectedAt   time.Time   `json:"detected_at"`
+}
+
+type AnomalyQuery struct {
+	UploadID string
+	Kinds    []AnomalyKind
+	MinScore float64
+}
+
+func (q AnomalyQuery) Validate() error {
+	for _, kind := range q.Kinds {
+		if !kind.IsValid() {
+			return ErrInvalidQuery
+		}
+	}
+
+	if q.MinScore < 0 || q.MinScore > 1 {
+		return ErrInvalidQuery
+	}
+
+	return nil
+}
+
+func (q AnomalyQuery) Matches(anomaly Anomaly) bool {
+	if len(q.Kinds) > 0 && !containsAnomalyKind(q.Kinds, anomaly.Kind) {
+		return false
+	}
+	return anomaly.Score >= q.MinScore
+}
+
+// AnomalyRules holds the detection thresholds
+type AnomalyRules struct {
+	// A row is an outlier when its amount is OutlierThreshold standard
+	// deviations from the counterparty's history of at least MinHistory rows.
+	// The deviation never goes below MinSpread of the mean.
+	OutlierThreshold float64
+	MinHistory       int
+	MinSpread        float64
+
+	// BurstCount rows within BurstWindow seconds
+	BurstWindow int64
+	BurstCount  int
+
+	FutureTolerance time.Duration
+	MaxAge          time.Duration
+
+	// StructuringCount round amounts, each a multiple of RoundUnit below
+	// StructuringCeiling, with one counterparty within StructuringWindow seconds
+	RoundUnit          int64
+	StructuringCeiling int64
+	StructuringCount   int
+	StructuringWindow  int64
+}
+
+func DefaultAnomalyRules() AnomalyRules {
+	return AnomalyRules{
+		OutlierThreshold:   3,
+		MinHistory:         5,
+		MinSpread:          0.1,
+		BurstWindow:        10,
+		BurstCount:         5,
+		FutureTolerance:    time.Hour,
+		MaxAge:             5 * 365 * 24 * time.Hour,
+		RoundUnit:          1000000,
+		StructuringCeiling: 500000000,
+		StructuringCount:   3,
+		StructuringWindow:  7 * 24 * 60 * 60,
+	}
+}
+
+// AnomalyContext is what a row is judged against. Nearby holds the rows of
+// the same upload inspected so far within BurstWindow of the row, and
+// SameCounterparty those with the row's counterparty within
+// StructuringWindow, the row itself included in both. History summarizes the
+// amounts of the same counterparty and type in other uploads.
+type AnomalyContext struct {
+	Nearby           []IssueTransaction
+	SameCounterparty []IssueTransaction
+	History          AmountStats
+	Now              time.Time
+}
+
+// AmountStats is a running count, sum and sum of squares of amounts, so the
+// mean and deviation are kept up to date without the amounts themselves
+type AmountStats struct {
+	Count      int
+	Sum        float64
+	SumSquares float64
+}
+
+func (a *AmountStats) Add(amount int64) {
+	a.Count++
+	a.Sum += float64(amount)
+	a.SumSquares += float64(amount) * float64(amount)
+}
+
+// Merge returns the statistics of both sets together
+func (a AmountStats) Merge(other AmountStats) AmountStats {
+	return AmountStats{
+		Count:      a.Count + other.Count,
+		Sum:        a.Sum + other.Sum,
+		SumSquares: a.SumSquares + other.SumSquares,
+	}
+}
+
+// Without returns the statistics with another set's amounts taken out
+func (a AmountStats) Without(other AmountStats) AmountStats {
+	return AmountStats{
+		Count:      a.Count - other.Count,
+		Sum:        a.Sum - other.Sum,
+		SumSquares: a.SumSquares - other.SumSquares,
+	}
+}
+
+func (a AmountStats) Mean() float64 {
+	if a.Count == 0 {
+		return 0
+	}
+	return a.Sum / float64(a.Count)
+}
+
+// StdDev is the population standard deviation
+func (a AmountStats) StdDev() float64 {
+	if a.Count == 0 {
+		return 0
+	}
+	mean := a.Mean()
+	return math.Sqrt(math.Max(a.SumSquares/float64(a.Count)-mean*mean, 0))
+}
+
+// Inspect returns the findings a new row causes. Bursts and structuring flag
+// every row of the window, so findings can point at rows seen earlier. Each
+// window is complete once its last row arrives, the outcome does not depend
+// on the order rows are inspected in.
+func (r AnomalyRules) Inspect(row IssueTransaction, actx AnomalyContext) []Anomaly {
+	var found []Anomaly
+
+	if anomaly, ok := r.checkTimestamp(row, actx.Now); ok {
+		found = append(found, anomaly)
+	}
+
+	if anomaly, ok := r.checkOutlier(row, actx.History); ok {
+		found = append(found, anomaly)
+	}
+
+	burst, size := windowsAround(actx.Nearby, row, r.BurstWindow, r.BurstCount)
+	for _, flagged := range burst {
+		found = append(found, newAnomaly(flagged, AnomalyKindBurst, ratioScore(float64(size), float64(r.BurstCount)),
+			fmt.Sprintf("%d transactions within %d seconds", size, r.BurstWindow)))
+	}
+
+	if r.isRoundAmount(row.Transaction) {
+		name := NormalizeCounterparty(row.Counterparty)
+		var candidates []IssueTransaction
+		for _, other := range actx.SameCounterparty {
+			if r.isRoundAmount(other.Transaction) && NormalizeCounterparty(other.Counterparty) == name {
+				candidates = append(candidates, other)
+			}
+		}
+
+		structured, size := windowsAround(candidates, row, r.StructuringWindow, r.StructuringCount)
+		for _, flagged := range structured {
+			found = append(found, newAnomaly(flagged, AnomalyKindStructuring, ratioScore(float64(size), float64(r.StructuringCount)),
+				fmt.Sprintf("%d round amounts below %d with %s within %d seconds", size, r.StructuringCeiling, name, r.StructuringWindow)))
+		}
+	}
+
+	return found
+}
+
+func (r AnomalyRules) checkTimestamp(row IssueTransaction, now time.Time) (Anomaly, bool) {
+	at := time.Unix(row.Timestamp, 0)
+
+	if ahead := at.Sub(now); ahead > r.FutureTolerance {
+		return newAnomaly(row, AnomalyKindFutureTimestamp, ratioScore(ahead.Seconds(), r.FutureTolerance.Seconds()),
+			fmt.Sprintf("timestamp is %s in the future", ahead.Round(time.Second))), true
+	}
+
+	if age := now.Sub(at); age > r.MaxAge {
+		return newAnomaly(row, AnomalyKindStaleTimestamp, ratioScore(age.Seconds(), r.MaxAge.Seconds()),
+			fmt.Sprintf("timestamp is %d days old", int64(age.Hours()/24))), true
+	}
+
+	return Anomaly{}, false
+}
+
+func (r AnomalyRules) checkOutlier(row IssueTransaction, history AmountStats) (Anomaly, bool) {
+	if history.Count < r.MinHistory || history.Count == 0 {
+		return Anomaly{}, false
+	}
+
+	mean := history.Mean()
+	spread := math.Max(history.StdDev(), math.Max(math.Abs(mean)*r.MinSpread, 1))
+
+	deviation := math.Abs(float64(row.Amount)-mean) / spread
+	if deviation < r.OutlierThreshold {
+		return Anomaly{}, false
+	}
+
+	return newAnomaly(row, AnomalyKindAmountOutlier, ratioScore(deviation, r.OutlierThreshold),
+		fmt.Sprintf("amount %d is %.1f standard deviations from the mean %.0f of %d earlier transactions", row.Amount, deviation, mean, history.Count)), true
+}
+
+func (r AnomalyRules) isRoundAmount(tx Transaction) bool {
+	return r.RoundUnit > 0 && tx.Amount >= r.RoundUnit && tx.Amount%r.RoundUnit == 0 && tx.Amount < r.StructuringCeiling
+}
+
+// windowsAround returns the rows of every window of at most width seconds that
+// holds row and at least count rows, with the size of the largest such window
+func windowsAround(rows []IssueTransaction, row IssueTransaction, width int64, count int) ([]IssueTransaction, int) {
+	if count < 2 {
+		return nil, 0
+	}
+
+	var nearby []IssueTransaction
+	for _, other := range rows {
+		if other.Timestamp >= row.Timestamp-width && other.Timestamp <= row.Timestamp+width {
+			nearby = append(nearby, other)
+		}
+	}
+	sort.Slice(nearby, func(i, j int) bool {
+		if nearby[i].Timestamp != nearby[j].Timestamp {
+			return nearby[i].Timestamp < nearby[j].Timestamp
+		}
+		return nearby[i].LineNumber < nearby[j].LineNumber
+	})
+
+	flagged := make(map[int]bool)
+	largest := 0
+	end := 0
+	for start := range nearby {
+		if nearby[start].Timestamp > row.Timestamp {
+			break
+		}
+		if end < start {
+			end = start
+		}
+		for end+1 < len(nearby) && nearby[end+1].Timestamp-nearby[start].Timestamp <= width {
+			end++
+		}
+
+		size := end - start + 1
+		if size < count {
+			continue
+		}
+		for i := start; i <= end; i++ {
+			flagged[i] = true
+		}
+		if size > largest {
+			largest = size
+		}
+	}
+
+	var result []IssueTransaction
+	for i, other := range nearby {
+		if flagged[i] {
+			result = append(result, other)
+		}
+	}
+
+	return result, largest
+}
+
+func newAnomaly(row IssueTransaction, kind AnomalyKind, score float64, reason string) Anomaly {
+	return Anomaly{
+		LineNumber:   row.LineNumber,
+		Kind:         kind,
+		Score:        score,
+		Reason:       reason,
+		Timestamp:    row.Timestamp,
+		Counterparty: strings.TrimSpace(row.Counterparty),
+		Amount:       row.Amount,
+	}
+}
+
+// ratioScore maps how far a value is past its threshold onto 0..1
+func ratioScore(value, threshold float64) float64 {
+	if value <= 0 || threshold <= 0 {
+		return 0
+	}
+	return math.Round(value/(value+threshold)*100) / 100
+}
+
+func containsAnomalyKind(kinds []AnomalyKind, kind AnomalyKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package domain
+
+import "sort"
+
+type BalanceEffect string
+
+const (
+	BalanceEffectAdd      BalanceEffect = "add"
+	BalanceEffectSubtract BalanceEffect = "subtract"
+	// BalanceEffectInverse undoes the reversed row, a reversal of a DEBIT adds
+	BalanceEffectInverse BalanceEffect = "inverse"
+)
+
+func (e BalanceEffect) IsValid() bool {
+	return e == BalanceEffectAdd || e == BalanceEffectSubtract || e == BalanceEffectInverse
+}
+
+// BalanceRule decides how rows of one type and status move the balance.
+// Excluded rows keep their effect so pending and failed totals can still be
+// split into inbound and outbound.
+type BalanceRule struct {
+	Type    TransactionType   `json:"type"`
+	Status  TransactionStatus `json:"status"`
+	Effect  BalanceEffect     `json:"effect"`
+	Include bool              `json:"include"`
+}
+
+func (r BalanceRule) Validate() error {
+	if !r.Type.IsValid() || !r.Status.IsValid() || !r.Effect.IsValid() {
+		return ErrInvalidBalanceRule
+	}
+
+	if (r.Effect == BalanceEffectInverse) != (r.Type == TransactionTypeReversal) {
+		return ErrInvalidBalanceRule
+	}
+
+	return nil
+}
+
+type balanceRuleKey struct {
+	txType TransactionType
+	status TransactionStatus
+}
+
+// BalanceRules is the complete type/status table. It is never changed once
+// built, a new table replaces it.
+type BalanceRules struct {
+	rules map[balanceRuleKey]BalanceRule
+}
+
+// defaultEffects is the direction of each type. Only settled rows count by
+// default, REFUNDED rows were settled and then returned in full.
+var defaultEffects = map[TransactionType]BalanceEffect{
+	TransactionTypeCredit:     BalanceEffectAdd,
+	TransactionTypeDebit:      BalanceEffectSubtract,
+	TransactionTypeFee:        BalanceEffectSubtract,
+	TransactionTypeInterest:   BalanceEffectAdd,
+	TransactionTypeAdjustment: BalanceEffectAdd,
+	TransactionTypeReversal:   BalanceEffectInverse,
+}
+
+func DefaultBalanceRules() *BalanceRules {
+	table := &BalanceRules{rules: make(map[balanceRuleKey]BalanceRule)}
+	for _, txType := range transactionTypes {
+		for _, status := range transactionStatuses {
+			table.rules[balanceRuleKey{txType, status}] = BalanceRule{
+				Type:    txType,
+				Status:  status,
+				Effect:  defaultEffects[txType],
+				Include: status == TransactionStatusSuccess,
+			}
+		}
+	}
+	return table
+}
+
+// NewBalanceRules builds a table from the default one with the given rules
+// replacing their type/status combination
+func NewBalanceRules(overrides []BalanceRule) (*BalanceRules, error) {
+	table := DefaultBalanceRules()
+	for _, rule := range overrides {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		table.rules[balanceRuleKey{rule.Type, rule.Status}] = rule
+	}
+	return table, nil
+}
+
+// Rules lists the table ordered by type and status
+func (t *BalanceRules) Rules() []BalanceRule {
+	rules := make([]BalanceRule, 0, len(t.rules))
+	for _, rule := range t.rules {
+		rules = append(rules, rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Type != rules[j].Type {
+			return rules[i].Type < rules[j].Type
+		}
+		return rules[i].Status < rules[j].Status
+	})
+
+	return rules
+}
+
+// Direction returns +1 or -1 for the side of the balance a row is on, and 0
+// when it cannot be told, as for an unlinked reversal
+func (t *BalanceRules) Direction(tx Transaction) int64 {
+	rule, ok := t.rules[balanceRuleKey{tx.Type, tx.Status}]
+	if !ok {
+		return 0
+	}
+
+	switch rule.Effect {
+	case BalanceEffectAdd:
+		return 1
+	case BalanceEffectSubtract:
+		return -1
+	case BalanceEffectInverse:
+		if tx.ReversalOf == nil || !tx.ReversalOf.IsResolved() || tx.ReversalOf.Type == TransactionTypeReversal {
+			return 0
+		}
+		return -t.Direction(Transaction{Type: tx.ReversalOf.Type, Status: tx.Status})
+	}
+
+	return 0
+}
+
+func (t *BalanceRules) Includes(tx Transaction) bool {
+	return t.rules[balanceRuleKey{tx.Type, tx.Status}].Include
+}
+
+// Signed returns the row's amount with its direction
+func (t *BalanceRules) Signed(tx Transaction) int64 {
+	return t.Direction(tx) * tx.Amount
+}
+
+// Settled returns the signed amount a row adds to the balance, 0 for rows the
+// table leaves out
+func (t *BalanceRules) Settled(tx Transaction) int64 {
+	if !t.Includes(tx) {
+		return 0
+	}
+	return t.Signed(tx)
+}
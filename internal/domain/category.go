@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CategoryRule assigns a category and tags to the rows it matches. Empty
+// conditions match everything, but a rule needs at least one condition.
+type CategoryRule struct {
+	ID                 string          `json:"id"`
+	Name               string          `json:"name"`
+	Priority           int             `json:"priority"`
+	DescriptionPattern string          `json:"description_pattern,omitempty"`
+	Counterparty       string          `json:"counterparty,omitempty"`
+	Type               TransactionType `json:"type,omitempty"`
+	MinAmount          *int64          `json:"min_amount,omitempty"`
+	MaxAmount          *int64          `json:"max_amount,omitempty"`
+	Category           string          `json:"category"`
+	Tags               []string        `json:"tags"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+}
+
+// Normalize lower-cases category and tags so filters do not depend on how a
+// rule author typed them
+func (r *CategoryRule) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Category = strings.ToLower(strings.TrimSpace(r.Category))
+	r.Counterparty = NormalizeCounterparty(r.Counterparty)
+	r.Type = TransactionType(strings.ToUpper(strings.TrimSpace(string(r.Type))))
+
+	seen := make(map[string]bool, len(r.Tags))
+	tags := make([]string, 0, len(r.Tags))
+	for _, tag := range r.Tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	r.Tags = tags
+}
+
+func (r CategoryRule) Validate() error {
+	if r.Category == "" {
+		return ErrInvalidCategoryRule
+	}
+
+	if r.DescriptionPattern == "" && r.Counterparty == "" && r.Type == "" &&
+		r.MinAmount == nil && r.MaxAmount == nil {
+		return ErrInvalidCategoryRule
+	}
+
+	if r.Type != "" && !r.Type.IsValid() {
+		return ErrInvalidCategoryRule
+	}
+
+	if r.MinAmount != nil && r.MaxAmount != nil && *r.MinAmount > *r.MaxAmount {
+		return ErrInvalidCategoryRule
+	}
+
+	if r.DescriptionPattern != "" {
+		if _, err := regexp.Compile(r.DescriptionPattern); err != nil {
+			return ErrInvalidCategoryRule
+		}
+	}
+
+	return nil
+}
+
+type compiledRule struct {
+	rule        CategoryRule
+	description *regexp.Regexp
+}
+
+func (c compiledRule) matches(tx Transaction) bool {
+	rule := c.rule
+
+	if rule.Type != "" && tx.Type != rule.Type {
+		return false
+	}
+
+	if rule.MinAmount != nil && tx.Amount < *rule.MinAmount {
+		return false
+	}
+
+	if rule.MaxAmount != nil && tx.Amount > *rule.MaxAmount {
+		return false
+	}
+
+	if rule.Counterparty != "" && NormalizeCounterparty(tx.Counterparty) != rule.Counterparty {
+		return false
+	}
+
+	if c.description != nil && !c.description.MatchString(tx.Description) {
+		return false
+	}
+
+	return true
+}
+
+// RuleSet is an immutable, compiled and ordered set of category rules
+type RuleSet struct {
+	rules []compiledRule
+}
+
+// NewRuleSet compiles the rules and orders them by priority, oldest first on
+// ties. Description patterns match case-insensitively.
+func NewRuleSet(rules []CategoryRule) (*RuleSet, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		entry := compiledRule{rule: rule}
+		if rule.DescriptionPattern != "" {
+			pattern, err := regexp.Compile("(?i)" + rule.DescriptionPattern)
+			if err != nil {
+				return nil, ErrInvalidCategoryRule
+			}
+			entry.description = pattern
+		}
+		compiled = append(compiled, entry)
+	}
+
+	sort.SliceStable(compiled, func(i, j int) bool {
+		a, b := compiled[i].rule, compiled[j].rule
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+
+	return &RuleSet{rules: compiled}, nil
+}
+
+// Categorize returns the category and tags of the first matching rule. The
+// transaction is returned unchanged when nothing matches or the set is nil.
+func (s *RuleSet) Categorize(tx Transaction) Transaction {
+	tx.Category = ""
+	tx.Tags = nil
+
+	if s == nil {
+		return tx
+	}
+
+	for _, rule := range s.rules {
+		if rule.matches(tx) {
+			tx.Category = rule.rule.Category
+			if len(rule.rule.Tags) > 0 {
+				tx.Tags = append([]string(nil), rule.rule.Tags...)
+			}
+			break
+		}
+	}
+
+	return tx
+}
+
+// UncategorizedLabel names the bucket of rows no rule matched
+const UncategorizedLabel = "uncategorized"
+
+type CategorySummary struct {
+	Category         string `json:"category"`
+	TotalCredit      int64  `json:"total_credit"`
+	TotalDebit       int64  `json:"total_debit"`
+	TransactionCount int    `json:"transaction_count"`
+}
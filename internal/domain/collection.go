@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type UploadCollection struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	UploadIDs []string  `json:"upload_ids"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type UploadBalance struct {
+	UploadID          string `json:"upload_id"`
+	Balance           int64  `json:"balance"`
+	TransactionCount  int    `json:"transaction_count"`
+	DuplicatesSkipped int    `json:"duplicates_skipped"`
+}
+
+type ConsolidatedBalance struct {
+	Balance           int64           `json:"balance"`
+	TransactionCount  int             `json:"transaction_count"`
+	DuplicatesSkipped int             `json:"duplicates_skipped"`
+	Uploads           []UploadBalance `json:"uploads"`
+}
+
+type ConsolidatedIssue struct {
+	UploadID string `json:"upload_id"`
+	IssueTransaction
+}
+
+// Fingerprint identifies the same real-world transaction across overlapping
+// statements. Status is left out on purpose: a row that was PENDING in one
+// statement and SUCCESS in the next is still the same transaction. The
+// counterparty is keyed by its canonical name so aliased spellings collide.
+// A bank reference identifies the transaction on its own, and so does the
+// adjustment a manual entry was approved from.
+func (tx Transaction) Fingerprint(aliases CounterpartyAliases) string {
+	if tx.AdjustmentID != "" {
+		sum := sha256.Sum256([]byte("adj|" + tx.AdjustmentID))
+		return hex.EncodeToString(sum[:])
+	}
+
+	if tx.Reference != "" {
+		sum := sha256.Sum256([]byte("ref|" + tx.Reference))
+		return hex.EncodeToString(sum[:])
+	}
+
+	key := strings.Join([]string{
+		strconv.FormatInt(tx.Timestamp, 10),
+		aliases.Canonical(tx.Counterparty),
+		string(tx.Type),
+		strconv.FormatInt(tx.Amount, 10),
+		strings.TrimSpace(tx.Description),
+	}, "|")
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
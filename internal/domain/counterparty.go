@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type CounterpartySummary struct {
+	Counterparty       string                    `json:"counterparty"`
+	TotalCredit        int64                     `json:"total_credit"`
+	TotalDebit         int64                     `json:"total_debit"`
+	TransactionCount   int                       `json:"transaction_count"`
+	StatusCounts       map[TransactionStatus]int `json:"status_counts"`
+	FirstTransactionAt int64                     `json:"first_transaction_at"`
+	LastTransactionAt  int64                     `json:"last_transaction_at"`
+	Variants           []string                  `json:"variants"`
+}
+
+type CounterpartySortField string
+
+const (
+	CounterpartySortByName        CounterpartySortField = "counterparty"
+	CounterpartySortByTotalCredit CounterpartySortField = "total_credit"
+	CounterpartySortByTotalDebit  CounterpartySortField = "total_debit"
+	CounterpartySortByCount       CounterpartySortField = "transaction_count"
+	CounterpartySortByLastSeen    CounterpartySortField = "last_transaction_at"
+)
+
+type CounterpartyQuery struct {
+	UploadID  string
+	SortBy    CounterpartySortField
+	SortOrder SortOrder
+	Page      int
+	PerPage   int
+}
+
+func (q CounterpartyQuery) Validate() error {
+	switch q.SortBy {
+	case "", CounterpartySortByName, CounterpartySortByTotalCredit, CounterpartySortByTotalDebit,
+		CounterpartySortByCount, CounterpartySortByLastSeen:
+	default:
+		return ErrInvalidQuery
+	}
+
+	switch q.SortOrder {
+	case "", SortOrderAsc, SortOrderDesc:
+	default:
+		return ErrInvalidQuery
+	}
+
+	return nil
+}
+
+// legalForms are company-form tokens dropped from either end of a name so
+// "PT JOHN DOE" and "JOHN DOE TBK" group with "JOHN DOE"
+var legalForms = map[string]bool{
+	"PT": true, "CV": true, "TBK": true, "UD": true, "PERSERO": true, "FIRMA": true,
+	"LTD": true, "LIMITED": true, "INC": true, "LLC": true, "LLP": true, "CORP": true,
+	"CORPORATION": true, "CO": true, "COMPANY": true, "PLC": true, "GMBH": true,
+	"BV": true, "NV": true, "SA": true, "AG": true, "PTE": true, "BHD": true, "SDN": true,
+}
+
+// NormalizeCounterparty folds case, drops punctuation and legal-form tokens
+// and collapses whitespace so "John  doe", "J. Doe" style variants and
+// "PT JOHN DOE TBK" reduce to comparable keys. Dots and apostrophes are
+// removed in place ("P.T." becomes "PT"), other punctuation separates words.
+// A name made only of legal-form tokens is kept as is.
+func NormalizeCounterparty(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case r == '.' || r == '\'' || r == '’':
+			return -1
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToUpper(r)
+		default:
+			return ' '
+		}
+	}, name)
+
+	tokens := strings.Fields(cleaned)
+
+	start, end := 0, len(tokens)
+	for start < end && legalForms[tokens[start]] {
+		start++
+	}
+	for end > start && legalForms[tokens[end-1]] {
+		end--
+	}
+	if start == end {
+		return strings.Join(tokens, " ")
+	}
+
+	return strings.Join(tokens[start:end], " ")
+}
+
+type CounterpartyAlias struct {
+	Alias     string    `json:"alias"`
+	Canonical string    `json:"canonical"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CounterpartyAliases maps a normalized alias to its normalized canonical
+// name. Aliases are kept one level deep so a lookup never chains.
+type CounterpartyAliases map[string]string
+
+func NewCounterpartyAliases(aliases []CounterpartyAlias) CounterpartyAliases {
+	table := make(CounterpartyAliases, len(aliases))
+	for _, alias := range aliases {
+		table[alias.Alias] = alias.Canonical
+	}
+	return table
+}
+
+// Canonical normalizes a raw name and resolves it through the alias table
+func (a CounterpartyAliases) Canonical(name string) string {
+	normalized := NormalizeCounterparty(name)
+	if canonical, ok := a[normalized]; ok {
+		return canonical
+	}
+	return normalized
+}
+
+// CounterpartyName is a canonical counterparty with the raw spellings it was
+// seen under
+type CounterpartyName struct {
+	Name             string   `json:"name"`
+	Variants         []string `json:"variants"`
+	TransactionCount int      `json:"transaction_count"`
+}
+
+// MergeSuggestion pairs two canonical names that probably are the same party
+type MergeSuggestion struct {
+	Name       string             `json:"name"`
+	Candidate  string             `json:"candidate"`
+	Similarity float64            `json:"similarity"`
+	Names      []CounterpartyName `json:"names"`
+}
+
+// SuggestMerges compares every pair of names and returns those scoring at
+// least threshold, most similar first. The name seen on more rows is proposed
+// as the canonical one.
+func SuggestMerges(names []CounterpartyName, threshold float64) []MergeSuggestion {
+	suggestions := []MergeSuggestion{}
+	for i := 0; i < len(names); i++ {
+		for j := i + 1; j < len(names); j++ {
+			similarity := NameSimilarity(names[i].Name, names[j].Name)
+			if similarity < threshold {
+				continue
+			}
+
+			keep, merge := names[i], names[j]
+			if merge.TransactionCount > keep.TransactionCount ||
+				(merge.TransactionCount == keep.TransactionCount && merge.Name < keep.Name) {
+				keep, merge = merge, keep
+			}
+
+			suggestions = append(suggestions, MergeSuggestion{
+				Name:       keep.Name,
+				Candidate:  merge.Name,
+				Similarity: similarity,
+				Names:      []CounterpartyName{keep, merge},
+			})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Similarity != suggestions[j].Similarity {
+			return suggestions[i].Similarity > suggestions[j].Similarity
+		}
+		return suggestions[i].Name < suggestions[j].Name
+	})
+
+	return suggestions
+}
+
+// CounterpartySimilarity scores two raw names from 0 to 1, names sharing a
+// canonical form score 1
+func CounterpartySimilarity(aliases CounterpartyAliases, a, b string) float64 {
+	canonicalA := aliases.Canonical(a)
+	canonicalB := aliases.Canonical(b)
+	if canonicalA == canonicalB {
+		return 1
+	}
+	return NameSimilarity(canonicalA, canonicalB)
+}
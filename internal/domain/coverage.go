@@ -0,0 +1,133 @@
+package domain
+
+import "sort"
+
+type StatementPeriod struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+func (p StatementPeriod) Validate() error {
+	if p.Start > p.End {
+		return ErrInvalidStatementPeriod
+	}
+	return nil
+}
+
+type CoverageSource string
+
+const (
+	CoverageSourceDeclared     CoverageSource = "declared"
+	CoverageSourceTransactions CoverageSource = "transactions"
+	CoverageSourceNone         CoverageSource = "none"
+)
+
+// UploadCoverage is the period an upload covers for one account, taken from
+// the declared statement period or else from its first and last row
+type UploadCoverage struct {
+	UploadID         string         `json:"upload_id"`
+	Start            int64          `json:"start"`
+	End              int64          `json:"end"`
+	Source           CoverageSource `json:"source"`
+	TransactionCount int            `json:"transaction_count"`
+}
+
+// CoverageGap is an inclusive range of seconds no upload covers
+type CoverageGap struct {
+	Start          int64  `json:"start"`
+	End            int64  `json:"end"`
+	Seconds        int64  `json:"seconds"`
+	AfterUploadID  string `json:"after_upload_id"`
+	BeforeUploadID string `json:"before_upload_id"`
+}
+
+// CoverageOverlap is an inclusive range covered by more than one upload
+type CoverageOverlap struct {
+	Start     int64    `json:"start"`
+	End       int64    `json:"end"`
+	UploadIDs []string `json:"upload_ids"`
+}
+
+type AccountCoverage struct {
+	AccountID        string            `json:"account_id"`
+	Start            *int64            `json:"start,omitempty"`
+	End              *int64            `json:"end,omitempty"`
+	Complete         bool              `json:"complete"`
+	GapTolerance     int64             `json:"gap_tolerance_seconds"`
+	Uploads          []UploadCoverage  `json:"uploads"`
+	Gaps             []CoverageGap     `json:"gaps"`
+	Overlaps         []CoverageOverlap `json:"overlaps"`
+	UncoveredUploads []string          `json:"uncovered_uploads"`
+}
+
+// AnalyzeCoverage walks the upload periods in start order and reports the gaps
+// longer than tolerance seconds and every range covered twice. Uploads without
+// a period are listed as uncovered and left out of the walk.
+func AnalyzeCoverage(accountID string, uploads []UploadCoverage, tolerance int64) *AccountCoverage {
+	coverage := &AccountCoverage{
+		AccountID:        accountID,
+		GapTolerance:     tolerance,
+		Uploads:          []UploadCoverage{},
+		Gaps:             []CoverageGap{},
+		Overlaps:         []CoverageOverlap{},
+		UncoveredUploads: []string{},
+	}
+
+	for _, upload := range uploads {
+		if upload.Source == CoverageSourceNone {
+			coverage.UncoveredUploads = append(coverage.UncoveredUploads, upload.UploadID)
+			continue
+		}
+		coverage.Uploads = append(coverage.Uploads, upload)
+	}
+
+	sort.SliceStable(coverage.Uploads, func(i, j int) bool {
+		a, b := coverage.Uploads[i], coverage.Uploads[j]
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		return a.End < b.End
+	})
+
+	if len(coverage.Uploads) == 0 {
+		return coverage
+	}
+
+	first := coverage.Uploads[0]
+	coveredEnd := first.End
+	furthest := first.UploadID
+
+	for _, upload := range coverage.Uploads[1:] {
+		if upload.Start <= coveredEnd {
+			end := coveredEnd
+			if upload.End < end {
+				end = upload.End
+			}
+			coverage.Overlaps = append(coverage.Overlaps, CoverageOverlap{
+				Start:     upload.Start,
+				End:       end,
+				UploadIDs: []string{furthest, upload.UploadID},
+			})
+		} else if uncovered := upload.Start - coveredEnd - 1; uncovered > tolerance {
+			coverage.Gaps = append(coverage.Gaps, CoverageGap{
+				Start:          coveredEnd + 1,
+				End:            upload.Start - 1,
+				Seconds:        uncovered,
+				AfterUploadID:  furthest,
+				BeforeUploadID: upload.UploadID,
+			})
+		}
+
+		if upload.End > coveredEnd {
+			coveredEnd = upload.End
+			furthest = upload.UploadID
+		}
+	}
+
+	start := first.Start
+	coverage.Start = &start
+	coverage.End = &coveredEnd
+	coverage.Complete = len(coverage.Gaps) == 0 && len(coverage.UncoveredUploads) == 0
+
+	return coverage
+}
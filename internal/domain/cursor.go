@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+type CursorDirection string
+
+const (
+	CursorDirectionNext CursorDirection = "next"
+	CursorDirectionPrev CursorDirection = "prev"
+)
+
+// Cursor points at the boundary row of a page. Rows are keyed on
+// timestamp + line number (or line number alone), which stays stable while
+// workers are still inserting rows.
+type Cursor struct {
+	Direction  CursorDirection `json:"d"`
+	SortBy     SortField       `json:"s"`
+	Timestamp  int64           `json:"t"`
+	LineNumber int             `json:"l"`
+}
+
+type CursorPage struct {
+	Items      []IssueTransaction `json:"items"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	PrevCursor string             `json:"prev_cursor,omitempty"`
+}
+
+func NewCursor(direction CursorDirection, sortBy SortField, tx IssueTransaction) Cursor {
+	return Cursor{
+		Direction:  direction,
+		SortBy:     sortBy,
+		Timestamp:  tx.Timestamp,
+		LineNumber: tx.LineNumber,
+	}
+}
+
+// Compare orders a row against the cursor key, ascending: -1 if the row sorts
+// before the cursor, 1 if after and 0 if it is the boundary row itself.
+func (c Cursor) Compare(tx IssueTransaction) int {
+	if c.SortBy != SortByLineNumber && tx.Timestamp != c.Timestamp {
+		if tx.Timestamp < c.Timestamp {
+			return -1
+		}
+		return 1
+	}
+
+	switch {
+	case tx.LineNumber < c.LineNumber:
+		return -1
+	case tx.LineNumber > c.LineNumber:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(token string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(raw, &cursor)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	if cursor.Direction != CursorDirectionNext && cursor.Direction != CursorDirectionPrev {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	if cursor.SortBy != SortByTimestamp && cursor.SortBy != SortByLineNumber {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
@@ -9,4 +9,59 @@ var (
 	ErrDuplicateEvent    = errors.New("duplicate event")
 	ErrInvalidStatus     = errors.New("invalid status")
 	ErrInvalidPageParams = errors.New("invalid page parameters")
+	ErrInvalidInterval   = errors.New("invalid interval")
+	ErrInvalidQuery      = errors.New("invalid query")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrInvalidPeriod     = errors.New("invalid period")
+
+	ErrCollectionNotFound = errors.New("collection not found")
+	ErrInvalidCollection  = errors.New("invalid collection")
+
+	ErrAccountNotFound  = errors.New("account not found")
+	ErrInvalidAccount   = errors.New("invalid account")
+	ErrDuplicateAccount = errors.New("account number already registered")
+	ErrAccountInUse     = errors.New("account has uploads attached")
+
+	ErrInvalidStatementPeriod = errors.New("invalid statement period")
+
+	ErrReconciliationRunNotFound    = errors.New("reconciliation run not found")
+	ErrInvalidReconciliationOptions = errors.New("invalid reconciliation options")
+	ErrUploadNotReconciled          = errors.New("upload is still being processed")
+	ErrInvalidLedger                = errors.New("invalid ledger file")
+
+	ErrAliasNotFound = errors.New("counterparty alias not found")
+	ErrInvalidAlias  = errors.New("invalid counterparty alias")
+
+	ErrCategoryRuleNotFound = errors.New("category rule not found")
+	ErrInvalidCategoryRule  = errors.New("invalid category rule")
+
+	ErrIssueNotFound          = errors.New("issue not found")
+	ErrInvalidIssueUpdate     = errors.New("invalid issue update")
+	ErrInvalidIssueTransition = errors.New("issue state transition not allowed")
+
+	ErrTransactionNotFound     = errors.New("transaction not found")
+	ErrInvalidStatusUpdate     = errors.New("invalid status update")
+	ErrInvalidStatusTransition = errors.New("status transition not allowed")
+
+	ErrDuplicateReference = errors.New("transaction reference already stored")
+
+	ErrInvalidBalanceRule = errors.New("invalid balance rule")
+
+	ErrAdjustmentNotFound = errors.New("adjustment not found")
+	ErrInvalidAdjustment  = errors.New("invalid adjustment")
+	ErrAdjustmentReviewed = errors.New("adjustment already reviewed")
+	ErrSelfApproval       = errors.New("adjustment cannot be approved by its proposer")
+
+	ErrAlertRuleNotFound = errors.New("alert rule not found")
+	ErrInvalidAlertRule  = errors.New("invalid alert rule")
+
+	ErrWatchlistEntryNotFound  = errors.New("watchlist entry not found")
+	ErrInvalidWatchlistEntry   = errors.New("invalid watchlist entry")
+	ErrDuplicateWatchlistEntry = errors.New("watchlist entry already exists")
+	ErrScreeningNotFound       = errors.New("screening result not found")
+	ErrInvalidScreeningReview  = errors.New("invalid screening review")
+	ErrUploadNeedsReview       = errors.New("upload is held for watchlist review")
+
+	ErrWebhookNotFound = errors.New("webhook subscription not found")
+	ErrInvalidWebhook  = errors.New("invalid webhook subscription")
 )
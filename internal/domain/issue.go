@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type IssueState string
+
+const (
+	IssueStateOpen          IssueState = "open"
+	IssueStateInvestigating IssueState = "investigating"
+	IssueStateResolved      IssueState = "resolved"
+	IssueStateWontFix       IssueState = "wont_fix"
+)
+
+// issueTransitions lists the states each state may move to. Closed issues
+// can only be reopened.
+var issueTransitions = map[IssueState][]IssueState{
+	IssueStateOpen:          {IssueStateInvestigating, IssueStateResolved, IssueStateWontFix},
+	IssueStateInvestigating: {IssueStateOpen, IssueStateResolved, IssueStateWontFix},
+	IssueStateResolved:      {IssueStateOpen},
+	IssueStateWontFix:       {IssueStateOpen},
+}
+
+func (s IssueState) IsValid() bool {
+	_, ok := issueTransitions[s]
+	return ok
+}
+
+func (s IssueState) IsClosed() bool {
+	return s == IssueStateResolved || s == IssueStateWontFix
+}
+
+func (s IssueState) CanTransitionTo(next IssueState) bool {
+	for _, allowed := range issueTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type IssueNote struct {
+	Author    string    `json:"author,omitempty"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IssueWorkflow tracks the follow-up of a FAILED or PENDING row. ClosedAt is
+// set while the issue is resolved or won't be fixed.
+type IssueWorkflow struct {
+	State     IssueState  `json:"state"`
+	Assignee  string      `json:"assignee,omitempty"`
+	Notes     []IssueNote `json:"notes,omitempty"`
+	OpenedAt  time.Time   `json:"opened_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	ClosedAt  *time.Time  `json:"closed_at,omitempty"`
+}
+
+func NewIssueWorkflow(now time.Time) *IssueWorkflow {
+	return &IssueWorkflow{
+		State:     IssueStateOpen,
+		OpenedAt:  now,
+		UpdatedAt: now,
+	}
+}
+
+// IssueUpdate changes an issue. Nil fields are left alone, an empty assignee
+// unassigns the issue.
+type IssueUpdate struct {
+	State    *IssueState
+	Assignee *string
+	Note     string
+	Actor    string
+}
+
+func (u *IssueUpdate) Normalize() {
+	if u.State != nil {
+		state := IssueState(strings.ToLower(strings.TrimSpace(string(*u.State))))
+		u.State = &state
+	}
+	if u.Assignee != nil {
+		assignee := strings.TrimSpace(*u.Assignee)
+		u.Assignee = &assignee
+	}
+	u.Note = strings.TrimSpace(u.Note)
+	u.Actor = strings.TrimSpace(u.Actor)
+}
+
+func (u IssueUpdate) Validate() error {
+	if u.State == nil && u.Assignee == nil && u.Note == "" {
+		return ErrInvalidIssueUpdate
+	}
+
+	if u.State != nil && !u.State.IsValid() {
+		return ErrInvalidIssueUpdate
+	}
+
+	return nil
+}
+
+// IssueHistoryEntry records one change to an issue
+type IssueHistoryEntry struct {
+	Actor            string     `json:"actor,omitempty"`
+	FromState        IssueState `json:"from_state,omitempty"`
+	ToState          IssueState `json:"to_state"`
+	PreviousAssignee string     `json:"previous_assignee,omitempty"`
+	Assignee         string     `json:"assignee,omitempty"`
+	Note             string     `json:"note,omitempty"`
+	ChangedAt        time.Time  `json:"changed_at"`
+}
+
+// Apply returns the workflow after the update and the history entry that
+// records it, the receiver is left untouched. Setting the current state
+// again is not a transition and is accepted.
+func (w IssueWorkflow) Apply(update IssueUpdate, now time.Time) (*IssueWorkflow, IssueHistoryEntry, error) {
+	next := w
+	next.Notes = append([]IssueNote(nil), w.Notes...)
+
+	entry := IssueHistoryEntry{
+		Actor:            update.Actor,
+		FromState:        w.State,
+		ToState:          w.State,
+		PreviousAssignee: w.Assignee,
+		Assignee:         w.Assignee,
+		Note:             update.Note,
+		ChangedAt:        now,
+	}
+
+	if update.State != nil && *update.State != w.State {
+		if !w.State.CanTransitionTo(*update.State) {
+			return nil, IssueHistoryEntry{}, ErrInvalidIssueTransition
+		}
+
+		next.State = *update.State
+		entry.ToState = next.State
+
+		next.ClosedAt = nil
+		if next.State.IsClosed() {
+			next.ClosedAt = &now
+		}
+	}
+
+	if update.Assignee != nil {
+		next.Assignee = *update.Assignee
+		entry.Assignee = next.Assignee
+	}
+
+	if update.Note != "" {
+		next.Notes = append(next.Notes, IssueNote{
+			Author:    update.Actor,
+			Text:      update.Note,
+			CreatedAt: now,
+		})
+	}
+
+	next.UpdatedAt = now
+
+	return &next, entry, nil
+}
+
+// IssueKind says why a row needs follow-up, a row can have both kinds
+type IssueKind string
+
+const (
+	IssueKindStatus  IssueKind = "status"
+	IssueKindAnomaly IssueKind = "anomaly"
+)
+
+func (k IssueKind) IsValid() bool {
+	return k == IssueKindStatus || k == IssueKindAnomaly
+}
+
+// IssueKindsOf lists the kinds of issue a row has, FAILED and PENDING rows are
+// status issues and flagged rows anomaly issues
+func IssueKindsOf(tx Transaction) []IssueKind {
+	var kinds []IssueKind
+	if tx.Status == TransactionStatusFailed || tx.Status == TransactionStatusPending {
+		kinds = append(kinds, IssueKindStatus)
+	}
+	if len(tx.Anomalies) > 0 {
+		kinds = append(kinds, IssueKindAnomaly)
+	}
+	return kinds
+}
+
+// IssueDetail is a single issue with its change history
+type IssueDetail struct {
+	IssueTransaction
+	History []IssueHistoryEntry `json:"history"`
+}
@@ -5,25 +5,88 @@ import "time"
 type TransactionType string
 
 const (
-	TransactionTypeCredit TransactionType = "CREDIT"
-	TransactionTypeDebit  TransactionType = "DEBIT"
+	TransactionTypeCredit     TransactionType = "CREDIT"
+	TransactionTypeDebit      TransactionType = "DEBIT"
+	TransactionTypeFee        TransactionType = "FEE"
+	TransactionTypeInterest   TransactionType = "INTEREST"
+	TransactionTypeAdjustment TransactionType = "ADJUSTMENT"
+	TransactionTypeReversal   TransactionType = "REVERSAL"
 )
 
+var transactionTypes = []TransactionType{
+	TransactionTypeCredit,
+	TransactionTypeDebit,
+	TransactionTypeFee,
+	TransactionTypeInterest,
+	TransactionTypeAdjustment,
+	TransactionTypeReversal,
+}
+
+func (t TransactionType) IsValid() bool {
+	for _, known := range transactionTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
 type TransactionStatus string
 
 const (
-	TransactionStatusSuccess TransactionStatus = "SUCCESS"
-	TransactionStatusFailed  TransactionStatus = "FAILED"
-	TransactionStatusPending TransactionStatus = "PENDING"
+	TransactionStatusSuccess  TransactionStatus = "SUCCESS"
+	TransactionStatusFailed   TransactionStatus = "FAILED"
+	TransactionStatusPending  TransactionStatus = "PENDING"
+	TransactionStatusRefunded TransactionStatus = "REFUNDED"
 )
 
+var transactionStatuses = []TransactionStatus{
+	TransactionStatusSuccess,
+	TransactionStatusFailed,
+	TransactionStatusPending,
+	TransactionStatusRefunded,
+}
+
+func (s TransactionStatus) IsValid() bool {
+	for _, known := range transactionStatuses {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
 type Transaction struct {
-	Timestamp    int64             `json:"timestamp"`
-	Counterparty string            `json:"counterparty"`
-	Type         TransactionType   `json:"type"`
-	Amount       int64             `json:"amount"`
-	Status       TransactionStatus `json:"status"`
-	Description  string            `json:"description"`
+	Timestamp     int64             `json:"timestamp"`
+	Counterparty  string            `json:"counterparty"`
+	Type          TransactionType   `json:"type"`
+	Amount        int64             `json:"amount"`
+	Status        TransactionStatus `json:"status"`
+	Description   string            `json:"description"`
+	Reference     string            `json:"reference,omitempty"`
+	AccountID     string            `json:"account_id,omitempty"`
+	Category      string            `json:"category,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+	Issue         *IssueWorkflow    `json:"issue,omitempty"`
+	ReversalOf    *TransactionLink  `json:"reversal_of,omitempty"`
+	ReversedBy    *TransactionLink  `json:"reversed_by,omitempty"`
+	Source        TransactionSource `json:"source,omitempty"`
+	AdjustmentID  string            `json:"adjustment_id,omitempty"`
+	Anomalies     []AnomalyKind     `json:"anomalies,omitempty"`
+	WatchlistHits []WatchlistHit    `json:"watchlist_hits,omitempty"`
+}
+
+// TransactionLink points at another stored row by its bank reference. The
+// upload and line number stay empty until the row is found.
+type TransactionLink struct {
+	Reference  string          `json:"reference"`
+	UploadID   string          `json:"upload_id,omitempty"`
+	LineNumber int             `json:"line_number,omitempty"`
+	Type       TransactionType `json:"type,omitempty"`
+}
+
+func (l TransactionLink) IsResolved() bool {
+	return l.UploadID != ""
 }
 
 type UploadStatus string
@@ -32,18 +95,118 @@ const (
 	UploadStatusProcessing UploadStatus = "processing"
 	UploadStatusCompleted  UploadStatus = "completed"
 	UploadStatusFailed     UploadStatus = "failed"
+	// UploadStatusNeedsReview holds a reconciled upload until its watchlist
+	// hits are cleared
+	UploadStatusNeedsReview UploadStatus = "needs_review"
 )
 
 type Upload struct {
-	ID            string       `json:"id"`
-	Status        UploadStatus `json:"status"`
-	ProcessedRows int          `json:"processed_rows"`
-	TotalRows     int          `json:"total_rows"`
-	CreatedAt     time.Time    `json:"created_at"`
-	CompletedAt   *time.Time   `json:"completed_at,omitempty"`
+	ID            string           `json:"id"`
+	AccountID     string           `json:"account_id,omitempty"`
+	Status        UploadStatus     `json:"status"`
+	ProcessedRows int              `json:"processed_rows"`
+	TotalRows     int              `json:"total_rows"`
+	CreatedAt     time.Time        `json:"created_at"`
+	CompletedAt   *time.Time       `json:"completed_at,omitempty"`
+	ReconciledAt  *time.Time       `json:"reconciled_at,omitempty"`
+	Period        *StatementPeriod `json:"period,omitempty"`
+	BalanceCheck  *BalanceCheck    `json:"balance_check,omitempty"`
+	HoldForReview bool             `json:"hold_for_review,omitempty"`
+}
+
+type UploadOptions struct {
+	AccountID              string
+	Period                 *StatementPeriod
+	OpeningBalance         *int64
+	ExpectedClosingBalance *int64
+	HoldForReview          bool
+}
+
+// BalanceCheck compares the declared statement balances with the computed one.
+// The computed fields stay empty until every row of the upload is reconciled.
+type BalanceCheck struct {
+	OpeningBalance         int64      `json:"opening_balance"`
+	ExpectedClosingBalance *int64     `json:"expected_closing_balance,omitempty"`
+	ComputedClosingBalance *int64     `json:"computed_closing_balance,omitempty"`
+	Matched                *bool      `json:"matched,omitempty"`
+	Difference             *int64     `json:"difference,omitempty"`
+	CheckedAt              *time.Time `json:"checked_at,omitempty"`
+}
+
+// Evaluate returns the check with the closing balance computed from the
+// settled balance of the rows and compared with the declared one
+func (c BalanceCheck) Evaluate(balance int64, now time.Time) BalanceCheck {
+	closing := c.OpeningBalance + balance
+	c.ComputedClosingBalance = &closing
+	c.CheckedAt = &now
+	c.Difference, c.Matched = nil, nil
+
+	if c.ExpectedClosingBalance != nil {
+		difference := closing - *c.ExpectedClosingBalance
+		matched := difference == 0
+		c.Difference = &difference
+		c.Matched = &matched
+	}
+
+	return c
 }
 
 type IssueTransaction struct {
 	Transaction
 	LineNumber int `json:"line_number"`
 }
+
+type RejectedRow struct {
+	LineNumber int    `json:"line_number"`
+	Raw        string `json:"raw"`
+	Reason     string `json:"reason"`
+}
+
+type AmountTotals struct {
+	Credit int64 `json:"credit"`
+	Debit  int64 `json:"debit"`
+}
+
+type BalanceBreakdown struct {
+	Balance          int64                     `json:"balance"`
+	TotalCredits     int64                     `json:"total_credits"`
+	TotalDebits      int64                     `json:"total_debits"`
+	StatusCounts     map[TransactionStatus]int `json:"status_counts"`
+	PendingInbound   int64                     `json:"pending_inbound"`
+	PendingOutbound  int64                     `json:"pending_outbound"`
+	FailedCredits    int64                     `json:"failed_credits"`
+	FailedDebits     int64                     `json:"failed_debits"`
+	RefundedCredits  int64                     `json:"refunded_credits"`
+	RefundedDebits   int64                     `json:"refunded_debits"`
+	ProjectedBalance int64                     `json:"projected_balance"`
+}
+
+type SeriesInterval string
+
+const (
+	SeriesIntervalHour SeriesInterval = "hour"
+	SeriesIntervalDay  SeriesInterval = "day"
+)
+
+func (i SeriesInterval) IsValid() bool {
+	return i == SeriesIntervalHour || i == SeriesIntervalDay
+}
+
+// Seconds returns the bucket width used to group transaction timestamps
+func (i SeriesInterval) Seconds() int64 {
+	switch i {
+	case SeriesIntervalHour:
+		return 60 * 60
+	case SeriesIntervalDay:
+		return 24 * 60 * 60
+	default:
+		return 0
+	}
+}
+
+type BalancePoint struct {
+	BucketStart int64 `json:"bucket_start"`
+	Credits     int64 `json:"credits"`
+	Debits      int64 `json:"debits"`
+	Balance     int64 `json:"balance"`
+}
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	DefaultProjectionHorizon = 30 * 24 * time.Hour
+	MaxProjectionHorizon     = 366 * 24 * time.Hour
+
+	projectionDateLayout = "2006-01-02"
+)
+
+type ProjectionQuery struct {
+	UploadID string
+	Horizon  time.Duration
+	// SettleProbability weights every PENDING row, nil counts them in full
+	SettleProbability *float64
+}
+
+func (q ProjectionQuery) Validate() error {
+	if q.Horizon <= 0 || q.Horizon > MaxProjectionHorizon {
+		return ErrInvalidQuery
+	}
+
+	if q.SettleProbability != nil && (*q.SettleProbability < 0 || *q.SettleProbability > 1) {
+		return ErrInvalidQuery
+	}
+
+	return nil
+}
+
+type ProjectionSource string
+
+const (
+	ProjectionSourcePending   ProjectionSource = "pending"
+	ProjectionSourceRecurring ProjectionSource = "recurring"
+)
+
+// ProjectionEvent is one expected movement, Amount is signed and already
+// weighted by the settle probability
+type ProjectionEvent struct {
+	Source       ProjectionSource   `json:"source"`
+	Counterparty string             `json:"counterparty"`
+	Type         TransactionType    `json:"type"`
+	Amount       int64              `json:"amount"`
+	LineNumber   int                `json:"line_number,omitempty"`
+	Interval     RecurrenceInterval `json:"interval,omitempty"`
+}
+
+type ProjectionPoint struct {
+	Date      string            `json:"date"`
+	Timestamp int64             `json:"timestamp"`
+	Inflow    int64             `json:"inflow"`
+	Outflow   int64             `json:"outflow"`
+	Balance   int64             `json:"balance"`
+	Negative  bool              `json:"negative"`
+	Events    []ProjectionEvent `json:"events"`
+}
+
+type Projection struct {
+	UploadID          string            `json:"upload_id"`
+	StartBalance      int64             `json:"start_balance"`
+	Start             int64             `json:"start"`
+	End               int64             `json:"end"`
+	SettleProbability float64           `json:"settle_probability"`
+	LowestBalance     int64             `json:"lowest_balance"`
+	LowestOn          string            `json:"lowest_on"`
+	NegativeDates     []string          `json:"negative_dates"`
+	Points            []ProjectionPoint `json:"points"`
+}
+
+// ProjectionInput is the state a projection starts from. Start is the end of
+// the statement, the current balance holds every settled row up to it.
+type ProjectionInput struct {
+	StartBalance int64
+	Start        int64
+	Pending      []IssueTransaction
+	Recurring    []RecurringSeries
+	Rules        *BalanceRules
+}
+
+// Project builds a daily balance series in UTC from the start day to the end
+// of the horizon. PENDING rows are expected to settle the day after the start,
+// recurring series repeat from their next due date. Overdue series are left
+// out as they may have stopped.
+func (q ProjectionQuery) Project(in ProjectionInput) Projection {
+	startDay := time.Unix(in.Start, 0).UTC().Truncate(24 * time.Hour)
+	end := in.Start + int64(q.Horizon.Seconds())
+	days := int(time.Unix(end, 0).UTC().Sub(startDay).Hours()/24) + 1
+
+	probability := 1.0
+	if q.SettleProbability != nil {
+		probability = *q.SettleProbability
+	}
+
+	projection := Projection{
+		UploadID:          q.UploadID,
+		StartBalance:      in.StartBalance,
+		Start:             in.Start,
+		End:               end,
+		SettleProbability: probability,
+		NegativeDates:     []string{},
+		Points:            make([]ProjectionPoint, days),
+	}
+	for i := range projection.Points {
+		day := startDay.AddDate(0, 0, i)
+		projection.Points[i] = ProjectionPoint{
+			Date:      day.Format(projectionDateLayout),
+			Timestamp: day.Unix(),
+			Events:    []ProjectionEvent{},
+		}
+	}
+
+	addEvent := func(index int, event ProjectionEvent) {
+		if event.Amount == 0 || index >= len(projection.Points) {
+			return
+		}
+		point := &projection.Points[index]
+		if event.Amount > 0 {
+			point.Inflow += event.Amount
+		} else {
+			point.Outflow -= event.Amount
+		}
+		point.Events = append(point.Events, event)
+	}
+
+	for _, row := range in.Pending {
+		settled := row.Transaction
+		settled.Status = TransactionStatusSuccess
+		addEvent(1, ProjectionEvent{
+			Source:       ProjectionSourcePending,
+			Counterparty: row.Counterparty,
+			Type:         row.Type,
+			Amount:       int64(math.Round(float64(in.Rules.Settled(settled)) * probability)),
+			LineNumber:   row.LineNumber,
+		})
+	}
+
+	for _, series := range in.Recurring {
+		if series.Overdue {
+			continue
+		}
+		amount := in.Rules.Settled(Transaction{Type: series.Type, Status: TransactionStatusSuccess, Amount: series.TypicalAmount})
+		for due := series.NextExpected; due <= end; due = nextDue(series.Interval, due) {
+			// A due date within the tolerance before the start is still to come
+			index := int(time.Unix(due, 0).UTC().Sub(startDay).Hours() / 24)
+			if due <= in.Start {
+				index = 1
+			}
+			addEvent(index, ProjectionEvent{
+				Source:       ProjectionSourceRecurring,
+				Counterparty: series.Counterparty,
+				Type:         series.Type,
+				Amount:       amount,
+				Interval:     series.Interval,
+			})
+		}
+	}
+
+	balance := in.StartBalance
+	projection.LowestBalance = balance
+	projection.LowestOn = projection.Points[0].Date
+	for i := range projection.Points {
+		point := &projection.Points[i]
+		balance += point.Inflow - point.Outflow
+		point.Balance = balance
+		point.Negative = balance < 0
+
+		if point.Negative {
+			projection.NegativeDates = append(projection.NegativeDates, point.Date)
+		}
+		if balance < projection.LowestBalance {
+			projection.LowestBalance = balance
+			projection.LowestOn = point.Date
+		}
+	}
+
+	return projection
+}
@@ -0,0 +1,226 @@
+package domain
+
+import "strings"
+
+type SortField string
+
+const (
+	SortByTimestamp  SortField = "timestamp"
+	SortByAmount     SortField = "amount"
+	SortByLineNumber SortField = "line_number"
+)
+
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// TransactionQuery describes a filtered, sorted and paginated read of an
+// upload's transactions. Zero values mean "no filter".
+type TransactionQuery struct {
+	UploadID     string
+	Statuses     []TransactionStatus
+	Types        []TransactionType
+	Counterparty string
+	MinAmount    *int64
+	MaxAmount    *int64
+	From         *int64
+	To           *int64
+	Description  string
+	Categories   []string
+	IssueStates  []IssueState
+	Assignee     string
+	Sources      []TransactionSource
+	IssueKinds   []IssueKind
+	SortBy       SortField
+	SortOrder    SortOrder
+	Page         int
+	PerPage      int
+}
+
+func (q TransactionQuery) Validate() error {
+	for _, status := range q.Statuses {
+		if !status.IsValid() {
+			return ErrInvalidQuery
+		}
+	}
+
+	for _, txType := range q.Types {
+		if !txType.IsValid() {
+			return ErrInvalidQuery
+		}
+	}
+
+	if q.MinAmount != nil && q.MaxAmount != nil && *q.MinAmount > *q.MaxAmount {
+		return ErrInvalidQuery
+	}
+
+	if q.From != nil && q.To != nil && *q.From > *q.To {
+		return ErrInvalidQuery
+	}
+
+	for _, state := range q.IssueStates {
+		if !state.IsValid() {
+			return ErrInvalidQuery
+		}
+	}
+
+	for _, kind := range q.IssueKinds {
+		if !kind.IsValid() {
+			return ErrInvalidQuery
+		}
+	}
+
+	for _, source := range q.Sources {
+		if !source.IsValid() {
+			return ErrInvalidQuery
+		}
+	}
+
+	switch q.SortBy {
+	case "", SortByTimestamp, SortByAmount, SortByLineNumber:
+	default:
+		return ErrInvalidQuery
+	}
+
+	switch q.SortOrder {
+	case "", SortOrderAsc, SortOrderDesc:
+	default:
+		return ErrInvalidQuery
+	}
+
+	return nil
+}
+
+// CursorSortField returns the sort key used for cursor pagination. Only the
+// stored orderings can be seeked, so sorting by amount is not supported.
+func (q TransactionQuery) CursorSortField() (SortField, error) {
+	switch q.SortBy {
+	case "", SortByTimestamp:
+		return SortByTimestamp, nil
+	case SortByLineNumber:
+		return SortByLineNumber, nil
+	default:
+		return "", ErrInvalidQuery
+	}
+}
+
+// Matches reports whether a transaction passes every filter of the query.
+// Counterparty is compared by normalized name, description by substring.
+func (q TransactionQuery) Matches(tx Transaction) bool {
+	if len(q.Statuses) > 0 && !containsStatus(q.Statuses, tx.Status) {
+		return false
+	}
+
+	if len(q.Types) > 0 && !containsType(q.Types, tx.Type) {
+		return false
+	}
+
+	if q.Counterparty != "" && NormalizeCounterparty(q.Counterparty) != NormalizeCounterparty(tx.Counterparty) {
+		return false
+	}
+
+	if q.MinAmount != nil && tx.Amount < *q.MinAmount {
+		return false
+	}
+
+	if q.MaxAmount != nil && tx.Amount > *q.MaxAmount {
+		return false
+	}
+
+	if q.From != nil && tx.Timestamp < *q.From {
+		return false
+	}
+
+	if q.To != nil && tx.Timestamp > *q.To {
+		return false
+	}
+
+	if q.Description != "" && !strings.Contains(strings.ToLower(tx.Description), strings.ToLower(q.Description)) {
+		return false
+	}
+
+	if len(q.Categories) > 0 && !containsCategory(q.Categories, tx.Category) {
+		return false
+	}
+
+	if len(q.IssueStates) > 0 && (tx.Issue == nil || !containsIssueState(q.IssueStates, tx.Issue.State)) {
+		return false
+	}
+
+	if q.Assignee != "" && (tx.Issue == nil || !strings.EqualFold(tx.Issue.Assignee, q.Assignee)) {
+		return false
+	}
+
+	if len(q.Sources) > 0 && !containsSource(q.Sources, SourceOf(tx)) {
+		return false
+	}
+
+	if len(q.IssueKinds) > 0 && (tx.Issue == nil || !containsAnyIssueKind(q.IssueKinds, IssueKindsOf(tx))) {
+		return false
+	}
+
+	return true
+}
+
+// containsCategory treats an empty category as the uncategorized bucket
+func containsCategory(categories []string, category string) bool {
+	if category == "" {
+		category = UncategorizedLabel
+	}
+	for _, c := range categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStatus(statuses []TransactionStatus, status TransactionStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func containsType(types []TransactionType, txType TransactionType) bool {
+	for _, t := range types {
+		if t == txType {
+			return true
+		}
+	}
+	return false
+}
+
+func containsIssueState(states []IssueState, state IssueState) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSource(sources []TransactionSource, source TransactionSource) bool {
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAnyIssueKind(wanted, kinds []IssueKind) bool {
+	for _, kind := range kinds {
+		for _, k := range wanted {
+			if k == kind {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package domain
+
+import "time"
+
+// LedgerEntry is one row of the internal ledger a statement is reconciled against
+type LedgerEntry struct {
+	LineNumber   int             `json:"line_number"`
+	Timestamp    int64           `json:"timestamp"`
+	Counterparty string          `json:"counterparty"`
+	Type         TransactionType `json:"type"`
+	Amount       int64           `json:"amount"`
+	Description  string          `json:"description"`
+	Reference    string          `json:"reference,omitempty"`
+}
+
+type MatchClassification string
+
+const (
+	MatchClassificationMatched         MatchClassification = "matched"
+	MatchClassificationUnmatchedBank   MatchClassification = "unmatched_bank"
+	MatchClassificationUnmatchedLedger MatchClassification = "unmatched_ledger"
+	MatchClassificationAmountMismatch  MatchClassification = "amount_mismatch"
+)
+
+func (c MatchClassification) IsValid() bool {
+	switch c {
+	case MatchClassificationMatched, MatchClassificationUnmatchedBank,
+		MatchClassificationUnmatchedLedger, MatchClassificationAmountMismatch:
+		return true
+	default:
+		return false
+	}
+}
+
+type ReconciliationRunStatus string
+
+const (
+	ReconciliationRunStatusProcessing ReconciliationRunStatus = "processing"
+	ReconciliationRunStatusCompleted  ReconciliationRunStatus = "completed"
+	ReconciliationRunStatusFailed     ReconciliationRunStatus = "failed"
+)
+
+// ReconciliationOptions tunes the matching engine. Entries pair only when the
+// type matches and the timestamps are at most DateTolerance seconds apart, a
+// pair with different amounts also needs MinSimilarity on reference or text.
+type ReconciliationOptions struct {
+	DateTolerance int64   `json:"date_tolerance_seconds"`
+	MinSimilarity float64 `json:"min_similarity"`
+}
+
+func (o ReconciliationOptions) Validate() error {
+	if o.DateTolerance < 0 || o.MinSimilarity < 0 || o.MinSimilarity > 1 {
+		return ErrInvalidReconciliationOptions
+	}
+	return nil
+}
+
+type ReconciliationSummary struct {
+	Matched         int `json:"matched"`
+	UnmatchedBank   int `json:"unmatched_bank"`
+	UnmatchedLedger int `json:"unmatched_ledger"`
+	AmountMismatch  int `json:"amount_mismatch"`
+}
+
+func (s *ReconciliationSummary) Add(classification MatchClassification) {
+	switch classification {
+	case MatchClassificationMatched:
+		s.Matched++
+	case MatchClassificationUnmatchedBank:
+		s.UnmatchedBank++
+	case MatchClassificationUnmatchedLedger:
+		s.UnmatchedLedger++
+	case MatchClassificationAmountMismatch:
+		s.AmountMismatch++
+	}
+}
+
+type ReconciliationRun struct {
+	ID                string                  `json:"id"`
+	UploadID          string                  `json:"upload_id"`
+	Status            ReconciliationRunStatus `json:"status"`
+	Options           ReconciliationOptions   `json:"options"`
+	LedgerEntries     int                     `json:"ledger_entries"`
+	InvalidLedgerRows int                     `json:"invalid_ledger_rows"`
+	Summary           ReconciliationSummary   `json:"summary"`
+	Error             string                  `json:"error,omitempty"`
+	CreatedAt         time.Time               `json:"created_at"`
+	CompletedAt       *time.Time              `json:"completed_at,omitempty"`
+}
+
+// ReconciliationResult is one outcome of a run. Unmatched results carry only
+// the side they came from.
+type ReconciliationResult struct {
+	Classification   MatchClassification `json:"classification"`
+	Bank             *IssueTransaction   `json:"bank,omitempty"`
+	Ledger           *LedgerEntry        `json:"ledger,omitempty"`
+	Similarity       float64             `json:"similarity"`
+	DateDifference   int64               `json:"date_difference_seconds"`
+	AmountDifference int64               `json:"amount_difference"`
+}
+
+type ReconciliationResultQuery struct {
+	RunID          string
+	Classification *MatchClassification
+	Page           int
+	PerPage        int
+}
@@ -0,0 +1,378 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+type RecurrenceInterval string
+
+const (
+	RecurrenceWeekly   RecurrenceInterval = "weekly"
+	RecurrenceBiweekly RecurrenceInterval = "biweekly"
+	RecurrenceMonthly  RecurrenceInterval = "monthly"
+)
+
+var recurrenceIntervals = []RecurrenceInterval{RecurrenceWeekly, RecurrenceBiweekly, RecurrenceMonthly}
+
+// Next returns when the occurrence after one at from is due. Monthly series
+// follow the calendar, so a payment on the 31st is next due early the month
+// after next when that month is short.
+func (i RecurrenceInterval) Next(from time.Time) time.Time {
+	switch i {
+	case RecurrenceWeekly:
+		return from.AddDate(0, 0, 7)
+	case RecurrenceBiweekly:
+		return from.AddDate(0, 0, 14)
+	default:
+		return from.AddDate(0, 1, 0)
+	}
+}
+
+// Tolerance is how far an occurrence may drift from its due date
+func (i RecurrenceInterval) Tolerance() time.Duration {
+	switch i {
+	case RecurrenceWeekly:
+		return 36 * time.Hour
+	case RecurrenceBiweekly:
+		return 2 * 24 * time.Hour
+	default:
+		return 4 * 24 * time.Hour
+	}
+}
+
+func (i RecurrenceInterval) days() float64 {
+	switch i {
+	case RecurrenceWeekly:
+		return 7
+	case RecurrenceBiweekly:
+		return 14
+	default:
+		return 30.44
+	}
+}
+
+type RecurringExceptionKind string
+
+const (
+	RecurringExceptionMissed        RecurringExceptionKind = "missed"
+	RecurringExceptionOffSchedule   RecurringExceptionKind = "off_schedule"
+	RecurringExceptionUnusualAmount RecurringExceptionKind = "unusual_amount"
+)
+
+type RecurringOccurrence struct {
+	UploadID   string `json:"upload_id"`
+	LineNumber int    `json:"line_number"`
+	Timestamp  int64  `json:"timestamp"`
+	Amount     int64  `json:"amount"`
+}
+
+// RecurringException is a missed occurrence, which has only the due date, or
+// an occurrence that came off schedule or with an unusual amount
+type RecurringException struct {
+	Kind       RecurringExceptionKind `json:"kind"`
+	Expected   int64                  `json:"expected,omitempty"`
+	Occurrence *RecurringOccurrence   `json:"occurrence,omitempty"`
+	Reason     string                 `json:"reason"`
+}
+
+type RecurringSeries struct {
+	Counterparty    string                `json:"counterparty"`
+	Type            TransactionType       `json:"type"`
+	Interval        RecurrenceInterval    `json:"interval"`
+	TypicalAmount   int64                 `json:"typical_amount"`
+	OccurrenceCount int                   `json:"occurrence_count"`
+	FirstSeen       int64                 `json:"first_seen"`
+	LastSeen        int64                 `json:"last_seen"`
+	NextExpected    int64                 `json:"next_expected"`
+	Overdue         bool                  `json:"overdue"`
+	Regularity      float64               `json:"regularity"`
+	Occurrences     []RecurringOccurrence `json:"occurrences"`
+	Exceptions      []RecurringException  `json:"exceptions"`
+}
+
+// RecurringQuery analyses one upload or one account. AsOf is the end of the
+// analysed period, by default the latest row.
+type RecurringQuery struct {
+	UploadID  string
+	AccountID string
+	AsOf      *int64
+}
+
+type RecurringRules struct {
+	MinOccurrences int
+	// AmountTolerance is the share of the typical amount an occurrence may
+	// differ by before it is unusual
+	AmountTolerance float64
+	// MinRegularity is the share of gaps that must match the interval
+	MinRegularity float64
+}
+
+func DefaultRecurringRules() RecurringRules {
+	return RecurringRules{
+		MinOccurrences:  3,
+		AmountTolerance: 0.2,
+		MinRegularity:   0.6,
+	}
+}
+
+// DetectRecurring groups SUCCESS rows by canonical counterparty and type,
+// splits each group into clusters of similar amounts and keeps the clusters
+// whose gaps follow a weekly, biweekly or monthly schedule. One counterparty
+// can have several series. Rows must be in timestamp order.
+func (r RecurringRules) DetectRecurring(rows []ConsolidatedIssue, aliases CounterpartyAliases, asOf int64) []RecurringSeries {
+	type groupKey struct {
+		counterparty string
+		txType       TransactionType
+	}
+
+	groups := make(map[groupKey][]ConsolidatedIssue)
+	for _, row := range rows {
+		if row.Status != TransactionStatusSuccess {
+			continue
+		}
+		key := groupKey{aliases.Canonical(row.Counterparty), row.Type}
+		groups[key] = append(groups[key], row)
+	}
+
+	series := []RecurringSeries{}
+	for key, group := range groups {
+		for _, found := range r.detectGroup(group, asOf) {
+			found.Counterparty = key.counterparty
+			found.Type = key.txType
+			series = append(series, found)
+		}
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		if series[i].Counterparty != series[j].Counterparty {
+			return series[i].Counterparty < series[j].Counterparty
+		}
+		if series[i].Type != series[j].Type {
+			return series[i].Type < series[j].Type
+		}
+		return series[i].TypicalAmount < series[j].TypicalAmount
+	})
+
+	return series
+}
+
+// detectGroup looks for a schedule in every amount cluster of one
+// counterparty and type, so a subscription is found among ad-hoc purchases
+// with the same counterparty. A row of another cluster landing on a missed
+// due date is then taken in as an occurrence with an unusual amount.
+func (r RecurringRules) detectGroup(group []ConsolidatedIssue, asOf int64) []RecurringSeries {
+	claimed := make(map[int]bool)
+	var clusters [][]int
+	var found []RecurringSeries
+	for _, cluster := range r.clusterByAmount(group) {
+		series, ok := r.detectSeries(pickRows(group, cluster), asOf)
+		if !ok {
+			continue
+		}
+		for _, i := range cluster {
+			claimed[i] = true
+		}
+		clusters = append(clusters, cluster)
+		found = append(found, series)
+	}
+
+	for n, series := range found {
+		tolerance := int64(series.Interval.Tolerance().Seconds())
+		cluster := clusters[n]
+		extended := false
+		for _, exception := range series.Exceptions {
+			if exception.Kind != RecurringExceptionMissed {
+				continue
+			}
+			for i, row := range group {
+				if claimed[i] || row.Timestamp < exception.Expected-tolerance || row.Timestamp > exception.Expected+tolerance {
+					continue
+				}
+				claimed[i] = true
+				cluster = append(cluster, i)
+				extended = true
+				break
+			}
+		}
+		if !extended {
+			continue
+		}
+
+		sort.Ints(cluster)
+		if merged, ok := r.detectSeries(pickRows(group, cluster), asOf); ok {
+			found[n] = merged
+		}
+	}
+
+	return found
+}
+
+// clusterByAmount splits rows into clusters whose amounts stay within
+// AmountTolerance of the cluster mean. Clusters hold indexes into rows in
+// timestamp order.
+func (r RecurringRules) clusterByAmount(rows []ConsolidatedIssue) [][]int {
+	byAmount := make([]int, len(rows))
+	for i := range byAmount {
+		byAmount[i] = i
+	}
+	sort.SliceStable(byAmount, func(i, j int) bool {
+		return rows[byAmount[i]].Amount < rows[byAmount[j]].Amount
+	})
+
+	var clusters [][]int
+	var sum float64
+	for _, i := range byAmount {
+		amount := float64(rows[i].Amount)
+		if n := len(clusters); n > 0 {
+			mean := sum / float64(len(clusters[n-1]))
+			if math.Abs(amount-mean) <= math.Abs(mean)*r.AmountTolerance {
+				clusters[n-1] = append(clusters[n-1], i)
+				sum += amount
+				continue
+			}
+		}
+		clusters = append(clusters, []int{i})
+		sum = amount
+	}
+
+	for _, cluster := range clusters {
+		sort.Ints(cluster)
+	}
+	return clusters
+}
+
+func pickRows(rows []ConsolidatedIssue, indexes []int) []ConsolidatedIssue {
+	picked := make([]ConsolidatedIssue, 0, len(indexes))
+	for _, i := range indexes {
+		picked = append(picked, rows[i])
+	}
+	return picked
+}
+
+func (r RecurringRules) detectSeries(group []ConsolidatedIssue, asOf int64) (RecurringSeries, bool) {
+	if len(group) < r.MinOccurrences || len(group) < 2 {
+		return RecurringSeries{}, false
+	}
+
+	gaps := make([]float64, 0, len(group)-1)
+	for i := 1; i < len(group); i++ {
+		gaps = append(gaps, float64(group[i].Timestamp-group[i-1].Timestamp)/86400)
+	}
+
+	interval, ok := matchInterval(median(gaps))
+	if !ok {
+		return RecurringSeries{}, false
+	}
+	tolerance := int64(interval.Tolerance().Seconds())
+
+	amounts := make([]float64, 0, len(group))
+	for _, row := range group {
+		amounts = append(amounts, float64(row.Amount))
+	}
+	typical := int64(math.Round(median(amounts)))
+
+	series := RecurringSeries{
+		Interval:        interval,
+		TypicalAmount:   typical,
+		OccurrenceCount: len(group),
+		FirstSeen:       group[0].Timestamp,
+		LastSeen:        group[len(group)-1].Timestamp,
+		Occurrences:     make([]RecurringOccurrence, 0, len(group)),
+		Exceptions:      []RecurringException{},
+	}
+
+	// Walk the schedule from the first occurrence, an occurrence on time
+	// re-anchors it so drifting pay days do not pile up as misses
+	anchor := group[0].Timestamp
+	onSchedule := 0
+	for i, row := range group {
+		occurrence := RecurringOccurrence{
+			UploadID:   row.UploadID,
+			LineNumber: row.LineNumber,
+			Timestamp:  row.Timestamp,
+			Amount:     row.Amount,
+		}
+		series.Occurrences = append(series.Occurrences, occurrence)
+
+		if typical != 0 && math.Abs(float64(row.Amount-typical)) > math.Abs(float64(typical))*r.AmountTolerance {
+			series.Exceptions = append(series.Exceptions, RecurringException{
+				Kind:       RecurringExceptionUnusualAmount,
+				Occurrence: &occurrence,
+				Reason:     fmt.Sprintf("amount %d differs from the usual %d", row.Amount, typical),
+			})
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		expected := nextDue(interval, anchor)
+		for row.Timestamp > expected+tolerance {
+			series.Exceptions = append(series.Exceptions, missedOccurrence(expected))
+			expected = nextDue(interval, expected)
+		}
+
+		if row.Timestamp >= expected-tolerance {
+			onSchedule++
+			anchor = row.Timestamp
+			continue
+		}
+
+		series.Exceptions = append(series.Exceptions, RecurringException{
+			Kind:       RecurringExceptionOffSchedule,
+			Expected:   expected,
+			Occurrence: &occurrence,
+			Reason:     fmt.Sprintf("came %s before the due date", time.Duration(expected-row.Timestamp)*time.Second),
+		})
+	}
+
+	series.Regularity = math.Round(float64(onSchedule)/float64(len(group)-1)*100) / 100
+	if series.Regularity < r.MinRegularity {
+		return RecurringSeries{}, false
+	}
+
+	next := nextDue(interval, anchor)
+	for next+tolerance < asOf {
+		series.Exceptions = append(series.Exceptions, missedOccurrence(next))
+		series.Overdue = true
+		next = nextDue(interval, next)
+	}
+	series.NextExpected = next
+
+	return series, true
+}
+
+func matchInterval(days float64) (RecurrenceInterval, bool) {
+	for _, interval := range recurrenceIntervals {
+		if math.Abs(days-interval.days()) <= interval.Tolerance().Hours()/24 {
+			return interval, true
+		}
+	}
+	return "", false
+}
+
+func nextDue(interval RecurrenceInterval, from int64) int64 {
+	return interval.Next(time.Unix(from, 0).UTC()).Unix()
+}
+
+func missedOccurrence(expected int64) RecurringException {
+	return RecurringException{
+		Kind:     RecurringExceptionMissed,
+		Expected: expected,
+		Reason:   "no occurrence around the due date",
+	}
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Repository interface {
 	// Upload management
@@ -8,11 +11,131 @@ type Repository interface {
 	GetUpload(ctx context.Context, uploadID string) (*Upload, error)
 	UpdateUploadStatus(ctx context.Context, uploadID string, status UploadStatus) error
 	IncrementProcessedRows(ctx context.Context, uploadID string) error
+	SetUploadTotalRows(ctx context.Context, uploadID string, total int) error
+	MarkUploadReconciled(ctx context.Context, uploadID string) (bool, error)
+	SetUploadBalanceCheck(ctx context.Context, uploadID string, check BalanceCheck) error
+	SetUploadPeriod(ctx context.Context, uploadID string, period StatementPeriod) error
 
 	// Transaction operations
+	// AddTransaction rejects a reference the upload already stores with ErrDuplicateReference
 	AddTransaction(ctx context.Context, uploadID string, tx Transaction, lineNumber int) error
 	GetBalance(ctx context.Context, uploadID string) (int64, error)
+	GetBalanceAsOf(ctx context.Context, uploadID string, asOf int64) (int64, error)
+	GetBalanceSeries(ctx context.Context, uploadID string, interval SeriesInterval) ([]BalancePoint, error)
 	GetIssues(ctx context.Context, uploadID string, page, perPage int, status *TransactionStatus) ([]IssueTransaction, int, error)
+	QueryTransactions(ctx context.Context, query TransactionQuery) ([]IssueTransaction, int, error)
+	SeekTransactions(ctx context.Context, query TransactionQuery, cursor *Cursor) ([]IssueTransaction, bool, error)
+	FindTransactionByReference(ctx context.Context, reference string) (*ConsolidatedIssue, error)
+
+	// Issue workflow, every FAILED or PENDING row opens an issue when stored
+	GetIssue(ctx context.Context, uploadID string, lineNumber int) (*IssueDetail, error)
+	UpdateIssue(ctx context.Context, uploadID string, lineNumber int, update IssueUpdate) (*IssueDetail, error)
+
+	// Anomaly findings, a flagged row opens an issue like FAILED and PENDING rows
+	DetectAnomalies(ctx context.Context, uploadID string, row IssueTransaction, rules AnomalyRules, now time.Time) ([]Anomaly, error)
+	ListAnomalies(ctx context.Context, query AnomalyQuery) ([]Anomaly, error)
+
+	// Recurring series are detected on demand from the SUCCESS rows, the projection builds on them
+	DetectRecurring(ctx context.Context, query RecurringQuery, rules RecurringRules) ([]RecurringSeries, error)
+	ProjectBalance(ctx context.Context, query ProjectionQuery, recurring RecurringRules, now time.Time) (*Projection, error)
+
+	// Alert rules are evaluated per row as it is reconciled and per upload once
+	// reconciled, an alert is raised once per rule and row or upload
+	CreateAlertRule(ctx context.Context, rule AlertRule) error
+	GetAlertRule(ctx context.Context, ruleID string) (*AlertRule, error)
+	ListAlertRules(ctx context.Context) ([]AlertRule, error)
+	DeleteAlertRule(ctx context.Context, ruleID string) error
+	EvaluateRowAlerts(ctx context.Context, uploadID string, row IssueTransaction, now time.Time) ([]Alert, error)
+	EvaluateUploadAlerts(ctx context.Context, uploadID string, now time.Time) ([]Alert, error)
+	ListAlerts(ctx context.Context, query AlertQuery) ([]Alert, error)
+
+	// Watchlist screening, rows are screened while the file is parsed and every
+	// stored hit waits for a reviewer to clear it
+	CreateWatchlistEntry(ctx context.Context, entry WatchlistEntry) error
+	ListWatchlistEntries(ctx context.Context) ([]WatchlistEntry, error)
+	DeleteWatchlistEntry(ctx context.Context, entryID string) error
+	GetWatchlist(ctx context.Context) (*Watchlist, error)
+	SetUploadHoldForReview(ctx context.Context, uploadID string, hold bool) error
+	ListScreeningResults(ctx context.Context, uploadID string, state *ScreeningState) ([]ScreeningResult, error)
+	ClearScreeningResults(ctx context.Context, uploadID string, review ScreeningReview) ([]ScreeningResult, error)
+
+	// Webhook subscriptions and the log of every delivery made to them
+	CreateWebhook(ctx context.Context, webhook WebhookSubscription) error
+	GetWebhook(ctx context.Context, webhookID string) (*WebhookSubscription, error)
+	ListWebhooks(ctx context.Context) ([]WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	SaveWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error
+	ListWebhookDeliveries(ctx context.Context, query WebhookDeliveryQuery) ([]WebhookDelivery, error)
+
+	// Settlement of PENDING rows, every change is kept in the row's status history
+	UpdateTransactionStatus(ctx context.Context, uploadID string, update StatusUpdate) (*IssueTransaction, error)
+	GetStatusHistory(ctx context.Context, uploadID string, lineNumber int) ([]StatusChange, error)
+
+	// Balance rules, every balance and credit/debit total is computed with them
+	GetBalanceRules(ctx context.Context) (*BalanceRules, error)
+	SetBalanceRules(ctx context.Context, rules *BalanceRules) error
+
+	// Manual adjustments, an approved adjustment is stored as a row of its upload
+	CreateAdjustment(ctx context.Context, adjustment Adjustment) error
+	GetAdjustment(ctx context.Context, uploadID, adjustmentID string) (*Adjustment, error)
+	ListAdjustments(ctx context.Context, uploadID string, state *AdjustmentState) ([]Adjustment, error)
+	ReviewAdjustment(ctx context.Context, uploadID, adjustmentID string, review AdjustmentReview) (*Adjustment, error)
+
+	// Rejected rows
+	AddRejectedRow(ctx context.Context, uploadID string, row RejectedRow) error
+	GetRejectedRows(ctx context.Context, uploadID string, afterLine, limit int) ([]RejectedRow, bool, error)
+
+	// Aggregations
+	SumByStatus(ctx context.Context, uploadID string, status TransactionStatus) (AmountTotals, error)
+	CountByStatus(ctx context.Context, uploadID string) (map[TransactionStatus]int, error)
+	AggregateByCounterparty(ctx context.Context, query CounterpartyQuery) ([]CounterpartySummary, int, error)
+	SummarizeByPeriod(ctx context.Context, uploadID string, period SummaryPeriod, loc *time.Location) ([]PeriodSummary, error)
+
+	// Counterparty aliases, applied wherever rows are grouped by counterparty
+	SetCounterpartyAlias(ctx context.Context, alias CounterpartyAlias) (*CounterpartyAlias, error)
+	ListCounterpartyAliases(ctx context.Context) ([]CounterpartyAlias, error)
+	DeleteCounterpartyAlias(ctx context.Context, alias string) error
+	ListCounterpartyNames(ctx context.Context, uploadID string) ([]CounterpartyName, error)
+
+	// Category rules, the compiled rule set is rebuilt on every change
+	CreateCategoryRule(ctx context.Context, rule CategoryRule) error
+	GetCategoryRule(ctx context.Context, ruleID string) (*CategoryRule, error)
+	ListCategoryRules(ctx context.Context) ([]CategoryRule, error)
+	UpdateCategoryRule(ctx context.Context, rule CategoryRule) error
+	DeleteCategoryRule(ctx context.Context, ruleID string) error
+	GetRuleSet(ctx context.Context) (*RuleSet, error)
+	ApplyRuleSet(ctx context.Context, uploadID string, ruleSet *RuleSet) (int, error)
+	AggregateByCategory(ctx context.Context, uploadID string) ([]CategorySummary, error)
+
+	// Upload collections
+	CreateCollection(ctx context.Context, collection UploadCollection) error
+	GetCollection(ctx context.Context, collectionID string) (*UploadCollection, error)
+	UpdateCollection(ctx context.Context, collection UploadCollection) error
+	DeleteCollection(ctx context.Context, collectionID string) error
+
+	// Consolidation across uploads, overlapping rows are deduplicated by fingerprint
+	GetConsolidatedBalance(ctx context.Context, uploadIDs []string) (*ConsolidatedBalance, error)
+	GetConsolidatedIssues(ctx context.Context, uploadIDs []string, page, perPage int, status *TransactionStatus) ([]ConsolidatedIssue, int, error)
+
+	// Accounts, a row belongs to the account named in its CSV account column
+	// or, when that is empty, to the account its upload is attached to
+	CreateAccount(ctx context.Context, account Account) error
+	GetAccount(ctx context.Context, accountID string) (*Account, error)
+	FindAccountByNumber(ctx context.Context, accountNumber string) (*Account, error)
+	ListAccounts(ctx context.Context) ([]Account, error)
+	UpdateAccount(ctx context.Context, account Account) error
+	DeleteAccount(ctx context.Context, accountID string) error
+	AttachUpload(ctx context.Context, uploadID, accountID string) error
+	GetAccountBalance(ctx context.Context, accountID string, asOf *int64) (*AccountBalance, error)
+	GetAccountIssues(ctx context.Context, query AccountIssueQuery) ([]ConsolidatedIssue, int, error)
+	GetAccountUploadPeriods(ctx context.Context, accountID string) ([]UploadCoverage, error)
+
+	// Ledger reconciliation runs
+	CreateReconciliationRun(ctx context.Context, run ReconciliationRun) error
+	UpdateReconciliationRun(ctx context.Context, run ReconciliationRun) error
+	GetReconciliationRun(ctx context.Context, runID string) (*ReconciliationRun, error)
+	SaveReconciliationResults(ctx context.Context, runID string, results []ReconciliationResult) error
+	GetReconciliationResults(ctx context.Context, query ReconciliationResultQuery) ([]ReconciliationResult, int, error)
 
 	// Idempotency tracking
 	IsEventProcessed(ctx context.Context, eventID string) (bool, error)
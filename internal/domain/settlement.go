@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// statusTransitions lists the statuses a stored row may move to. PENDING rows
+// settle, SUCCESS rows can still be refunded, FAILED and REFUNDED are final.
+var statusTransitions = map[TransactionStatus][]TransactionStatus{
+	TransactionStatusPending: {TransactionStatusSuccess, TransactionStatusFailed},
+	TransactionStatusSuccess: {TransactionStatusRefunded},
+}
+
+func (s TransactionStatus) CanTransitionTo(next TransactionStatus) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type StatusUpdateSource string
+
+const (
+	StatusUpdateSourceAPI  StatusUpdateSource = "api"
+	StatusUpdateSourceFile StatusUpdateSource = "file"
+)
+
+// StatusUpdateMatch says how a status update file names its rows
+type StatusUpdateMatch string
+
+const (
+	StatusUpdateMatchLineNumber StatusUpdateMatch = "line_number"
+	StatusUpdateMatchReference  StatusUpdateMatch = "reference"
+)
+
+func (m StatusUpdateMatch) IsValid() bool {
+	return m == StatusUpdateMatchLineNumber || m == StatusUpdateMatchReference
+}
+
+// StatusUpdate settles the row of an upload at LineNumber or, when set, the
+// row carrying Reference
+type StatusUpdate struct {
+	LineNumber int                `json:"line_number,omitempty"`
+	Reference  string             `json:"reference,omitempty"`
+	Status     TransactionStatus  `json:"status"`
+	Reason     string             `json:"reason,omitempty"`
+	Source     StatusUpdateSource `json:"source"`
+}
+
+func (u *StatusUpdate) Normalize() {
+	u.Status = TransactionStatus(strings.ToUpper(strings.TrimSpace(string(u.Status))))
+	u.Reason = strings.TrimSpace(u.Reason)
+	u.Reference = strings.TrimSpace(u.Reference)
+}
+
+func (u StatusUpdate) Validate() error {
+	if u.LineNumber < 1 && u.Reference == "" {
+		return ErrInvalidStatusUpdate
+	}
+
+	if !u.Status.IsValid() || u.Status == TransactionStatusPending {
+		return ErrInvalidStatusUpdate
+	}
+
+	return nil
+}
+
+// StatusChange records one settlement of a row
+type StatusChange struct {
+	FromStatus TransactionStatus  `json:"from_status"`
+	ToStatus   TransactionStatus  `json:"to_status"`
+	Reason     string             `json:"reason,omitempty"`
+	Source     StatusUpdateSource `json:"source"`
+	ChangedAt  time.Time          `json:"changed_at"`
+}
+
+// StatusUpdateOutcome is the result of one line of a status update file
+type StatusUpdateOutcome struct {
+	FileLine   int               `json:"file_line"`
+	LineNumber int               `json:"line_number,omitempty"`
+	Reference  string            `json:"reference,omitempty"`
+	Status     TransactionStatus `json:"status,omitempty"`
+	Applied    bool              `json:"applied"`
+	Error      string            `json:"error,omitempty"`
+}
+
+type StatusUpdateReport struct {
+	UploadID string                `json:"upload_id"`
+	Applied  int                   `json:"applied"`
+	Rejected int                   `json:"rejected"`
+	Outcomes []StatusUpdateOutcome `json:"outcomes"`
+}
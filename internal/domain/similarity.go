@@ -0,0 +1,104 @@
+package domain
+
+import "strings"
+
+// JaroWinkler returns the Jaro-Winkler similarity of two strings, 1 for equal
+// strings and 0 when nothing matches. Common prefixes of up to four runes are
+// boosted with the standard 0.1 scaling factor.
+func JaroWinkler(a, b string) float64 {
+	runesA := []rune(a)
+	runesB := []rune(b)
+
+	if len(runesA) == 0 && len(runesB) == 0 {
+		return 1
+	}
+	if len(runesA) == 0 || len(runesB) == 0 {
+		return 0
+	}
+
+	window := max(len(runesA), len(runesB))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(runesA))
+	matchedB := make([]bool, len(runesB))
+	matches := 0
+	for i, r := range runesA {
+		lo := max(0, i-window)
+		hi := min(len(runesB), i+window+1)
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && runesB[j] == r {
+				matchedA[i] = true
+				matchedB[j] = true
+				matches++
+				break
+			}
+		}
+	}
+
+	if matches == 0 {
+		return 0
+	}
+
+	// Half the matched runes that appear in a different order
+	transpositions := 0
+	j := 0
+	for i := range runesA {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if runesA[i] != runesB[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(runesA)) + m/float64(len(runesB)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(runesA), len(runesB)) && runesA[prefix] == runesB[prefix] {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// initialsSimilarity is the score given to names that line up word for word
+// where every differing word is abbreviated to its initial, "J DOE" and
+// "JOHN DOE"
+const initialsSimilarity = 0.9
+
+// NameSimilarity scores two normalized names, the higher of Jaro-Winkler and
+// the initials rule
+func NameSimilarity(a, b string) float64 {
+	score := JaroWinkler(a, b)
+	if score < initialsSimilarity && initialsMatch(strings.Fields(a), strings.Fields(b)) {
+		return initialsSimilarity
+	}
+	return score
+}
+
+func initialsMatch(a, b []string) bool {
+	if len(a) != len(b) || len(a) < 2 {
+		return false
+	}
+
+	fullWords := 0
+	for i := range a {
+		switch {
+		case a[i] == b[i]:
+			fullWords++
+		case len(a[i]) == 1 && strings.HasPrefix(b[i], a[i]):
+		case len(b[i]) == 1 && strings.HasPrefix(a[i], b[i]):
+		default:
+			return false
+		}
+	}
+
+	return fullWords > 0
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+type SummaryPeriod string
+
+const (
+	SummaryPeriodDay   SummaryPeriod = "day"
+	SummaryPeriodWeek  SummaryPeriod = "week"
+	SummaryPeriodMonth SummaryPeriod = "month"
+)
+
+func (p SummaryPeriod) IsValid() bool {
+	return p == SummaryPeriodDay || p == SummaryPeriodWeek || p == SummaryPeriodMonth
+}
+
+// Start returns the beginning of the period containing t, in t's location.
+// Weeks start on Monday.
+func (p SummaryPeriod) Start(t time.Time) time.Time {
+	year, month, day := t.Date()
+	switch p {
+	case SummaryPeriodWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	case SummaryPeriodMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// Next returns the start of the period following the one starting at start
+func (p SummaryPeriod) Next(start time.Time) time.Time {
+	switch p {
+	case SummaryPeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case SummaryPeriodMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+type PeriodSummary struct {
+	PeriodStart    time.Time `json:"period_start"`
+	PeriodEnd      time.Time `json:"period_end"`
+	Credits        int64     `json:"credits"`
+	Debits         int64     `json:"debits"`
+	Net            int64     `json:"net"`
+	FailedCount    int       `json:"failed_count"`
+	PendingCount   int       `json:"pending_count"`
+	ClosingBalance int64     `json:"closing_balance"`
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// WatchlistMatchThreshold is the lowest name similarity reported as a fuzzy hit
+const WatchlistMatchThreshold = 0.88
+
+type WatchlistSource string
+
+const (
+	WatchlistSourceAPI  WatchlistSource = "api"
+	WatchlistSourceFile WatchlistSource = "file"
+)
+
+// WatchlistEntry is a blocked entity, stored under its normalized name
+type WatchlistEntry struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Reason    string          `json:"reason,omitempty"`
+	Source    WatchlistSource `json:"source"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (e *WatchlistEntry) Normalize() {
+	e.Name = NormalizeCounterparty(e.Name)
+	e.Reason = strings.TrimSpace(e.Reason)
+}
+
+func (e WatchlistEntry) Validate() error {
+	if e.Name == "" {
+		return ErrInvalidWatchlistEntry
+	}
+	return nil
+}
+
+type WatchlistMatch string
+
+const (
+	WatchlistMatchExact WatchlistMatch = "exact"
+	WatchlistMatchFuzzy WatchlistMatch = "fuzzy"
+)
+
+type WatchlistHit struct {
+	EntryID   string         `json:"entry_id"`
+	EntryName string         `json:"entry_name"`
+	Match     WatchlistMatch `json:"match"`
+	Score     float64        `json:"score"`
+}
+
+// Watchlist screens counterparties against every entry, built once per file
+// so the entries and aliases are read a single time
+type Watchlist struct {
+	entries []WatchlistEntry
+	aliases CounterpartyAliases
+}
+
+func NewWatchlist(entries []WatchlistEntry, aliases CounterpartyAliases) *Watchlist {
+	return &Watchlist{
+		entries: entries,
+		aliases: aliases,
+	}
+}
+
+// Screen returns the entries a counterparty matches, best first. Names sharing
+// a canonical form are exact hits, similar names are fuzzy hits.
+func (w *Watchlist) Screen(counterparty string) []WatchlistHit {
+	var hits []WatchlistHit
+	for _, entry := range w.entries {
+		score := CounterpartySimilarity(w.aliases, counterparty, entry.Name)
+		if score < WatchlistMatchThreshold {
+			continue
+		}
+
+		match := WatchlistMatchFuzzy
+		if score == 1 {
+			match = WatchlistMatchExact
+		}
+		hits = append(hits, WatchlistHit{
+			EntryID:   entry.ID,
+			EntryName: entry.Name,
+			Match:     match,
+			Score:     float64(int(score*100+0.5)) / 100,
+		})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+
+	return hits
+}
+
+type ScreeningState string
+
+const (
+	ScreeningStateOpen    ScreeningState = "open"
+	ScreeningStateCleared ScreeningState = "cleared"
+)
+
+func (s ScreeningState) IsValid() bool {
+	return s == ScreeningStateOpen || s == ScreeningStateCleared
+}
+
+// ScreeningResult is a row of an upload that hit the watchlist and its review
+type ScreeningResult struct {
+	UploadID     string         `json:"upload_id"`
+	LineNumber   int            `json:"line_number"`
+	Counterparty string         `json:"counterparty"`
+	Hits         []WatchlistHit `json:"hits"`
+	State        ScreeningState `json:"state"`
+	ClearedBy    string         `json:"cleared_by,omitempty"`
+	Note         string         `json:"note,omitempty"`
+	ClearedAt    *time.Time     `json:"cleared_at,omitempty"`
+}
+
+// ScreeningReview clears the listed rows, or every open row when none are listed
+type ScreeningReview struct {
+	Reviewer    string
+	Note        string
+	LineNumbers []int
+}
+
+func (r *ScreeningReview) Normalize() {
+	r.Reviewer = strings.TrimSpace(r.Reviewer)
+	r.Note = strings.TrimSpace(r.Note)
+}
+
+func (r ScreeningReview) Validate() error {
+	if r.Reviewer == "" {
+		return ErrInvalidScreeningReview
+	}
+	return nil
+}
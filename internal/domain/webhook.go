@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WebhookSignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of
+// the request body, keyed with the subscription secret
+const WebhookSignatureHeader = "X-Webhook-Signature"
+
+type WebhookEventType string
+
+const (
+	WebhookEventUploadCompleted   WebhookEventType = "upload.completed"
+	WebhookEventUploadNeedsReview WebhookEventType = "upload.needs_review"
+	WebhookEventUploadFailed      WebhookEventType = "upload.failed"
+	WebhookEventAlertTriggered    WebhookEventType = "alert.triggered"
+)
+
+func (t WebhookEventType) IsValid() bool {
+	switch t {
+	case WebhookEventUploadCompleted, WebhookEventUploadNeedsReview, WebhookEventUploadFailed, WebhookEventAlertTriggered:
+		return true
+	}
+	return false
+}
+
+// WebhookSubscription receives every event of its event types. The secret is
+// only accepted on creation and never returned.
+type WebhookSubscription struct {
+	ID         string             `json:"id"`
+	URL        string             `json:"url"`
+	Secret     string             `json:"-"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+func (s *WebhookSubscription) Normalize() {
+	s.URL = strings.TrimSpace(s.URL)
+	s.Secret = strings.TrimSpace(s.Secret)
+
+	seen := make(map[WebhookEventType]bool, len(s.EventTypes))
+	eventTypes := make([]WebhookEventType, 0, len(s.EventTypes))
+	for _, eventType := range s.EventTypes {
+		eventType = WebhookEventType(strings.ToLower(strings.TrimSpace(string(eventType))))
+		if eventType == "" || seen[eventType] {
+			continue
+		}
+		seen[eventType] = true
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Slice(eventTypes, func(i, j int) bool { return eventTypes[i] < eventTypes[j] })
+	s.EventTypes = eventTypes
+}
+
+func (s WebhookSubscription) Validate() error {
+	parsed, err := url.Parse(s.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidWebhook
+	}
+
+	if s.Secret == "" || len(s.EventTypes) == 0 {
+		return ErrInvalidWebhook
+	}
+
+	for _, eventType := range s.EventTypes {
+		if !eventType.IsValid() {
+			return ErrInvalidWebhook
+		}
+	}
+
+	return nil
+}
+
+func (s WebhookSubscription) Subscribes(eventType WebhookEventType) bool {
+	for _, candidate := range s.EventTypes {
+		if candidate == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent is the body POSTed to subscribers. Data is the upload for
+// upload events and the alert for alert.triggered.
+type WebhookEvent struct {
+	ID         string           `json:"id"`
+	Type       WebhookEventType `json:"type"`
+	UploadID   string           `json:"upload_id,omitempty"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       interface{}      `json:"data"`
+}
+
+// WebhookDispatcher delivers an event to every subscription of its type
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, event WebhookEvent) error
+}
+
+// SignWebhook returns the signature header value for a body
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks a signature header value in constant time
+func VerifyWebhook(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhook(secret, body)), []byte(signature))
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryFailed:
+		return true
+	}
+	return false
+}
+
+// WebhookDelivery is one event sent to one subscription. StatusCode and Error
+// describe the last attempt.
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	SubscriptionID string                `json:"subscription_id"`
+	EventID        string                `json:"event_id"`
+	EventType      WebhookEventType      `json:"event_type"`
+	URL            string                `json:"url"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	StatusCode     int                   `json:"status_code,omitempty"`
+	Error          string                `json:"error,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	CompletedAt    *time.Time            `json:"completed_at,omitempty"`
+}
+
+type WebhookDeliveryQuery struct {
+	SubscriptionID string
+	EventType      WebhookEventType
+	Status         WebhookDeliveryStatus
+}
+
+func (q WebhookDeliveryQuery) Validate() error {
+	if q.EventType != "" && !q.EventType.IsValid() {
+		return ErrInvalidQuery
+	}
+	if q.Status != "" && !q.Status.IsValid() {
+		return ErrInvalidQuery
+	}
+	return nil
+}
+
+func (q WebhookDeliveryQuery) Matches(delivery WebhookDelivery) bool {
+	if q.SubscriptionID != "" && delivery.SubscriptionID != q.SubscriptionID {
+		return false
+	}
+	if q.EventType != "" && delivery.EventType != q.EventType {
+		return false
+	}
+	if q.Status != "" && delivery.Status != q.Status {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+// AlertConsumer evaluates the alert rules. Per-row rules run on transaction
+// stored events and upload-wide rules on upload reconciled events, so it
+// subscribes to both. Each alert is stored once and only new alerts are notified.
+type AlertConsumer struct {
+	repo        domain.Repository
+	notifier    domain.Notifier
+	logger      *logger.Logger
+	workerCount int
+}
+
+func NewAlertConsumer(repo domain.Repository, notifier domain.Notifier, log *logger.Logger, workerCount int) *AlertConsumer {
+	return &AlertConsumer{
+		repo:        repo,
+		notifier:    notifier,
+		logger:      log,
+		workerCount: workerCount,
+	}
+}
+
+func (ac *AlertConsumer) Consume(ctx context.Context, event Event) error {
+	var (
+		alerts []domain.Alert
+		err    error
+	)
+
+	switch payload := event.Payload.(type) {
+	case TransactionStoredEvent:
+		ctx = logger.WithUploadID(ctx, payload.UploadID)
+		alerts, err = ac.repo.EvaluateRowAlerts(ctx, payload.UploadID, domain.IssueTransaction{
+			Transaction: payload.Transaction,
+			LineNumber:  payload.LineNumber,
+		}, time.Now())
+	case UploadReconciledEvent:
+		ctx = logger.WithUploadID(ctx, payload.UploadID)
+		alerts, err = ac.repo.EvaluateUploadAlerts(ctx, payload.UploadID, time.Now())
+	default:
+		ac.logger.Error(ctx, "Invalid payload type for alert evaluation",
+			"event_id", event.ID,
+		)
+		return fmt.Errorf("invalid payload type")
+	}
+	if err != nil {
+		ac.logger.Error(ctx, "Failed to evaluate alert rules",
+			"event_id", event.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	// The alerts are stored already, a failed delivery is logged rather than
+	// retried so the event does not raise them again
+	for _, alert := range alerts {
+		if err := ac.notifier.Notify(ctx, alert); err != nil {
+			ac.logger.Error(ctx, "Failed to notify alert",
+				"alert_id", alert.ID,
+				"error", err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (ac *AlertConsumer) GetWorkerCount() int {
+	return ac.workerCount
+}
@@ -0,0 +1,69 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+// AnomalyConsumer inspects every row the ReconciliationConsumer stored, rows
+// rejected as duplicates are never inspected. Detection is idempotent, a
+// retried event records nothing twice.
+type AnomalyConsumer struct {
+	repo        domain.Repository
+	logger      *logger.Logger
+	rules       domain.AnomalyRules
+	workerCount int
+}
+
+func NewAnomalyConsumer(repo domain.Repository, log *logger.Logger, rules domain.AnomalyRules, workerCount int) *AnomalyConsumer {
+	return &AnomalyConsumer{
+		repo:        repo,
+		logger:      log,
+		rules:       rules,
+		workerCount: workerCount,
+	}
+}
+
+func (ac *AnomalyConsumer) Consume(ctx context.Context, event Event) error {
+	payload, ok := event.Payload.(TransactionStoredEvent)
+	if !ok {
+		ac.logger.Error(ctx, "Invalid payload type for transaction stored event",
+			"event_id", event.ID,
+		)
+		return fmt.Errorf("invalid payload type")
+	}
+
+	ctx = logger.WithUploadID(ctx, payload.UploadID)
+
+	found, err := ac.repo.DetectAnomalies(ctx, payload.UploadID, domain.IssueTransaction{
+		Transaction: payload.Transaction,
+		LineNumber:  payload.LineNumber,
+	}, ac.rules, time.Now())
+	if err != nil {
+		ac.logger.Error(ctx, "Failed to detect anomalies",
+			"event_id", event.ID,
+			"line_number", payload.LineNumber,
+			"error", err,
+		)
+		return err
+	}
+
+	for _, anomaly := range found {
+		ac.logger.Warn(ctx, "Anomalous transaction",
+			"line_number", anomaly.LineNumber,
+			"kind", anomaly.Kind,
+			"score", anomaly.Score,
+			"reason", anomaly.Reason,
+		)
+	}
+
+	return nil
+}
+
+func (ac *AnomalyConsumer) GetWorkerCount() int {
+	return ac.workerCount
+}
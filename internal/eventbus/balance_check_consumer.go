@@ -0,0 +1,113 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+// BalanceCheckConsumer compares the declared closing balance of a reconciled
+// upload with the computed one, then publishes EventTypeUploadChecked so the
+// upload is announced with its check
+type BalanceCheckConsumer struct {
+	eventBus    EventBus
+	repo        domain.Repository
+	logger      *logger.Logger
+	workerCount int
+}
+
+func NewBalanceCheckConsumer(eventBus EventBus, repo domain.Repository, log *logger.Logger, workerCount int) *BalanceCheckConsumer {
+	return &BalanceCheckConsumer{
+		eventBus:    eventBus,
+		repo:        repo,
+		logger:      log,
+		workerCount: workerCount,
+	}
+}
+
+func (bc *BalanceCheckConsumer) Consume(ctx context.Context, event Event) error {
+	payload, ok := event.Payload.(UploadReconciledEvent)
+	if !ok {
+		bc.logger.Error(ctx, "Invalid payload type for upload reconciled event",
+			"event_id", event.ID,
+		)
+		return fmt.Errorf("invalid payload type")
+	}
+
+	ctx = logger.WithUploadID(ctx, payload.UploadID)
+
+	upload, err := bc.repo.GetUpload(ctx, payload.UploadID)
+	if err != nil {
+		bc.logger.Error(ctx, "Failed to get upload",
+			"event_id", event.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	if upload.BalanceCheck == nil {
+		bc.logger.Debug(ctx, "No declared balances, skipping balance check")
+		return bc.publishChecked(ctx, event, payload.UploadID)
+	}
+
+	balance, err := bc.repo.GetBalance(ctx, payload.UploadID)
+	if err != nil {
+		bc.logger.Error(ctx, "Failed to get balance",
+			"event_id", event.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	check := upload.BalanceCheck.Evaluate(balance, time.Now())
+	closing := *check.ComputedClosingBalance
+
+	if check.Matched != nil && !*check.Matched {
+		bc.logger.Warn(ctx, "Closing balance mismatch",
+			"expected_closing_balance", *check.ExpectedClosingBalance,
+			"computed_closing_balance", closing,
+			"difference", *check.Difference,
+		)
+	}
+
+	err = bc.repo.SetUploadBalanceCheck(ctx, payload.UploadID, check)
+	if err != nil {
+		bc.logger.Error(ctx, "Failed to store balance check",
+			"event_id", event.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	bc.logger.Info(ctx, "Balance check completed",
+		"computed_closing_balance", closing,
+		"matched", check.Matched,
+	)
+
+	return bc.publishChecked(ctx, event, payload.UploadID)
+}
+
+func (bc *BalanceCheckConsumer) publishChecked(ctx context.Context, event Event, uploadID string) error {
+	err := bc.eventBus.Publish(ctx, Event{
+		ID:   uploadID + "-checked",
+		Type: EventTypeUploadChecked,
+		Payload: UploadCheckedEvent{
+			UploadID: uploadID,
+		},
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		bc.logger.Error(ctx, "Failed to publish upload checked event",
+			"event_id", event.ID,
+			"error", err,
+		)
+	}
+	return err
+}
+
+func (bc *BalanceCheckConsumer) GetWorkerCount() int {
+	return bc.workerCount
+}
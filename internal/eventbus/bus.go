@@ -2,12 +2,16 @@ package eventbus
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	"github.com/grachmannico95/flip-test-be/pkg/logger"
 	"github.com/grachmannico95/flip-test-be/pkg/retry"
 )
 
+// ErrBusStopped is returned when an event is published after Shutdown
+var ErrBusStopped = errors.New("event bus stopped")
+
 type EventBus interface {
 	Publish(ctx context.Context, event Event) error
 	Subscribe(eventType EventType, consumer Consumer) error
@@ -15,9 +19,15 @@ type EventBus interface {
 	Shutdown(ctx context.Context) error
 }
 
+// subscription is one consumer with its own channel, every consumer of an
+// event type receives every event of that type
+type subscription struct {
+	consumer Consumer
+	ch       chan Event
+}
+
 type eventBus struct {
-	channels      map[EventType]chan Event
-	consumers     map[EventType][]Consumer
+	subscriptions map[EventType][]subscription
 	mu            sync.RWMutex
 	wg            sync.WaitGroup
 	ctx           context.Context
@@ -41,8 +51,7 @@ func New(log *logger.Logger, cfg *Config) EventBus {
 	}
 
 	return &eventBus{
-		channels:      make(map[EventType]chan Event),
-		consumers:     make(map[EventType][]Consumer),
+		subscriptions: make(map[EventType][]subscription),
 		logger:        log,
 		channelBuffer: cfg.ChannelBuffer,
 	}
@@ -52,11 +61,10 @@ func (eb *eventBus) Subscribe(eventType EventType, consumer Consumer) error {
 	eb.mu.Lock()
 	defer eb.mu.Unlock()
 
-	if _, exists := eb.channels[eventType]; !exists {
-		eb.channels[eventType] = make(chan Event, eb.channelBuffer)
-	}
-
-	eb.consumers[eventType] = append(eb.consumers[eventType], consumer)
+	eb.subscriptions[eventType] = append(eb.subscriptions[eventType], subscription{
+		consumer: consumer,
+		ch:       make(chan Event, eb.channelBuffer),
+	})
 
 	return nil
 }
@@ -71,11 +79,9 @@ func (eb *eventBus) Start(ctx context.Context) error {
 
 	eb.ctx, eb.cancel = context.WithCancel(ctx)
 
-	for eventType, consumers := range eb.consumers {
-		ch := eb.channels[eventType]
-
-		for _, consumer := range consumers {
-			workerCount := consumer.GetWorkerCount()
+	for eventType, subscriptions := range eb.subscriptions {
+		for _, sub := range subscriptions {
+			workerCount := sub.consumer.GetWorkerCount()
 			eb.logger.Info(eb.ctx, "Starting workers",
 				"event_type", eventType,
 				"worker_count", workerCount,
@@ -83,7 +89,7 @@ func (eb *eventBus) Start(ctx context.Context) error {
 
 			for i := 0; i < workerCount; i++ {
 				eb.wg.Add(1)
-				go eb.worker(eb.ctx, ch, consumer, i)
+				go eb.worker(eb.ctx, sub.ch, sub.consumer, i)
 			}
 		}
 	}
@@ -150,11 +156,19 @@ func (eb *eventBus) processEvent(ctx context.Context, event Event, consumer Cons
 }
 
 func (eb *eventBus) Publish(ctx context.Context, event Event) error {
+	// Blocks until every consumer has room for the event. Callers count a row
+	// as delivered only when Publish returns nil, so an event is never
+	// silently dropped.
+
 	eb.mu.RLock()
-	ch, exists := eb.channels[event.Type]
+	subscriptions := eb.subscriptions[event.Type]
+	var stopped <-chan struct{}
+	if eb.ctx != nil {
+		stopped = eb.ctx.Done()
+	}
 	eb.mu.RUnlock()
 
-	if !exists {
+	if len(subscriptions) == 0 {
 		eb.logger.Warn(ctx, "No channel for event type",
 			"event_type", event.Type,
 			"event_id", event.ID,
@@ -162,24 +176,25 @@ func (eb *eventBus) Publish(ctx context.Context, event Event) error {
 		return nil
 	}
 
-	// Non-blocking send
-	select {
-	case ch <- event:
-		eb.logger.Debug(ctx, "Event published",
-			"event_type", event.Type,
-			"event_id", event.ID,
-		)
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
-		// Channel is full, log warning but don't block
-		eb.logger.Warn(ctx, "Event channel full, event dropped",
-			"event_type", event.Type,
-			"event_id", event.ID,
-		)
-		return nil
+	for _, sub := range subscriptions {
+		select {
+		case sub.ch <- event:
+			eb.logger.Debug(ctx, "Event published",
+				"event_type", event.Type,
+				"event_id", event.ID,
+			)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-stopped:
+			eb.logger.Warn(ctx, "Event bus stopped, event not published",
+				"event_type", event.Type,
+				"event_id", event.ID,
+			)
+			return ErrBusStopped
+		}
 	}
+
+	return nil
 }
 
 func (eb *eventBus) Shutdown(ctx context.Context) error {
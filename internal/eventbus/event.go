@@ -9,7 +9,11 @@ import (
 type EventType string
 
 const (
-	EventTypeReconciliation EventType = "reconciliation"
+	EventTypeReconciliation    EventType = "reconciliation"
+	EventTypeTransactionStored EventType = "transaction_stored"
+	EventTypeUploadReconciled  EventType = "upload_reconciled"
+	EventTypeUploadChecked     EventType = "upload_checked"
+	EventTypeUploadFailed      EventType = "upload_failed"
 )
 
 type Event struct {
@@ -25,3 +29,25 @@ type ReconciliationEvent struct {
 	Transaction domain.Transaction `json:"transaction"`
 	LineNumber  int                `json:"line_number"`
 }
+
+// TransactionStoredEvent carries a row as it was stored, rows rejected by the
+// repository are not published
+type TransactionStoredEvent struct {
+	UploadID    string             `json:"upload_id"`
+	Transaction domain.Transaction `json:"transaction"`
+	LineNumber  int                `json:"line_number"`
+}
+
+type UploadReconciledEvent struct {
+	UploadID string `json:"upload_id"`
+}
+
+// UploadCheckedEvent follows UploadReconciledEvent once the balance check of
+// the upload is stored
+type UploadCheckedEvent struct {
+	UploadID string `json:"upload_id"`
+}
+
+type UploadFailedEvent struct {
+	UploadID string `json:"upload_id"`
+}
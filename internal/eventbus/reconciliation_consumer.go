@@ -3,19 +3,22 @@ package eventbus
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/grachmannico95/flip-test-be/internal/domain"
 	"github.com/grachmannico95/flip-test-be/pkg/logger"
 )
 
 type ReconciliationConsumer struct {
+	eventBus    EventBus
 	repo        domain.Repository
 	logger      *logger.Logger
 	workerCount int
 }
 
-func NewReconciliationConsumer(repo domain.Repository, log *logger.Logger, workerCount int) *ReconciliationConsumer {
+func NewReconciliationConsumer(eventBus EventBus, repo domain.Repository, log *logger.Logger, workerCount int) *ReconciliationConsumer {
 	return &ReconciliationConsumer{
+		eventBus:    eventBus,
 		repo:        repo,
 		logger:      log,
 		workerCount: workerCount,
@@ -58,7 +61,33 @@ func (rc *ReconciliationConsumer) Consume(ctx context.Context, event Event) erro
 		"amount", payload.Transaction.Amount,
 	)
 
-	err = rc.repo.AddTransaction(ctx, payload.UploadID, payload.Transaction, payload.LineNumber)
+	ruleSet, err := rc.repo.GetRuleSet(ctx)
+	if err != nil {
+		rc.logger.Error(ctx, "Failed to get category rules",
+			"event_id", event.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	tx := ruleSet.Categorize(payload.Transaction)
+
+	err = rc.repo.AddTransaction(ctx, payload.UploadID, tx, payload.LineNumber)
+	stored := err == nil
+	if err == domain.ErrDuplicateReference {
+		// The reader already rejects references repeated in a file, a row
+		// getting here is rejected but still counts towards reconciliation
+		rc.logger.Warn(ctx, "Duplicate transaction reference",
+			"event_id", event.ID,
+			"line_number", payload.LineNumber,
+			"reference", tx.Reference,
+		)
+
+		err = rc.repo.AddRejectedRow(ctx, payload.UploadID, domain.RejectedRow{
+			LineNumber: payload.LineNumber,
+			Reason:     fmt.Sprintf("duplicate reference: %s already stored", tx.Reference),
+		})
+	}
 	if err != nil {
 		rc.logger.Error(ctx, "Failed to add transaction",
 			"event_id", event.ID,
@@ -77,12 +106,40 @@ func (rc *ReconciliationConsumer) Consume(ctx context.Context, event Event) erro
 		return err
 	}
 
+	// Anomaly detection and row alerts only see rows that were stored
+	if stored {
+		err = rc.eventBus.Publish(ctx, Event{
+			ID:   event.ID + "-stored",
+			Type: EventTypeTransactionStored,
+			Payload: TransactionStoredEvent{
+				UploadID:    payload.UploadID,
+				Transaction: tx,
+				LineNumber:  payload.LineNumber,
+			},
+			Timestamp: time.Now(),
+		})
+		if err != nil {
+			rc.logger.Error(ctx, "Failed to publish transaction stored event",
+				"event_id", event.ID,
+				"error", err,
+			)
+		}
+	}
+
 	err = rc.repo.IncrementProcessedRows(ctx, payload.UploadID)
 	if err != nil {
 		rc.logger.Error(ctx, "Failed to increment processed rows",
 			"event_id", event.ID,
 			"error", err,
 		)
+	} else {
+		err = PublishIfReconciled(ctx, rc.eventBus, rc.repo, payload.UploadID)
+		if err != nil {
+			rc.logger.Error(ctx, "Failed to publish upload reconciled event",
+				"event_id", event.ID,
+				"error", err,
+			)
+		}
 	}
 
 	rc.logger.Debug(ctx, "Transaction processed successfully",
@@ -0,0 +1,32 @@
+package eventbus
+
+import (
+	"context"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+// PublishIfReconciled emits EventTypeUploadReconciled once the upload has been
+// fully parsed and every published row has been stored. Both the CSV processor
+// and the reconciliation workers call it; the repository guarantees only one
+// of them wins.
+func PublishIfReconciled(ctx context.Context, eventBus EventBus, repo domain.Repository, uploadID string) error {
+	reconciled, err := repo.MarkUploadReconciled(ctx, uploadID)
+	if err != nil {
+		return err
+	}
+
+	if !reconciled {
+		return nil
+	}
+
+	return eventBus.Publish(ctx, Event{
+		ID:   uploadID + "-reconciled",
+		Type: EventTypeUploadReconciled,
+		Payload: UploadReconciledEvent{
+			UploadID: uploadID,
+		},
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,88 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+// WebhookConsumer turns upload lifecycle events into webhook events. An upload
+// is sent as upload.completed once its balance check is stored, or as
+// upload.needs_review while it is held with open watchlist hits.
+type WebhookConsumer struct {
+	repo        domain.Repository
+	dispatcher  domain.WebhookDispatcher
+	logger      *logger.Logger
+	workerCount int
+}
+
+func NewWebhookConsumer(repo domain.Repository, dispatcher domain.WebhookDispatcher, log *logger.Logger, workerCount int) *WebhookConsumer {
+	return &WebhookConsumer{
+		repo:        repo,
+		dispatcher:  dispatcher,
+		logger:      log,
+		workerCount: workerCount,
+	}
+}
+
+func (wc *WebhookConsumer) Consume(ctx context.Context, event Event) error {
+	var (
+		uploadID  string
+		eventType domain.WebhookEventType
+	)
+
+	switch payload := event.Payload.(type) {
+	case UploadCheckedEvent:
+		uploadID = payload.UploadID
+		eventType = domain.WebhookEventUploadCompleted
+	case UploadFailedEvent:
+		uploadID = payload.UploadID
+		eventType = domain.WebhookEventUploadFailed
+	default:
+		wc.logger.Error(ctx, "Invalid payload type for webhook delivery",
+			"event_id", event.ID,
+		)
+		return fmt.Errorf("invalid payload type")
+	}
+
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	upload, err := wc.repo.GetUpload(ctx, uploadID)
+	if err != nil {
+		wc.logger.Error(ctx, "Failed to get upload for webhook delivery",
+			"event_id", event.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	if eventType == domain.WebhookEventUploadCompleted && upload.Status == domain.UploadStatusNeedsReview {
+		eventType = domain.WebhookEventUploadNeedsReview
+	}
+
+	// Deliveries retry on their own, a failure is logged rather than returned
+	// so subscribers that succeeded are not sent the event again
+	err = wc.dispatcher.Dispatch(ctx, domain.WebhookEvent{
+		ID:         fmt.Sprintf("%s-%s", uploadID, eventType),
+		Type:       eventType,
+		UploadID:   uploadID,
+		OccurredAt: time.Now(),
+		Data:       *upload,
+	})
+	if err != nil {
+		wc.logger.Error(ctx, "Failed to deliver webhook event",
+			"event_id", event.ID,
+			"event_type", eventType,
+			"error", err,
+		)
+	}
+
+	return nil
+}
+
+func (wc *WebhookConsumer) GetWorkerCount() int {
+	return wc.workerCount
+}
@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultGapTolerance ignores the usual space between the last row of one
+// statement and the first row of the next
+const defaultGapTolerance = 24 * time.Hour
+
+type AccountHandler struct {
+	service service.AccountService
+	logger  *logger.Logger
+}
+
+type accountRequest struct {
+	AccountNumber string `json:"account_number"`
+	Bank          string `json:"bank"`
+	Currency      string `json:"currency"`
+	Owner         string `json:"owner"`
+}
+
+type attachUploadRequest struct {
+	UploadID string `json:"upload_id"`
+}
+
+func NewAccountHandler(service service.AccountService, log *logger.Logger) *AccountHandler {
+	return &AccountHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (r accountRequest) toAccount() domain.Account {
+	return domain.Account{
+		AccountNumber: r.AccountNumber,
+		Bank:          r.Bank,
+		Currency:      r.Currency,
+		Owner:         r.Owner,
+	}
+}
+
+func (h *AccountHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req accountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	account, err := h.service.CreateAccount(ctx, req.toAccount())
+	if err != nil {
+		return h.accountError(c, err, "failed to create account")
+	}
+
+	return c.JSON(http.StatusCreated, account)
+}
+
+func (h *AccountHandler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	accounts, err := h.service.ListAccounts(ctx)
+	if err != nil {
+		return h.accountError(c, err, "failed to list accounts")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"items": accounts,
+		"total": len(accounts),
+	})
+}
+
+func (h *AccountHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	account, err := h.service.GetAccount(ctx, c.Param("id"))
+	if err != nil {
+		return h.accountError(c, err, "failed to get account")
+	}
+
+	return c.JSON(http.StatusOK, account)
+}
+
+func (h *AccountHandler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req accountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	account, err := h.service.UpdateAccount(ctx, c.Param("id"), req.toAccount())
+	if err != nil {
+		return h.accountError(c, err, "failed to update account")
+	}
+
+	return c.JSON(http.StatusOK, account)
+}
+
+func (h *AccountHandler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.service.DeleteAccount(ctx, c.Param("id"))
+	if err != nil {
+		return h.accountError(c, err, "failed to delete account")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *AccountHandler) AttachUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req attachUploadRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	uploadID := strings.TrimSpace(req.UploadID)
+	if uploadID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "upload_id is required",
+		})
+	}
+
+	err := h.service.AttachUpload(ctx, c.Param("id"), uploadID)
+	if err != nil {
+		return h.accountError(c, err, "failed to attach upload")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *AccountHandler) GetBalance(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var asOf *int64
+	if asOfParam := c.QueryParam("as_of"); asOfParam != "" {
+		ts, err := parseTimestamp(asOfParam)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "as_of must be a unix timestamp or RFC3339 time",
+			})
+		}
+		asOf = &ts
+	}
+
+	balance, err := h.service.GetAccountBalance(ctx, c.Param("id"), asOf)
+	if err != nil {
+		return h.accountError(c, err, "failed to get account balance")
+	}
+
+	return c.JSON(http.StatusOK, balance)
+}
+
+func (h *AccountHandler) GetIssues(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	query := domain.AccountIssueQuery{
+		AccountID: c.Param("id"),
+	}
+
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	query.Page = page
+
+	perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+	query.PerPage = perPage
+
+	statusParam := c.QueryParam("status")
+	if statusParam != "" {
+		status := domain.TransactionStatus(statusParam)
+		if status != domain.TransactionStatusFailed && status != domain.TransactionStatusPending {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "status must be FAILED or PENDING",
+			})
+		}
+		query.Status = &status
+	}
+
+	if from := c.QueryParam("from"); from != "" {
+		ts, err := parseTimestamp(from)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "from must be a unix timestamp or RFC3339 time",
+			})
+		}
+		query.From = &ts
+	}
+
+	if to := c.QueryParam("to"); to != "" {
+		ts, err := parseTimestamp(to)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "to must be a unix timestamp or RFC3339 time",
+			})
+		}
+		query.To = &ts
+	}
+
+	issues, total, err := h.service.GetAccountIssues(ctx, query)
+	if err != nil {
+		return h.accountError(c, err, "failed to get account issues")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"account_id": query.AccountID,
+		"items":      issues,
+		"page":       page,
+		"per_page":   perPage,
+		"total":      total,
+	})
+}
+
+func (h *AccountHandler) GetCoverage(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	gapTolerance := defaultGapTolerance
+	if value := c.QueryParam("gap_tolerance"); value != "" {
+		parsed, err := parseDuration(value)
+		if err != nil || parsed < 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "gap_tolerance must be a duration such as 36h or a number of seconds",
+			})
+		}
+		gapTolerance = parsed
+	}
+
+	coverage, err := h.service.GetAccountCoverage(ctx, c.Param("id"), gapTolerance)
+	if err != nil {
+		return h.accountError(c, err, "failed to get account coverage")
+	}
+
+	return c.JSON(http.StatusOK, coverage)
+}
+
+func (h *AccountHandler) accountError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrAccountNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "account not found",
+		})
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrInvalidAccount:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "account_number, bank, owner and a 3-letter currency are required",
+		})
+	case domain.ErrInvalidQuery:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "from must not be after to",
+		})
+	case domain.ErrDuplicateAccount:
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "account number already registered",
+		})
+	case domain.ErrAccountInUse:
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "account has uploads attached",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
+
+// parseDuration accepts a Go duration, a number of days such as 30d or a
+// plain number of seconds
+func parseDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if days, found := strings.CutSuffix(value, "d"); found {
+		if n, err := strconv.ParseInt(days, 10, 64); err == nil {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	return time.ParseDuration(value)
+}
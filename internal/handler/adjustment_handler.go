@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type AdjustmentHandler struct {
+	service service.AdjustmentService
+	logger  *logger.Logger
+}
+
+type adjustmentRequest struct {
+	Timestamp    int64  `json:"timestamp"`
+	Counterparty string `json:"counterparty"`
+	Type         string `json:"type"`
+	Amount       int64  `json:"amount"`
+	Description  string `json:"description"`
+	ProposedBy   string `json:"proposed_by"`
+}
+
+type adjustmentReviewRequest struct {
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+}
+
+func NewAdjustmentHandler(service service.AdjustmentService, log *logger.Logger) *AdjustmentHandler {
+	return &AdjustmentHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (h *AdjustmentHandler) Propose(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req adjustmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	adjustment, err := h.service.Propose(ctx, c.Param("id"), domain.Adjustment{
+		Timestamp:    req.Timestamp,
+		Counterparty: req.Counterparty,
+		Type:         domain.TransactionType(req.Type),
+		Amount:       req.Amount,
+		Description:  req.Description,
+		ProposedBy:   req.ProposedBy,
+	})
+	if err != nil {
+		return h.adjustmentError(c, err, "failed to propose adjustment")
+	}
+
+	return c.JSON(http.StatusCreated, adjustment)
+}
+
+func (h *AdjustmentHandler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var state *domain.AdjustmentState
+	if value := c.QueryParam("state"); value != "" {
+		parsed := domain.AdjustmentState(strings.ToLower(value))
+		state = &parsed
+	}
+
+	adjustments, err := h.service.ListAdjustments(ctx, c.Param("id"), state)
+	if err != nil {
+		return h.adjustmentError(c, err, "failed to list adjustments")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"items": adjustments,
+		"total": len(adjustments),
+	})
+}
+
+func (h *AdjustmentHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	adjustment, err := h.service.GetAdjustment(ctx, c.Param("id"), c.Param("adjustment_id"))
+	if err != nil {
+		return h.adjustmentError(c, err, "failed to get adjustment")
+	}
+
+	return c.JSON(http.StatusOK, adjustment)
+}
+
+func (h *AdjustmentHandler) Approve(c echo.Context) error {
+	return h.review(c, true)
+}
+
+func (h *AdjustmentHandler) Reject(c echo.Context) error {
+	return h.review(c, false)
+}
+
+func (h *AdjustmentHandler) review(c echo.Context, approve bool) error {
+	ctx := c.Request().Context()
+
+	var req adjustmentReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	adjustment, err := h.service.Review(ctx, c.Param("id"), c.Param("adjustment_id"), domain.AdjustmentReview{
+		Approve: approve,
+		Actor:   req.Actor,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		return h.adjustmentError(c, err, "failed to review adjustment")
+	}
+
+	return c.JSON(http.StatusOK, adjustment)
+}
+
+func (h *AdjustmentHandler) adjustmentError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrAdjustmentNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "adjustment not found",
+		})
+	case domain.ErrInvalidAdjustment:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "an adjustment needs proposed_by, a type other than REVERSAL and a non-zero amount (negative only for ADJUSTMENT), a review needs an actor and a rejection a reason",
+		})
+	case domain.ErrInvalidQuery:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "state must be proposed, approved or rejected",
+		})
+	case domain.ErrUploadNotReconciled:
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "upload is still being processed",
+		})
+	case domain.ErrAdjustmentReviewed:
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "adjustment already reviewed",
+		})
+	case domain.ErrSelfApproval:
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "adjustment must be approved by someone other than its proposer",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type AlertHandler struct {
+	service service.AlertService
+	logger  *logger.Logger
+}
+
+type alertRuleRequest struct {
+	Name           string   `json:"name"`
+	Kind           string   `json:"kind"`
+	Amount         int64    `json:"amount"`
+	Ratio          float64  `json:"ratio"`
+	Counterparties []string `json:"counterparties"`
+}
+
+func NewAlertHandler(service service.AlertService, log *logger.Logger) *AlertHandler {
+	return &AlertHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (r alertRuleRequest) toRule() domain.AlertRule {
+	return domain.AlertRule{
+		Name:           r.Name,
+		Kind:           domain.AlertRuleKind(r.Kind),
+		Amount:         r.Amount,
+		Ratio:          r.Ratio,
+		Counterparties: r.Counterparties,
+	}
+}
+
+func (h *AlertHandler) CreateRule(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req alertRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	rule, err := h.service.CreateRule(ctx, req.toRule())
+	if err != nil {
+		return h.alertError(c, err, "failed to create alert rule")
+	}
+
+	return c.JSON(http.StatusCreated, rule)
+}
+
+func (h *AlertHandler) ListRules(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	rules, err := h.service.ListRules(ctx)
+	if err != nil {
+		return h.alertError(c, err, "failed to list alert rules")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"items": rules,
+		"total": len(rules),
+	})
+}
+
+func (h *AlertHandler) GetRule(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	rule, err := h.service.GetRule(ctx, c.Param("id"))
+	if err != nil {
+		return h.alertError(c, err, "failed to get alert rule")
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *AlertHandler) DeleteRule(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.service.DeleteRule(ctx, c.Param("id"))
+	if err != nil {
+		return h.alertError(c, err, "failed to delete alert rule")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *AlertHandler) ListAlerts(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	query := domain.AlertQuery{
+		UploadID: c.QueryParam("upload_id"),
+		RuleID:   c.QueryParam("rule_id"),
+	}
+	for _, kind := range splitList(c.QueryParam("kind")) {
+		query.Kinds = append(query.Kinds, domain.AlertRuleKind(strings.ToLower(kind)))
+	}
+
+	alerts, err := h.service.ListAlerts(ctx, query)
+	if err != nil {
+		return h.alertError(c, err, "failed to list alerts")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"items": alerts,
+		"total": len(alerts),
+	})
+}
+
+func (h *AlertHandler) alertError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrAlertRuleNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "alert rule not found",
+		})
+	case domain.ErrInvalidAlertRule:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "a rule needs a name and a kind of balance_below, debit_above with a positive amount, counterparty_watchlist with counterparties or failed_ratio_above with a ratio from 0 to below 1",
+		})
+	case domain.ErrInvalidQuery:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "kind must be balance_below, debit_above, counterparty_watchlist or failed_ratio_above",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
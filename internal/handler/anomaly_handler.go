@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type AnomalyHandler struct {
+	service service.AnomalyService
+	logger  *logger.Logger
+}
+
+func NewAnomalyHandler(service service.AnomalyService, log *logger.Logger) *AnomalyHandler {
+	return &AnomalyHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (h *AnomalyHandler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("id")
+	query := domain.AnomalyQuery{UploadID: uploadID}
+
+	for _, kind := range splitList(c.QueryParam("kind")) {
+		query.Kinds = append(query.Kinds, domain.AnomalyKind(strings.ToLower(kind)))
+	}
+
+	if value := c.QueryParam("min_score"); value != "" {
+		score, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "min_score must be a number between 0 and 1",
+			})
+		}
+		query.MinScore = score
+	}
+
+	anomalies, err := h.service.ListAnomalies(ctx, query)
+	if err != nil {
+		return h.anomalyError(c, err, "failed to list anomalies")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id": uploadID,
+		"items":     anomalies,
+		"total":     len(anomalies),
+	})
+}
+
+func (h *AnomalyHandler) anomalyError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrInvalidQuery:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "kind must be amount_outlier, burst, future_timestamp, stale_timestamp or structuring and min_score between 0 and 1",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type BalanceRuleHandler struct {
+	service service.BalanceRuleService
+	logger  *logger.Logger
+}
+
+type balanceRulesRequest struct {
+	Rules []domain.BalanceRule `json:"rules"`
+}
+
+func NewBalanceRuleHandler(service service.BalanceRuleService, log *logger.Logger) *BalanceRuleHandler {
+	return &BalanceRuleHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (h *BalanceRuleHandler) GetRules(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	rules, err := h.service.GetRules(ctx)
+	if err != nil {
+		return h.balanceRuleError(c, err, "failed to get balance rules")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"items": rules,
+		"total": len(rules),
+	})
+}
+
+func (h *BalanceRuleHandler) ReplaceRules(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req balanceRulesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	rules, err := h.service.ReplaceRules(ctx, req.Rules)
+	if err != nil {
+		return h.balanceRuleError(c, err, "failed to replace balance rules")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"items": rules,
+		"total": len(rules),
+	})
+}
+
+func (h *BalanceRuleHandler) balanceRuleError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrInvalidBalanceRule:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "each rule needs a known type and status and an effect of add or subtract, inverse is for REVERSAL only",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
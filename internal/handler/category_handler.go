@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type CategoryHandler struct {
+	service service.CategoryService
+	logger  *logger.Logger
+}
+
+type categoryRuleRequest struct {
+	Name               string   `json:"name"`
+	Priority           int      `json:"priority"`
+	DescriptionPattern string   `json:"description_pattern"`
+	Counterparty       string   `json:"counterparty"`
+	Type               string   `json:"type"`
+	MinAmount          *int64   `json:"min_amount"`
+	MaxAmount          *int64   `json:"max_amount"`
+	Category           string   `json:"category"`
+	Tags               []string `json:"tags"`
+}
+
+func NewCategoryHandler(service service.CategoryService, log *logger.Logger) *CategoryHandler {
+	return &CategoryHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (r categoryRuleRequest) toRule() domain.CategoryRule {
+	return domain.CategoryRule{
+		Name:               r.Name,
+		Priority:           r.Priority,
+		DescriptionPattern: r.DescriptionPattern,
+		Counterparty:       r.Counterparty,
+		Type:               domain.TransactionType(r.Type),
+		MinAmount:          r.MinAmount,
+		MaxAmount:          r.MaxAmount,
+		Category:           r.Category,
+		Tags:               r.Tags,
+	}
+}
+
+func (h *CategoryHandler) CreateRule(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req categoryRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	rule, err := h.service.CreateRule(ctx, req.toRule())
+	if err != nil {
+		return h.categoryError(c, err, "failed to create category rule")
+	}
+
+	return c.JSON(http.StatusCreated, rule)
+}
+
+func (h *CategoryHandler) ListRules(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	rules, err := h.service.ListRules(ctx)
+	if err != nil {
+		return h.categoryError(c, err, "failed to list category rules")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"items": rules,
+		"total": len(rules),
+	})
+}
+
+func (h *CategoryHandler) GetRule(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	rule, err := h.service.GetRule(ctx, c.Param("id"))
+	if err != nil {
+		return h.categoryError(c, err, "failed to get category rule")
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *CategoryHandler) UpdateRule(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req categoryRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	rule, err := h.service.UpdateRule(ctx, c.Param("id"), req.toRule())
+	if err != nil {
+		return h.categoryError(c, err, "failed to update category rule")
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *CategoryHandler) DeleteRule(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.service.DeleteRule(ctx, c.Param("id"))
+	if err != nil {
+		return h.categoryError(c, err, "failed to delete category rule")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CategoryHandler) ApplyRules(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("id")
+
+	changed, err := h.service.ApplyRules(ctx, uploadID)
+	if err != nil {
+		return h.categoryError(c, err, "failed to apply category rules")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id": uploadID,
+		"changed":   changed,
+	})
+}
+
+func (h *CategoryHandler) GetCategories(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("id")
+
+	summaries, err := h.service.GetCategories(ctx, uploadID)
+	if err != nil {
+		return h.categoryError(c, err, "failed to get categories")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id": uploadID,
+		"items":     summaries,
+	})
+}
+
+func (h *CategoryHandler) categoryError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrCategoryRuleNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "category rule not found",
+		})
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrInvalidCategoryRule:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "a rule needs a category, at least one condition, a valid description_pattern regex, type CREDIT or DEBIT and min_amount not above max_amount",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type CollectionHandler struct {
+	service service.CollectionService
+	logger  *logger.Logger
+}
+
+type collectionRequest struct {
+	Name      string   `json:"name"`
+	UploadIDs []string `json:"upload_ids"`
+}
+
+func NewCollectionHandler(service service.CollectionService, log *logger.Logger) *CollectionHandler {
+	return &CollectionHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (h *CollectionHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req collectionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	collection, err := h.service.CreateCollection(ctx, req.Name, req.UploadIDs)
+	if err != nil {
+		return h.collectionError(c, err, "failed to create collection")
+	}
+
+	return c.JSON(http.StatusCreated, collection)
+}
+
+func (h *CollectionHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	collection, err := h.service.GetCollection(ctx, c.Param("id"))
+	if err != nil {
+		return h.collectionError(c, err, "failed to get collection")
+	}
+
+	return c.JSON(http.StatusOK, collection)
+}
+
+func (h *CollectionHandler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req collectionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	collection, err := h.service.UpdateCollection(ctx, c.Param("id"), req.Name, req.UploadIDs)
+	if err != nil {
+		return h.collectionError(c, err, "failed to update collection")
+	}
+
+	return c.JSON(http.StatusOK, collection)
+}
+
+func (h *CollectionHandler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.service.DeleteCollection(ctx, c.Param("id"))
+	if err != nil {
+		return h.collectionError(c, err, "failed to delete collection")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CollectionHandler) GetConsolidatedBalance(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadIDs, err := h.resolveUploadIDs(c)
+	if err != nil {
+		return h.collectionError(c, err, "failed to get collection")
+	}
+
+	balance, err := h.service.GetConsolidatedBalance(ctx, uploadIDs)
+	if err != nil {
+		return h.collectionError(c, err, "failed to get consolidated balance")
+	}
+
+	return c.JSON(http.StatusOK, balance)
+}
+
+func (h *CollectionHandler) GetConsolidatedIssues(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadIDs, err := h.resolveUploadIDs(c)
+	if err != nil {
+		return h.collectionError(c, err, "failed to get collection")
+	}
+
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+
+	var statusFilter *domain.TransactionStatus
+	statusParam := c.QueryParam("status")
+	if statusParam != "" {
+		status := domain.TransactionStatus(statusParam)
+		if status == domain.TransactionStatusFailed || status == domain.TransactionStatusPending {
+			statusFilter = &status
+		} else {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "status must be FAILED or PENDING",
+			})
+		}
+	}
+
+	issues, total, err := h.service.GetConsolidatedIssues(ctx, uploadIDs, page, perPage, statusFilter)
+	if err != nil {
+		return h.collectionError(c, err, "failed to get consolidated issues")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_ids": uploadIDs,
+		"items":      issues,
+		"page":       page,
+		"per_page":   perPage,
+		"total":      total,
+	})
+}
+
+// resolveUploadIDs reads either a stored collection or an explicit upload_ids list
+func (h *CollectionHandler) resolveUploadIDs(c echo.Context) ([]string, error) {
+	collectionID := c.QueryParam("collection_id")
+	if collectionID == "" {
+		return splitList(c.QueryParam("upload_ids")), nil
+	}
+
+	collection, err := h.service.GetCollection(c.Request().Context(), collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.UploadIDs, nil
+}
+
+func (h *CollectionHandler) collectionError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrCollectionNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "collection not found",
+		})
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrInvalidCollection:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "name and at least one upload id are required",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
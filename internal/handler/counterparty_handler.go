@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultMergeThreshold keeps "J DOE" next to "JOHN DOE" while leaving most
+// unrelated names with a shared surname out
+const defaultMergeThreshold = 0.88
+
+type CounterpartyHandler struct {
+	service service.CounterpartyService
+	logger  *logger.Logger
+}
+
+type aliasRequest struct {
+	Alias     string `json:"alias"`
+	Canonical string `json:"canonical"`
+}
+
+func NewCounterpartyHandler(service service.CounterpartyService, log *logger.Logger) *CounterpartyHandler {
+	return &CounterpartyHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (h *CounterpartyHandler) SetAlias(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req aliasRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	alias, err := h.service.SetAlias(ctx, req.Alias, req.Canonical)
+	if err != nil {
+		return h.counterpartyError(c, err, "failed to set counterparty alias")
+	}
+
+	return c.JSON(http.StatusOK, alias)
+}
+
+func (h *CounterpartyHandler) ListAliases(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	aliases, err := h.service.ListAliases(ctx)
+	if err != nil {
+		return h.counterpartyError(c, err, "failed to list counterparty aliases")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"items": aliases,
+		"total": len(aliases),
+	})
+}
+
+func (h *CounterpartyHandler) DeleteAlias(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	alias, err := url.PathUnescape(c.Param("alias"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid alias",
+		})
+	}
+
+	err = h.service.DeleteAlias(ctx, alias)
+	if err != nil {
+		return h.counterpartyError(c, err, "failed to delete counterparty alias")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CounterpartyHandler) GetMergeSuggestions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	threshold := defaultMergeThreshold
+	if value := c.QueryParam("threshold"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "threshold must be a number between 0 and 1",
+			})
+		}
+		threshold = parsed
+	}
+
+	suggestions, err := h.service.SuggestMerges(ctx, c.QueryParam("upload_id"), threshold)
+	if err != nil {
+		return h.counterpartyError(c, err, "failed to suggest counterparty merges")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"threshold": threshold,
+		"items":     suggestions,
+		"total":     len(suggestions),
+	})
+}
+
+func (h *CounterpartyHandler) counterpartyError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrAliasNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "counterparty alias not found",
+		})
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrInvalidAlias:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "alias and canonical must be different non-empty names",
+		})
+	case domain.ErrInvalidQuery:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "threshold must be a number between 0 and 1",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
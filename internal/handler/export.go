@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+type exportFormat string
+
+const (
+	exportFormatCSV    exportFormat = "csv"
+	exportFormatJSON   exportFormat = "json"
+	exportFormatNDJSON exportFormat = "ndjson"
+)
+
+func (f exportFormat) isValid() bool {
+	return f == exportFormatCSV || f == exportFormatJSON || f == exportFormatNDJSON
+}
+
+func (f exportFormat) contentType() string {
+	switch f {
+	case exportFormatCSV:
+		return "text/csv"
+	case exportFormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/json"
+	}
+}
+
+type exportDataset string
+
+const (
+	exportDatasetIssues       exportDataset = "issues"
+	exportDatasetTransactions exportDataset = "transactions"
+	exportDatasetRejections   exportDataset = "rejections"
+)
+
+func (d exportDataset) isValid() bool {
+	return d == exportDatasetIssues || d == exportDatasetTransactions || d == exportDatasetRejections
+}
+
+var (
+	transactionExportHeader = []string{"line_number", "timestamp", "counterparty", "type", "amount", "status", "description", "reference", "account_id", "category", "tags", "reversal_of", "source"}
+	rejectionExportHeader   = []string{"line_number", "reason", "raw"}
+)
+
+// exportWriter streams rows in one of the export formats. Rows are written as
+// they arrive, nothing is buffered beyond the underlying writer.
+type exportWriter struct {
+	format  exportFormat
+	out     io.Writer
+	csv     *csv.Writer
+	json    *json.Encoder
+	written int
+}
+
+func newExportWriter(format exportFormat, out io.Writer, header []string) (*exportWriter, error) {
+	w := &exportWriter{
+		format: format,
+		out:    out,
+	}
+
+	switch format {
+	case exportFormatCSV:
+		w.csv = csv.NewWriter(out)
+		return w, w.csv.Write(header)
+	case exportFormatJSON:
+		w.json = json.NewEncoder(out)
+		_, err := io.WriteString(out, "[")
+		return w, err
+	default:
+		w.json = json.NewEncoder(out)
+		return w, nil
+	}
+}
+
+func (w *exportWriter) writeRecord(record []string, value interface{}) error {
+	defer func() { w.written++ }()
+
+	switch w.format {
+	case exportFormatCSV:
+		return w.csv.Write(record)
+	case exportFormatJSON:
+		if w.written > 0 {
+			if _, err := io.WriteString(w.out, ","); err != nil {
+				return err
+			}
+		}
+		return w.json.Encode(value)
+	default:
+		return w.json.Encode(value)
+	}
+}
+
+func (w *exportWriter) writeTransaction(tx domain.IssueTransaction) error {
+	return w.writeRecord([]string{
+		strconv.Itoa(tx.LineNumber),
+		strconv.FormatInt(tx.Timestamp, 10),
+		tx.Counterparty,
+		string(tx.Type),
+		strconv.FormatInt(tx.Amount, 10),
+		string(tx.Status),
+		tx.Description,
+		tx.Reference,
+		tx.AccountID,
+		tx.Category,
+		strings.Join(tx.Tags, "|"),
+		reversedReference(tx.Transaction),
+		string(domain.SourceOf(tx.Transaction)),
+	}, tx)
+}
+
+func reversedReference(tx domain.Transaction) string {
+	if tx.ReversalOf == nil {
+		return ""
+	}
+	return tx.ReversalOf.Reference
+}
+
+func (w *exportWriter) writeRejectedRow(row domain.RejectedRow) error {
+	return w.writeRecord([]string{
+		strconv.Itoa(row.LineNumber),
+		row.Reason,
+		row.Raw,
+	}, row)
+}
+
+func (w *exportWriter) close() error {
+	switch w.format {
+	case exportFormatCSV:
+		w.csv.Flush()
+		return w.csv.Error()
+	case exportFormatJSON:
+		_, err := io.WriteString(w.out, "]\n")
+		return err
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type IssueHandler struct {
+	service service.IssueService
+	logger  *logger.Logger
+}
+
+type issueUpdateRequest struct {
+	State    *string `json:"state"`
+	Assignee *string `json:"assignee"`
+	Note     string  `json:"note"`
+	Actor    string  `json:"actor"`
+}
+
+func NewIssueHandler(service service.IssueService, log *logger.Logger) *IssueHandler {
+	return &IssueHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (h *IssueHandler) GetIssue(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("upload_id")
+	lineNumber, err := strconv.Atoi(c.Param("line_number"))
+	if err != nil || lineNumber < 1 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "line_number must be a positive integer",
+		})
+	}
+
+	issue, err := h.service.GetIssue(ctx, uploadID, lineNumber)
+	if err != nil {
+		return h.issueError(c, err, "failed to get issue")
+	}
+
+	return c.JSON(http.StatusOK, issue)
+}
+
+func (h *IssueHandler) UpdateIssue(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("upload_id")
+	lineNumber, err := strconv.Atoi(c.Param("line_number"))
+	if err != nil || lineNumber < 1 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "line_number must be a positive integer",
+		})
+	}
+
+	var req issueUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	update := domain.IssueUpdate{
+		Assignee: req.Assignee,
+		Note:     req.Note,
+		Actor:    req.Actor,
+	}
+	if req.State != nil {
+		state := domain.IssueState(*req.State)
+		update.State = &state
+	}
+
+	issue, err := h.service.UpdateIssue(ctx, uploadID, lineNumber, update)
+	if err != nil {
+		return h.issueError(c, err, "failed to update issue")
+	}
+
+	return c.JSON(http.StatusOK, issue)
+}
+
+func (h *IssueHandler) issueError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrIssueNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "no FAILED or PENDING row at that line",
+		})
+	case domain.ErrInvalidIssueUpdate:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "give a state (open, investigating, resolved, wont_fix), an assignee or a note",
+		})
+	case domain.ErrInvalidIssueTransition:
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "issue state transition not allowed, closed issues can only be reopened",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type ProjectionHandler struct {
+	service service.ProjectionService
+	logger  *logger.Logger
+}
+
+func NewProjectionHandler(service service.ProjectionService, log *logger.Logger) *ProjectionHandler {
+	return &ProjectionHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (h *ProjectionHandler) Project(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	query := domain.ProjectionQuery{
+		UploadID: c.Param("id"),
+		Horizon:  domain.DefaultProjectionHorizon,
+	}
+
+	if value := c.QueryParam("horizon"); value != "" {
+		horizon, err := parseDuration(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "horizon must be a number of days such as 30d or a duration",
+			})
+		}
+		query.Horizon = horizon
+	}
+
+	if value := c.QueryParam("settle_probability"); value != "" {
+		probability, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "settle_probability must be a number between 0 and 1",
+			})
+		}
+		query.SettleProbability = &probability
+	}
+
+	projection, err := h.service.ProjectBalance(ctx, query)
+	if err != nil {
+		return h.projectionError(c, err, "failed to project balance")
+	}
+
+	return c.JSON(http.StatusOK, projection)
+}
+
+func (h *ProjectionHandler) projectionError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrInvalidQuery:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "horizon must be between 1 second and 366 days and settle_probability between 0 and 1",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultDateTolerance = 48 * time.Hour
+	defaultMinSimilarity = 0.75
+)
+
+type ReconciliationHandler struct {
+	service service.ReconciliationService
+	logger  *logger.Logger
+}
+
+func NewReconciliationHandler(service service.ReconciliationService, log *logger.Logger) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (h *ReconciliationHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := strings.TrimSpace(c.FormValue("upload_id"))
+	if uploadID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "upload_id is required",
+		})
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "file is required",
+		})
+	}
+
+	opts := domain.ReconciliationOptions{
+		DateTolerance: int64(defaultDateTolerance / time.Second),
+		MinSimilarity: defaultMinSimilarity,
+	}
+
+	if value := c.FormValue("date_tolerance"); value != "" {
+		tolerance, err := parseDuration(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "date_tolerance must be a duration such as 48h or a number of seconds",
+			})
+		}
+		opts.DateTolerance = int64(tolerance / time.Second)
+	}
+
+	if value := c.FormValue("min_similarity"); value != "" {
+		similarity, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "min_similarity must be a number between 0 and 1",
+			})
+		}
+		opts.MinSimilarity = similarity
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		h.logger.Error(ctx, "Failed to open file",
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to open file",
+		})
+	}
+	defer src.Close()
+
+	run, err := h.service.StartRun(ctx, uploadID, src, opts)
+	if err != nil {
+		return h.reconciliationError(c, err, "failed to start reconciliation run")
+	}
+
+	return c.JSON(http.StatusAccepted, run)
+}
+
+func (h *ReconciliationHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	run, err := h.service.GetRun(ctx, c.Param("id"))
+	if err != nil {
+		return h.reconciliationError(c, err, "failed to get reconciliation run")
+	}
+
+	return c.JSON(http.StatusOK, run)
+}
+
+func (h *ReconciliationHandler) GetResults(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	query := domain.ReconciliationResultQuery{
+		RunID: c.Param("id"),
+	}
+
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	query.Page = page
+
+	perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+	query.PerPage = perPage
+
+	if value := c.QueryParam("classification"); value != "" {
+		classification := domain.MatchClassification(strings.ToLower(value))
+		query.Classification = &classification
+	}
+
+	results, total, err := h.service.GetResults(ctx, query)
+	if err != nil {
+		return h.reconciliationError(c, err, "failed to get reconciliation results")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"run_id":   query.RunID,
+		"items":    results,
+		"page":     page,
+		"per_page": perPage,
+		"total":    total,
+	})
+}
+
+func (h *ReconciliationHandler) reconciliationError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrReconciliationRunNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "reconciliation run not found",
+		})
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrUploadNotReconciled:
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "upload is still being processed",
+		})
+	case domain.ErrUploadNeedsReview:
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "upload is held until its watchlist hits are cleared",
+		})
+	case domain.ErrInvalidLedger:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "ledger rows must be timestamp,counterparty,type,amount,description[,reference]",
+		})
+	case domain.ErrInvalidReconciliationOptions:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "date_tolerance must not be negative and min_similarity must be between 0 and 1",
+		})
+	case domain.ErrInvalidQuery:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "classification must be matched, unmatched_bank, unmatched_ledger or amount_mismatch",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type RecurringHandler struct {
+	service service.RecurringService
+	logger  *logger.Logger
+}
+
+func NewRecurringHandler(service service.RecurringService, log *logger.Logger) *RecurringHandler {
+	return &RecurringHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (h *RecurringHandler) ListForUpload(c echo.Context) error {
+	uploadID := c.Param("id")
+	return h.list(c, domain.RecurringQuery{UploadID: uploadID}, "upload_id", uploadID)
+}
+
+func (h *RecurringHandler) ListForAccount(c echo.Context) error {
+	accountID := c.Param("id")
+	return h.list(c, domain.RecurringQuery{AccountID: accountID}, "account_id", accountID)
+}
+
+func (h *RecurringHandler) list(c echo.Context, query domain.RecurringQuery, scopeKey, scopeID string) error {
+	ctx := c.Request().Context()
+
+	if asOfParam := c.QueryParam("as_of"); asOfParam != "" {
+		ts, err := parseTimestamp(asOfParam)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "as_of must be a unix timestamp or RFC3339 time",
+			})
+		}
+		query.AsOf = &ts
+	}
+
+	series, err := h.service.DetectRecurring(ctx, query)
+	if err != nil {
+		return h.recurringError(c, err, "failed to detect recurring series")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		scopeKey: scopeID,
+		"items":  series,
+		"total":  len(series),
+	})
+}
+
+func (h *RecurringHandler) recurringError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrAccountNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "account not found",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type SettlementHandler struct {
+	service service.SettlementService
+	logger  *logger.Logger
+}
+
+type statusUpdateRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+func NewSettlementHandler(service service.SettlementService, log *logger.Logger) *SettlementHandler {
+	return &SettlementHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (h *SettlementHandler) UpdateStatus(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	lineNumber, err := strconv.Atoi(c.Param("line_number"))
+	if err != nil || lineNumber < 1 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "line_number must be a positive integer",
+		})
+	}
+
+	var req statusUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	tx, err := h.service.UpdateStatus(ctx, c.Param("id"), domain.StatusUpdate{
+		LineNumber: lineNumber,
+		Status:     domain.TransactionStatus(req.Status),
+		Reason:     req.Reason,
+		Source:     domain.StatusUpdateSourceAPI,
+	})
+	if err != nil {
+		return h.settlementError(c, err, "failed to update transaction status")
+	}
+
+	return c.JSON(http.StatusOK, tx)
+}
+
+func (h *SettlementHandler) UpdateStatusByReference(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	reference, err := url.PathUnescape(c.Param("reference"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid reference",
+		})
+	}
+
+	var req statusUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	tx, err := h.service.UpdateStatusByReference(ctx, domain.StatusUpdate{
+		Reference: reference,
+		Status:    domain.TransactionStatus(req.Status),
+		Reason:    req.Reason,
+		Source:    domain.StatusUpdateSourceAPI,
+	})
+	if err != nil {
+		return h.settlementError(c, err, "failed to update transaction status")
+	}
+
+	return c.JSON(http.StatusOK, tx)
+}
+
+func (h *SettlementHandler) ApplyStatusFile(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "file is required",
+		})
+	}
+
+	matchBy := domain.StatusUpdateMatchLineNumber
+	if value := c.FormValue("match_by"); value != "" {
+		matchBy = domain.StatusUpdateMatch(strings.ToLower(value))
+		if !matchBy.IsValid() {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "match_by must be line_number or reference",
+			})
+		}
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		h.logger.Error(ctx, "Failed to open file",
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to open file",
+		})
+	}
+	defer src.Close()
+
+	report, err := h.service.ApplyStatusFile(ctx, c.Param("id"), src, matchBy)
+	if err != nil {
+		return h.settlementError(c, err, "failed to apply status updates")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+func (h *SettlementHandler) GetStatusHistory(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("id")
+	lineNumber, err := strconv.Atoi(c.Param("line_number"))
+	if err != nil || lineNumber < 1 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "line_number must be a positive integer",
+		})
+	}
+
+	history, err := h.service.GetStatusHistory(ctx, uploadID, lineNumber)
+	if err != nil {
+		return h.settlementError(c, err, "failed to get status history")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id":   uploadID,
+		"line_number": lineNumber,
+		"items":       history,
+	})
+}
+
+func (h *SettlementHandler) settlementError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrTransactionNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "transaction not found",
+		})
+	case domain.ErrInvalidStatusUpdate:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "status must be SUCCESS, FAILED or REFUNDED",
+		})
+	case domain.ErrInvalidStatusTransition:
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "only PENDING transactions can be settled and only SUCCESS transactions refunded",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
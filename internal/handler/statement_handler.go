@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/grachmannico95/flip-test-be/internal/domain"
 	"github.com/grachmannico95/flip-test-be/internal/service"
@@ -48,8 +53,58 @@ func (h *StatementHandler) Upload(c echo.Context) error {
 	}
 	defer src.Close()
 
-	uploadID, err := h.service.UploadStatement(ctx, src)
+	openingBalance, err := parseOptionalInt64(c.FormValue("opening_balance"))
 	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "opening_balance must be an integer",
+		})
+	}
+
+	expectedClosingBalance, err := parseOptionalInt64(c.FormValue("expected_closing_balance"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "expected_closing_balance must be an integer",
+		})
+	}
+
+	period, err := parseStatementPeriod(c.FormValue("period_start"), c.FormValue("period_end"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	var holdForReview bool
+	if value := c.FormValue("hold_for_review"); value != "" {
+		holdForReview, err = strconv.ParseBool(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "hold_for_review must be true or false",
+			})
+		}
+	}
+
+	opts := domain.UploadOptions{
+		Period:                 period,
+		AccountID:              strings.TrimSpace(c.FormValue("account_id")),
+		OpeningBalance:         openingBalance,
+		ExpectedClosingBalance: expectedClosingBalance,
+		HoldForReview:          holdForReview,
+	}
+
+	uploadID, err := h.service.UploadStatement(ctx, src, opts)
+	if err != nil {
+		if err == domain.ErrAccountNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "account not found",
+			})
+		}
+		if err == domain.ErrInvalidStatementPeriod {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "period_start must not be after period_end",
+			})
+		}
+
 		h.logger.Error(ctx, "Failed to upload statement",
 			"error", err,
 		)
@@ -68,6 +123,31 @@ func (h *StatementHandler) Upload(c echo.Context) error {
 	})
 }
 
+func (h *StatementHandler) GetUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("id")
+
+	upload, err := h.service.GetUploadStatus(ctx, uploadID)
+	if err != nil {
+		if err == domain.ErrUploadNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "upload not found",
+			})
+		}
+
+		h.logger.Error(ctx, "Failed to get upload",
+			"upload_id", uploadID,
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get upload",
+		})
+	}
+
+	return c.JSON(http.StatusOK, upload)
+}
+
 func (h *StatementHandler) GetBalance(c echo.Context) error {
 	ctx := c.Request().Context()
 
@@ -78,11 +158,29 @@ func (h *StatementHandler) GetBalance(c echo.Context) error {
 		})
 	}
 
+	var asOf *int64
+	if asOfParam := c.QueryParam("as_of"); asOfParam != "" {
+		ts, err := parseTimestamp(asOfParam)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "as_of must be a unix timestamp or RFC3339 time",
+			})
+		}
+		asOf = &ts
+	}
+
 	h.logger.Debug(ctx, "Getting balance",
 		"upload_id", uploadID,
+		"as_of", asOf,
 	)
 
-	balance, err := h.service.GetBalance(ctx, uploadID)
+	var balance int64
+	var err error
+	if asOf != nil {
+		balance, err = h.service.GetBalanceAsOf(ctx, uploadID, *asOf)
+	} else {
+		balance, err = h.service.GetBalance(ctx, uploadID)
+	}
 	if err != nil {
 		if err == domain.ErrUploadNotFound {
 			return c.JSON(http.StatusNotFound, map[string]string{
@@ -99,9 +197,475 @@ func (h *StatementHandler) GetBalance(c echo.Context) error {
 		})
 	}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{
+	response := map[string]interface{}{
 		"upload_id": uploadID,
 		"balance":   balance,
+	}
+	if asOf != nil {
+		response["as_of"] = *asOf
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *StatementHandler) GetTransactionByReference(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	reference, err := url.PathUnescape(c.Param("reference"))
+	if err != nil || strings.TrimSpace(reference) == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid reference",
+		})
+	}
+
+	tx, err := h.service.GetTransactionByReference(ctx, strings.TrimSpace(reference))
+	if err != nil {
+		if err == domain.ErrTransactionNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "transaction not found",
+			})
+		}
+
+		h.logger.Error(ctx, "Failed to get transaction by reference",
+			"reference", reference,
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get transaction",
+		})
+	}
+
+	return c.JSON(http.StatusOK, tx)
+}
+
+func (h *StatementHandler) GetTransactions(c echo.Context) error {
+	uploadID := c.QueryParam("upload_id")
+	if uploadID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "upload_id is required",
+		})
+	}
+
+	query, err := parseTransactionQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	query.UploadID = uploadID
+
+	cursor, cursorMode, err := parseCursor(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid cursor",
+		})
+	}
+	if cursorMode {
+		return h.seekTransactions(c, query, cursor)
+	}
+
+	return h.queryTransactions(c, query)
+}
+
+func (h *StatementHandler) queryTransactions(c echo.Context, query domain.TransactionQuery) error {
+	ctx := c.Request().Context()
+
+	uploadID := query.UploadID
+
+	h.logger.Debug(ctx, "Querying transactions",
+		"upload_id", uploadID,
+		"page", query.Page,
+		"per_page", query.PerPage,
+	)
+
+	transactions, total, err := h.service.QueryTransactions(ctx, query)
+	if err != nil {
+		if err == domain.ErrUploadNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "upload not found",
+			})
+		}
+		if err == domain.ErrInvalidQuery {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "invalid query parameters",
+			})
+		}
+
+		h.logger.Error(ctx, "Failed to query transactions",
+			"upload_id", uploadID,
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to query transactions",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id": uploadID,
+		"items":     transactions,
+		"page":      query.Page,
+		"per_page":  query.PerPage,
+		"total":     total,
+	})
+}
+
+func (h *StatementHandler) seekTransactions(c echo.Context, query domain.TransactionQuery, cursor *domain.Cursor) error {
+	ctx := c.Request().Context()
+
+	h.logger.Debug(ctx, "Seeking transactions",
+		"upload_id", query.UploadID,
+		"per_page", query.PerPage,
+	)
+
+	page, err := h.service.SeekTransactions(ctx, query, cursor)
+	if err != nil {
+		if err == domain.ErrUploadNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "upload not found",
+			})
+		}
+		if err == domain.ErrInvalidCursor {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "invalid cursor",
+			})
+		}
+		if err == domain.ErrInvalidQuery {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "invalid query parameters, cursor pagination supports timestamp and line_number sort only",
+			})
+		}
+
+		h.logger.Error(ctx, "Failed to seek transactions",
+			"upload_id", query.UploadID,
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get transactions",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id":   query.UploadID,
+		"items":       page.Items,
+		"per_page":    query.PerPage,
+		"next_cursor": page.NextCursor,
+		"prev_cursor": page.PrevCursor,
+	})
+}
+
+func (h *StatementHandler) GetSummary(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("id")
+
+	period := domain.SummaryPeriodDay
+	if periodParam := c.QueryParam("period"); periodParam != "" {
+		period = domain.SummaryPeriod(periodParam)
+	}
+	if !period.IsValid() {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "period must be day, week or month",
+		})
+	}
+
+	loc := time.UTC
+	if tz := c.QueryParam("tz"); tz != "" {
+		var err error
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "tz must be an IANA time zone such as Asia/Jakarta",
+			})
+		}
+	}
+
+	h.logger.Debug(ctx, "Getting summary",
+		"upload_id", uploadID,
+		"period", period,
+		"timezone", loc.String(),
+	)
+
+	summaries, err := h.service.GetPeriodSummary(ctx, uploadID, period, loc)
+	if err != nil {
+		if err == domain.ErrUploadNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "upload not found",
+			})
+		}
+
+		h.logger.Error(ctx, "Failed to get summary",
+			"upload_id", uploadID,
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get summary",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id": uploadID,
+		"period":    period,
+		"timezone":  loc.String(),
+		"items":     summaries,
+	})
+}
+
+func (h *StatementHandler) GetCounterparties(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("id")
+
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+
+	query := domain.CounterpartyQuery{
+		UploadID:  uploadID,
+		SortBy:    domain.CounterpartySortField(c.QueryParam("sort")),
+		SortOrder: domain.SortOrder(strings.ToLower(c.QueryParam("order"))),
+		Page:      page,
+		PerPage:   perPage,
+	}
+
+	h.logger.Debug(ctx, "Getting counterparties",
+		"upload_id", uploadID,
+		"page", page,
+		"per_page", perPage,
+	)
+
+	summaries, total, err := h.service.GetCounterparties(ctx, query)
+	if err != nil {
+		if err == domain.ErrUploadNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "upload not found",
+			})
+		}
+		if err == domain.ErrInvalidQuery {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "sort must be counterparty, total_credit, total_debit, transaction_count or last_transaction_at",
+			})
+		}
+
+		h.logger.Error(ctx, "Failed to get counterparties",
+			"upload_id", uploadID,
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get counterparties",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id": uploadID,
+		"items":     summaries,
+		"page":      page,
+		"per_page":  perPage,
+		"total":     total,
+	})
+}
+
+func (h *StatementHandler) Export(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("id")
+
+	dataset := exportDatasetTransactions
+	if datasetParam := c.QueryParam("dataset"); datasetParam != "" {
+		dataset = exportDataset(datasetParam)
+	}
+	if !dataset.isValid() {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "dataset must be issues, transactions or rejections",
+		})
+	}
+
+	format := exportFormatCSV
+	if formatParam := c.QueryParam("format"); formatParam != "" {
+		format = exportFormat(formatParam)
+	}
+	if !format.isValid() {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "format must be csv, json or ndjson",
+		})
+	}
+
+	query, err := parseTransactionQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	query.UploadID = uploadID
+
+	if dataset == exportDatasetIssues {
+		for _, status := range query.Statuses {
+			if status != domain.TransactionStatusFailed && status != domain.TransactionStatusPending {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": "status must be FAILED or PENDING",
+				})
+			}
+		}
+		if len(query.Statuses) == 0 {
+			query.Statuses = []domain.TransactionStatus{domain.TransactionStatusFailed, domain.TransactionStatusPending}
+		}
+	}
+
+	// Everything that can fail must be checked before the body starts streaming
+	if _, err := query.CursorSortField(); err != nil || query.Validate() != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid query parameters, export supports timestamp and line_number sort only",
+		})
+	}
+
+	_, err = h.service.GetUploadStatus(ctx, uploadID)
+	if err != nil {
+		if err == domain.ErrUploadNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "upload not found",
+			})
+		}
+
+		h.logger.Error(ctx, "Failed to get upload",
+			"upload_id", uploadID,
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to export",
+		})
+	}
+
+	filename := sanitizeFilename(c.QueryParam("filename"))
+	if filename == "" {
+		filename = fmt.Sprintf("%s-%s", uploadID, dataset)
+	}
+
+	h.logger.Info(ctx, "Exporting upload",
+		"upload_id", uploadID,
+		"dataset", dataset,
+		"format", format,
+	)
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, format.contentType())
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename+"."+string(format)))
+	res.WriteHeader(http.StatusOK)
+
+	header := transactionExportHeader
+	if dataset == exportDatasetRejections {
+		header = rejectionExportHeader
+	}
+
+	writer, err := newExportWriter(format, res, header)
+	if err == nil {
+		if dataset == exportDatasetRejections {
+			err = h.service.ExportRejectedRows(ctx, uploadID, writer.writeRejectedRow)
+		} else {
+			err = h.service.ExportTransactions(ctx, query, writer.writeTransaction)
+		}
+	}
+	if err == nil {
+		err = writer.close()
+	}
+
+	// The status line is already sent, a failure can only truncate the body
+	if err != nil {
+		h.logger.Error(ctx, "Export aborted",
+			"upload_id", uploadID,
+			"dataset", dataset,
+			"error", err,
+		)
+		return nil
+	}
+
+	res.Flush()
+
+	return nil
+}
+
+func (h *StatementHandler) GetBalanceSeries(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("id")
+
+	interval := domain.SeriesIntervalDay
+	if intervalParam := c.QueryParam("interval"); intervalParam != "" {
+		interval = domain.SeriesInterval(intervalParam)
+	}
+	if !interval.IsValid() {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "interval must be hour or day",
+		})
+	}
+
+	h.logger.Debug(ctx, "Getting balance series",
+		"upload_id", uploadID,
+		"interval", interval,
+	)
+
+	series, err := h.service.GetBalanceSeries(ctx, uploadID, interval)
+	if err != nil {
+		if err == domain.ErrUploadNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "upload not found",
+			})
+		}
+
+		h.logger.Error(ctx, "Failed to get balance series",
+			"upload_id", uploadID,
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get balance series",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id": uploadID,
+		"interval":  interval,
+		"items":     series,
+	})
+}
+
+func (h *StatementHandler) GetBalanceBreakdown(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.QueryParam("upload_id")
+	if uploadID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "upload_id is required",
+		})
+	}
+
+	h.logger.Debug(ctx, "Getting balance breakdown",
+		"upload_id", uploadID,
+	)
+
+	breakdown, err := h.service.GetBalanceBreakdown(ctx, uploadID)
+	if err != nil {
+		if err == domain.ErrUploadNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "upload not found",
+			})
+		}
+
+		h.logger.Error(ctx, "Failed to get balance breakdown",
+			"upload_id", uploadID,
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get balance breakdown",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id": uploadID,
+		"breakdown": breakdown,
 	})
 }
 
@@ -138,6 +702,61 @@ func (h *StatementHandler) GetIssues(c echo.Context) error {
 		}
 	}
 
+	var issueStates []domain.IssueState
+	for _, state := range splitList(c.QueryParam("state")) {
+		issueState := domain.IssueState(strings.ToLower(state))
+		if !issueState.IsValid() {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "state must be open, investigating, resolved or wont_fix",
+			})
+		}
+		issueStates = append(issueStates, issueState)
+	}
+	assignee := strings.TrimSpace(c.QueryParam("assignee"))
+
+	var issueKinds []domain.IssueKind
+	for _, kind := range splitList(c.QueryParam("kind")) {
+		issueKind := domain.IssueKind(strings.ToLower(kind))
+		if !issueKind.IsValid() {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "kind must be status or anomaly",
+			})
+		}
+		issueKinds = append(issueKinds, issueKind)
+	}
+
+	// Workflow filters and cursors go through the generic transaction query
+	query := domain.TransactionQuery{
+		UploadID:    uploadID,
+		Statuses:    []domain.TransactionStatus{domain.TransactionStatusFailed, domain.TransactionStatusPending},
+		IssueStates: issueStates,
+		Assignee:    assignee,
+		IssueKinds:  issueKinds,
+		Page:        page,
+		PerPage:     perPage,
+	}
+	if len(issueKinds) > 0 {
+		// Flagged rows can have any status
+		query.Statuses = nil
+	}
+	if statusFilter != nil {
+		query.Statuses = []domain.TransactionStatus{*statusFilter}
+	}
+
+	cursor, cursorMode, err := parseCursor(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid cursor",
+		})
+	}
+	if cursorMode {
+		return h.seekTransactions(c, query, cursor)
+	}
+
+	if len(issueStates) > 0 || assignee != "" || len(issueKinds) > 0 {
+		return h.queryTransactions(c, query)
+	}
+
 	h.logger.Debug(ctx, "Getting issues",
 		"upload_id", uploadID,
 		"page", page,
@@ -170,3 +789,168 @@ func (h *StatementHandler) GetIssues(c echo.Context) error {
 		"total":     total,
 	})
 }
+
+// parseTimestamp accepts unix seconds (as used in statement files) or RFC3339
+func parseTimestamp(value string) (int64, error) {
+	if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return ts, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Unix(), nil
+}
+
+func parseOptionalInt64(value string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
+
+// parseStatementPeriod reads the optional declared statement period, both ends
+// are required once either is given
+func parseStatementPeriod(startValue, endValue string) (*domain.StatementPeriod, error) {
+	if startValue == "" && endValue == "" {
+		return nil, nil
+	}
+
+	if startValue == "" || endValue == "" {
+		return nil, errors.New("period_start and period_end must be given together")
+	}
+
+	start, err := parseTimestamp(startValue)
+	if err != nil {
+		return nil, errors.New("period_start must be a unix timestamp or RFC3339 time")
+	}
+
+	end, err := parseTimestamp(endValue)
+	if err != nil {
+		return nil, errors.New("period_end must be a unix timestamp or RFC3339 time")
+	}
+
+	return &domain.StatementPeriod{Start: start, End: end}, nil
+}
+
+// parseTransactionQuery reads the filter, sort and pagination parameters shared
+// by the transaction listing endpoints. The upload is left for the caller.
+func parseTransactionQuery(c echo.Context) (domain.TransactionQuery, error) {
+	query := domain.TransactionQuery{
+		Counterparty: strings.TrimSpace(c.QueryParam("counterparty")),
+		Description:  strings.TrimSpace(c.QueryParam("description")),
+		SortBy:       domain.SortField(c.QueryParam("sort")),
+		SortOrder:    domain.SortOrder(strings.ToLower(c.QueryParam("order"))),
+	}
+
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	query.Page = page
+
+	perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+	query.PerPage = perPage
+
+	for _, status := range splitList(c.QueryParam("status")) {
+		query.Statuses = append(query.Statuses, domain.TransactionStatus(strings.ToUpper(status)))
+	}
+
+	for _, category := range splitList(c.QueryParam("category")) {
+		query.Categories = append(query.Categories, strings.ToLower(category))
+	}
+
+	for _, txType := range splitList(c.QueryParam("type")) {
+		query.Types = append(query.Types, domain.TransactionType(strings.ToUpper(txType)))
+	}
+
+	for _, state := range splitList(c.QueryParam("state")) {
+		query.IssueStates = append(query.IssueStates, domain.IssueState(strings.ToLower(state)))
+	}
+	query.Assignee = strings.TrimSpace(c.QueryParam("assignee"))
+
+	for _, source := range splitList(c.QueryParam("source")) {
+		query.Sources = append(query.Sources, domain.TransactionSource(strings.ToLower(source)))
+	}
+
+	query.MinAmount, err = parseOptionalInt64(c.QueryParam("min_amount"))
+	if err != nil {
+		return query, errors.New("min_amount must be an integer")
+	}
+
+	query.MaxAmount, err = parseOptionalInt64(c.QueryParam("max_amount"))
+	if err != nil {
+		return query, errors.New("max_amount must be an integer")
+	}
+
+	if from := c.QueryParam("from"); from != "" {
+		ts, err := parseTimestamp(from)
+		if err != nil {
+			return query, errors.New("from must be a unix timestamp or RFC3339 time")
+		}
+		query.From = &ts
+	}
+
+	if to := c.QueryParam("to"); to != "" {
+		ts, err := parseTimestamp(to)
+		if err != nil {
+			return query, errors.New("to must be a unix timestamp or RFC3339 time")
+		}
+		query.To = &ts
+	}
+
+	return query, nil
+}
+
+// parseCursor reports whether the request asked for cursor pagination. An
+// empty cursor parameter starts from the first page.
+func parseCursor(c echo.Context) (*domain.Cursor, bool, error) {
+	if !c.QueryParams().Has("cursor") {
+		return nil, false, nil
+	}
+
+	token := c.QueryParam("cursor")
+	if token == "" {
+		return nil, true, nil
+	}
+
+	cursor, err := domain.DecodeCursor(token)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return &cursor, true, nil
+}
+
+// sanitizeFilename keeps a caller supplied download name header-safe
+func sanitizeFilename(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' {
+			b.WriteRune(r)
+		}
+	}
+	return strings.Trim(b.String(), ".")
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type WatchlistHandler struct {
+	service service.WatchlistService
+	logger  *logger.Logger
+}
+
+type watchlistEntryRequest struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+type screeningReviewRequest struct {
+	Reviewer    string `json:"reviewer"`
+	Note        string `json:"note"`
+	LineNumbers []int  `json:"line_numbers"`
+}
+
+func NewWatchlistHandler(service service.WatchlistService, log *logger.Logger) *WatchlistHandler {
+	return &WatchlistHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (h *WatchlistHandler) CreateEntry(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req watchlistEntryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	entry, err := h.service.CreateEntry(ctx, domain.WatchlistEntry{
+		Name:   req.Name,
+		Reason: req.Reason,
+	})
+	if err != nil {
+		return h.watchlistError(c, err, "failed to add watchlist entry")
+	}
+
+	return c.JSON(http.StatusCreated, entry)
+}
+
+func (h *WatchlistHandler) ListEntries(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	entries, err := h.service.ListEntries(ctx)
+	if err != nil {
+		return h.watchlistError(c, err, "failed to list watchlist entries")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"items": entries,
+		"total": len(entries),
+	})
+}
+
+func (h *WatchlistHandler) DeleteEntry(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.service.DeleteEntry(ctx, c.Param("id"))
+	if err != nil {
+		return h.watchlistError(c, err, "failed to delete watchlist entry")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *WatchlistHandler) Import(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "file is required",
+		})
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		h.logger.Error(ctx, "Failed to open file",
+			"error", err,
+		)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to open file",
+		})
+	}
+	defer src.Close()
+
+	imported, skipped, err := h.service.ImportEntries(ctx, src, domain.WatchlistSourceFile)
+	if err != nil {
+		return h.watchlistError(c, err, "failed to import watchlist")
+	}
+
+	return c.JSON(http.StatusOK, map[string]int{
+		"imported": imported,
+		"skipped":  skipped,
+	})
+}
+
+func (h *WatchlistHandler) ListScreening(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	uploadID := c.Param("id")
+
+	var state *domain.ScreeningState
+	if value := c.QueryParam("state"); value != "" {
+		parsed := domain.ScreeningState(strings.ToLower(value))
+		state = &parsed
+	}
+
+	results, err := h.service.ListScreening(ctx, uploadID, state)
+	if err != nil {
+		return h.watchlistError(c, err, "failed to list screening results")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id": uploadID,
+		"items":     results,
+		"total":     len(results),
+	})
+}
+
+func (h *WatchlistHandler) ClearScreening(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req screeningReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	uploadID := c.Param("id")
+	cleared, upload, err := h.service.ClearScreening(ctx, uploadID, domain.ScreeningReview{
+		Reviewer:    req.Reviewer,
+		Note:        req.Note,
+		LineNumbers: req.LineNumbers,
+	})
+	if err != nil {
+		return h.watchlistError(c, err, "failed to clear watchlist hits")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"upload_id":     uploadID,
+		"upload_status": upload.Status,
+		"items":         cleared,
+		"total":         len(cleared),
+	})
+}
+
+func (h *WatchlistHandler) watchlistError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrWatchlistEntryNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "watchlist entry not found",
+		})
+	case domain.ErrUploadNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "upload not found",
+		})
+	case domain.ErrScreeningNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "no watchlist hit on one of the line numbers",
+		})
+	case domain.ErrDuplicateWatchlistEntry:
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "watchlist entry already exists",
+		})
+	case domain.ErrInvalidWatchlistEntry:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "a watchlist entry needs a name, files hold one name[,reason] per line",
+		})
+	case domain.ErrInvalidScreeningReview:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "reviewer is required",
+		})
+	case domain.ErrInvalidQuery:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "state must be open or cleared",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/service"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	service service.WebhookService
+	logger  *logger.Logger
+}
+
+type webhookRequest struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+}
+
+func NewWebhookHandler(service service.WebhookService, log *logger.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+		logger:  log,
+	}
+}
+
+func (r webhookRequest) toSubscription() domain.WebhookSubscription {
+	webhook := domain.WebhookSubscription{
+		URL:    r.URL,
+		Secret: r.Secret,
+	}
+	for _, eventType := range r.EventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, domain.WebhookEventType(eventType))
+	}
+	return webhook
+}
+
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req webhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	webhook, err := h.service.CreateWebhook(ctx, req.toSubscription())
+	if err != nil {
+		return h.webhookError(c, err, "failed to create webhook subscription")
+	}
+
+	return c.JSON(http.StatusCreated, webhook)
+}
+
+func (h *WebhookHandler) ListWebhooks(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhooks, err := h.service.ListWebhooks(ctx)
+	if err != nil {
+		return h.webhookError(c, err, "failed to list webhook subscriptions")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"items": webhooks,
+		"total": len(webhooks),
+	})
+}
+
+func (h *WebhookHandler) GetWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhook, err := h.service.GetWebhook(ctx, c.Param("id"))
+	if err != nil {
+		return h.webhookError(c, err, "failed to get webhook subscription")
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.service.DeleteWebhook(ctx, c.Param("id"))
+	if err != nil {
+		return h.webhookError(c, err, "failed to delete webhook subscription")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhookID := c.Param("id")
+	query := domain.WebhookDeliveryQuery{
+		SubscriptionID: webhookID,
+		EventType:      domain.WebhookEventType(strings.ToLower(c.QueryParam("event_type"))),
+		Status:         domain.WebhookDeliveryStatus(strings.ToLower(c.QueryParam("status"))),
+	}
+
+	deliveries, err := h.service.ListDeliveries(ctx, query)
+	if err != nil {
+		return h.webhookError(c, err, "failed to list webhook deliveries")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"webhook_id": webhookID,
+		"items":      deliveries,
+		"total":      len(deliveries),
+	})
+}
+
+func (h *WebhookHandler) webhookError(c echo.Context, err error, message string) error {
+	switch err {
+	case domain.ErrWebhookNotFound:
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "webhook subscription not found",
+		})
+	case domain.ErrInvalidWebhook:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "a webhook needs an http(s) url, a secret and event_types from upload.completed, upload.needs_review, upload.failed or alert.triggered",
+		})
+	case domain.ErrInvalidQuery:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "status must be pending, delivered or failed and event_type one of upload.completed, upload.needs_review, upload.failed or alert.triggered",
+		})
+	}
+
+	h.logger.Error(c.Request().Context(), message,
+		"error", err,
+	)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+// LogNotifier writes every alert to the application log at warn level
+type LogNotifier struct {
+	logger *logger.Logger
+}
+
+func NewLogNotifier(log *logger.Logger) *LogNotifier {
+	return &LogNotifier{
+		logger: log,
+	}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, alert domain.Alert) error {
+	n.logger.Warn(logger.WithUploadID(ctx, alert.UploadID), "Alert triggered",
+		"alert_id", alert.ID,
+		"rule_id", alert.RuleID,
+		"rule_name", alert.RuleName,
+		"kind", alert.Kind,
+		"line_numbers", alert.LineNumbers,
+		"message", alert.Message,
+	)
+	return nil
+}
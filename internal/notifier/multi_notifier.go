@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+// MultiNotifier passes every alert to each of its notifiers, a failing one
+// does not stop the others
+type MultiNotifier struct {
+	notifiers []domain.Notifier
+}
+
+func NewMultiNotifier(notifiers ...domain.Notifier) *MultiNotifier {
+	return &MultiNotifier{
+		notifiers: notifiers,
+	}
+}
+
+func (n *MultiNotifier) Notify(ctx context.Context, alert domain.Alert) error {
+	var errs []error
+	for _, notifier := range n.notifiers {
+		if err := notifier.Notify(ctx, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
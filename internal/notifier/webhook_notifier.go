@@ -0,0 +1,166 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/grachmannico95/flip-test-be/pkg/retry"
+)
+
+// WebhookNotifier POSTs events to every subscription of their type. Each
+// delivery is retried with backoff and every attempt is kept in the
+// delivery log.
+type WebhookNotifier struct {
+	repo      domain.Repository
+	client    *http.Client
+	logger    *logger.Logger
+	retryOpts []retry.Option
+}
+
+func NewWebhookNotifier(repo domain.Repository, log *logger.Logger, timeout time.Duration, retryOpts ...retry.Option) *WebhookNotifier {
+	return &WebhookNotifier{
+		repo:      repo,
+		client:    &http.Client{Timeout: timeout},
+		logger:    log,
+		retryOpts: retryOpts,
+	}
+}
+
+// Notify sends a triggered alert as alert.triggered
+func (n *WebhookNotifier) Notify(ctx context.Context, alert domain.Alert) error {
+	return n.Dispatch(ctx, alertEvent(alert))
+}
+
+func alertEvent(alert domain.Alert) domain.WebhookEvent {
+	return domain.WebhookEvent{
+		ID:         "alert-" + alert.ID,
+		Type:       domain.WebhookEventAlertTriggered,
+		UploadID:   alert.UploadID,
+		OccurredAt: alert.TriggeredAt,
+		Data:       alert,
+	}
+}
+
+// Dispatch delivers an event to its subscribers one after another and
+// reports how many deliveries failed after their retries
+func (n *WebhookNotifier) Dispatch(ctx context.Context, event domain.WebhookEvent) error {
+	webhooks, err := n.repo.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	failed, total := 0, 0
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event.Type) {
+			continue
+		}
+
+		total++
+		if !n.deliver(ctx, webhook, event, body) {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d webhook deliveries failed", failed, total)
+	}
+
+	return nil
+}
+
+func (n *WebhookNotifier) deliver(ctx context.Context, webhook domain.WebhookSubscription, event domain.WebhookEvent, body []byte) bool {
+	delivery := domain.WebhookDelivery{
+		ID:             uuid.New().String(),
+		SubscriptionID: webhook.ID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		URL:            webhook.URL,
+		Status:         domain.WebhookDeliveryPending,
+		CreatedAt:      time.Now(),
+	}
+	n.save(ctx, delivery)
+
+	err := retry.Do(ctx, func() error {
+		delivery.Attempts++
+		delivery.StatusCode, delivery.Error = 0, ""
+
+		statusCode, err := n.send(ctx, webhook, delivery.ID, event, body)
+		delivery.StatusCode = statusCode
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		n.save(ctx, delivery)
+
+		return err
+	}, n.retryOpts...)
+
+	completedAt := time.Now()
+	delivery.CompletedAt = &completedAt
+	delivery.Status = domain.WebhookDeliveryDelivered
+	if err != nil {
+		delivery.Status = domain.WebhookDeliveryFailed
+		if delivery.Error == "" {
+			delivery.Error = err.Error()
+		}
+
+		n.logger.Error(ctx, "Webhook delivery failed",
+			"webhook_id", webhook.ID,
+			"delivery_id", delivery.ID,
+			"event_type", event.Type,
+			"attempts", delivery.Attempts,
+			"error", err,
+		)
+	}
+	n.save(ctx, delivery)
+
+	return err == nil
+}
+
+// send makes one attempt, any status outside 2xx is a failure
+func (n *WebhookNotifier) send(ctx context.Context, webhook domain.WebhookSubscription, deliveryID string, event domain.WebhookEvent, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", string(event.Type))
+	req.Header.Set("X-Webhook-ID", event.ID)
+	req.Header.Set("X-Webhook-Delivery", deliveryID)
+	req.Header.Set(domain.WebhookSignatureHeader, domain.SignWebhook(webhook.Secret, body))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (n *WebhookNotifier) save(ctx context.Context, delivery domain.WebhookDelivery) {
+	if err := n.repo.SaveWebhookDelivery(ctx, delivery); err != nil {
+		n.logger.Error(ctx, "Failed to save webhook delivery",
+			"delivery_id", delivery.ID,
+			"error", err,
+		)
+	}
+}
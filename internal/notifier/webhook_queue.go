@@ -0,0 +1,140 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+// ErrWebhookQueueStopped is returned when an event is queued after Shutdown
+var ErrWebhookQueueStopped = errors.New("webhook queue stopped")
+
+// WebhookQueue hands events to a dispatcher on its own workers, so callers
+// such as the alert consumer never wait on HTTP. A full queue makes callers
+// wait for room rather than drop the event.
+type WebhookQueue struct {
+	dispatcher  domain.WebhookDispatcher
+	logger      *logger.Logger
+	ch          chan domain.WebhookEvent
+	stopping    chan struct{}
+	stopOnce    sync.Once
+	workerCount int
+	wg          sync.WaitGroup
+	mu          sync.RWMutex
+	started     bool
+	stopped     bool
+}
+
+func NewWebhookQueue(dispatcher domain.WebhookDispatcher, log *logger.Logger, size, workerCount int) *WebhookQueue {
+	return &WebhookQueue{
+		dispatcher:  dispatcher,
+		logger:      log,
+		ch:          make(chan domain.WebhookEvent, size),
+		stopping:    make(chan struct{}),
+		workerCount: workerCount,
+	}
+}
+
+// Notify queues a triggered alert as alert.triggered
+func (q *WebhookQueue) Notify(ctx context.Context, alert domain.Alert) error {
+	return q.Dispatch(ctx, alertEvent(alert))
+}
+
+// Dispatch queues an event, waiting while the queue is full. Delivery
+// failures are logged by the worker.
+func (q *WebhookQueue) Dispatch(ctx context.Context, event domain.WebhookEvent) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.stopped {
+		return ErrWebhookQueueStopped
+	}
+
+	select {
+	case q.ch <- event:
+		return nil
+	default:
+	}
+
+	q.logger.Warn(ctx, "Webhook queue full, waiting for room",
+		"event_id", event.ID,
+		"event_type", event.Type,
+	)
+
+	select {
+	case q.ch <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-q.stopping:
+		return ErrWebhookQueueStopped
+	}
+}
+
+// Start runs the workers, ctx is used for every delivery
+func (q *WebhookQueue) Start(ctx context.Context) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.started {
+		return
+	}
+
+	for i := 0; i < q.workerCount; i++ {
+		q.wg.Add(1)
+		go q.worker(ctx)
+	}
+	q.started = true
+}
+
+func (q *WebhookQueue) worker(ctx context.Context) {
+	defer q.wg.Done()
+
+	for event := range q.ch {
+		eventCtx := logger.WithUploadID(ctx, event.UploadID)
+		if err := q.dispatcher.Dispatch(eventCtx, event); err != nil {
+			q.logger.Error(eventCtx, "Failed to deliver webhook event",
+				"event_id", event.ID,
+				"event_type", event.Type,
+				"error", err,
+			)
+		}
+	}
+}
+
+// Shutdown stops accepting events and waits for the queued ones to be
+// delivered
+func (q *WebhookQueue) Shutdown(ctx context.Context) error {
+	q.logger.Info(ctx, "Shutting down webhook queue")
+
+	// Callers waiting for room give up first, the channel is closed once none
+	// can send on it
+	q.stopOnce.Do(func() {
+		close(q.stopping)
+	})
+
+	q.mu.Lock()
+	if !q.stopped {
+		q.stopped = true
+		close(q.ch)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.logger.Info(ctx, "Webhook queue shutdown complete")
+		return nil
+	case <-ctx.Done():
+		q.logger.Warn(ctx, "Webhook queue shutdown timeout")
+		return ctx.Err()
+	}
+}
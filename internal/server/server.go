@@ -13,17 +13,47 @@ import (
 )
 
 type Server struct {
-	echo             *echo.Echo
-	cfg              *config.Config
-	logger           *logger.Logger
-	statementHandler *handler.StatementHandler
-	healthHandler    *handler.HealthHandler
+	echo                  *echo.Echo
+	cfg                   *config.Config
+	logger                *logger.Logger
+	statementHandler      *handler.StatementHandler
+	collectionHandler     *handler.CollectionHandler
+	accountHandler        *handler.AccountHandler
+	reconciliationHandler *handler.ReconciliationHandler
+	counterpartyHandler   *handler.CounterpartyHandler
+	categoryHandler       *handler.CategoryHandler
+	issueHandler          *handler.IssueHandler
+	settlementHandler     *handler.SettlementHandler
+	balanceRuleHandler    *handler.BalanceRuleHandler
+	adjustmentHandler     *handler.AdjustmentHandler
+	anomalyHandler        *handler.AnomalyHandler
+	recurringHandler      *handler.RecurringHandler
+	projectionHandler     *handler.ProjectionHandler
+	alertHandler          *handler.AlertHandler
+	watchlistHandler      *handler.WatchlistHandler
+	webhookHandler        *handler.WebhookHandler
+	healthHandler         *handler.HealthHandler
 }
 
 func New(
 	cfg *config.Config,
 	log *logger.Logger,
 	statementHandler *handler.StatementHandler,
+	collectionHandler *handler.CollectionHandler,
+	accountHandler *handler.AccountHandler,
+	reconciliationHandler *handler.ReconciliationHandler,
+	counterpartyHandler *handler.CounterpartyHandler,
+	categoryHandler *handler.CategoryHandler,
+	issueHandler *handler.IssueHandler,
+	settlementHandler *handler.SettlementHandler,
+	balanceRuleHandler *handler.BalanceRuleHandler,
+	adjustmentHandler *handler.AdjustmentHandler,
+	anomalyHandler *handler.AnomalyHandler,
+	recurringHandler *handler.RecurringHandler,
+	projectionHandler *handler.ProjectionHandler,
+	alertHandler *handler.AlertHandler,
+	watchlistHandler *handler.WatchlistHandler,
+	webhookHandler *handler.WebhookHandler,
 	healthHandler *handler.HealthHandler,
 ) *Server {
 	e := echo.New()
@@ -31,11 +61,26 @@ func New(
 	e.HidePort = true
 
 	return &Server{
-		echo:             e,
-		cfg:              cfg,
-		logger:           log,
-		statementHandler: statementHandler,
-		healthHandler:    healthHandler,
+		echo:                  e,
+		cfg:                   cfg,
+		logger:                log,
+		statementHandler:      statementHandler,
+		collectionHandler:     collectionHandler,
+		accountHandler:        accountHandler,
+		reconciliationHandler: reconciliationHandler,
+		counterpartyHandler:   counterpartyHandler,
+		categoryHandler:       categoryHandler,
+		issueHandler:          issueHandler,
+		settlementHandler:     settlementHandler,
+		balanceRuleHandler:    balanceRuleHandler,
+		adjustmentHandler:     adjustmentHandler,
+		anomalyHandler:        anomalyHandler,
+		recurringHandler:      recurringHandler,
+		projectionHandler:     projectionHandler,
+		alertHandler:          alertHandler,
+		watchlistHandler:      watchlistHandler,
+		webhookHandler:        webhookHandler,
+		healthHandler:         healthHandler,
 	}
 }
 
@@ -68,7 +113,86 @@ func (s *Server) setupRoutes() {
 
 	s.echo.POST("/statements", s.statementHandler.Upload)
 	s.echo.GET("/balance", s.statementHandler.GetBalance)
+	s.echo.GET("/balance/breakdown", s.statementHandler.GetBalanceBreakdown)
+	s.echo.GET("/balance/consolidated", s.collectionHandler.GetConsolidatedBalance)
+	s.echo.GET("/balance-rules", s.balanceRuleHandler.GetRules)
+	s.echo.PUT("/balance-rules", s.balanceRuleHandler.ReplaceRules)
+	s.echo.GET("/transactions", s.statementHandler.GetTransactions)
 	s.echo.GET("/transactions/issues", s.statementHandler.GetIssues)
+	s.echo.GET("/transactions/issues/consolidated", s.collectionHandler.GetConsolidatedIssues)
+	s.echo.GET("/transactions/issues/:upload_id/:line_number", s.issueHandler.GetIssue)
+	s.echo.PATCH("/transactions/issues/:upload_id/:line_number", s.issueHandler.UpdateIssue)
+	s.echo.GET("/transactions/:reference", s.statementHandler.GetTransactionByReference)
+	s.echo.PATCH("/transactions/:reference/status", s.settlementHandler.UpdateStatusByReference)
+
+	s.echo.GET("/uploads/:id", s.statementHandler.GetUpload)
+	s.echo.GET("/uploads/:id/balance-series", s.statementHandler.GetBalanceSeries)
+	s.echo.GET("/uploads/:id/export", s.statementHandler.Export)
+	s.echo.GET("/uploads/:id/counterparties", s.statementHandler.GetCounterparties)
+	s.echo.GET("/uploads/:id/summary", s.statementHandler.GetSummary)
+	s.echo.GET("/uploads/:id/categories", s.categoryHandler.GetCategories)
+	s.echo.POST("/uploads/:id/categorize", s.categoryHandler.ApplyRules)
+	s.echo.PATCH("/uploads/:id/transactions/:line_number/status", s.settlementHandler.UpdateStatus)
+	s.echo.GET("/uploads/:id/transactions/:line_number/status-history", s.settlementHandler.GetStatusHistory)
+	s.echo.POST("/uploads/:id/status-updates", s.settlementHandler.ApplyStatusFile)
+	s.echo.GET("/uploads/:id/anomalies", s.anomalyHandler.List)
+	s.echo.GET("/uploads/:id/recurring", s.recurringHandler.ListForUpload)
+	s.echo.GET("/uploads/:id/projection", s.projectionHandler.Project)
+	s.echo.GET("/uploads/:id/screening", s.watchlistHandler.ListScreening)
+	s.echo.POST("/uploads/:id/screening/clear", s.watchlistHandler.ClearScreening)
+	s.echo.POST("/uploads/:id/adjustments", s.adjustmentHandler.Propose)
+	s.echo.GET("/uploads/:id/adjustments", s.adjustmentHandler.List)
+	s.echo.GET("/uploads/:id/adjustments/:adjustment_id", s.adjustmentHandler.Get)
+	s.echo.POST("/uploads/:id/adjustments/:adjustment_id/approve", s.adjustmentHandler.Approve)
+	s.echo.POST("/uploads/:id/adjustments/:adjustment_id/reject", s.adjustmentHandler.Reject)
+
+	s.echo.POST("/collections", s.collectionHandler.Create)
+	s.echo.GET("/collections/:id", s.collectionHandler.Get)
+	s.echo.PUT("/collections/:id", s.collectionHandler.Update)
+	s.echo.DELETE("/collections/:id", s.collectionHandler.Delete)
+
+	s.echo.POST("/accounts", s.accountHandler.Create)
+	s.echo.GET("/accounts", s.accountHandler.List)
+	s.echo.GET("/accounts/:id", s.accountHandler.Get)
+	s.echo.PUT("/accounts/:id", s.accountHandler.Update)
+	s.echo.DELETE("/accounts/:id", s.accountHandler.Delete)
+	s.echo.POST("/accounts/:id/uploads", s.accountHandler.AttachUpload)
+	s.echo.GET("/accounts/:id/balance", s.accountHandler.GetBalance)
+	s.echo.GET("/accounts/:id/issues", s.accountHandler.GetIssues)
+	s.echo.GET("/accounts/:id/coverage", s.accountHandler.GetCoverage)
+	s.echo.GET("/accounts/:id/recurring", s.recurringHandler.ListForAccount)
+
+	s.echo.POST("/reconciliations", s.reconciliationHandler.Create)
+	s.echo.GET("/reconciliations/:id", s.reconciliationHandler.Get)
+	s.echo.GET("/reconciliations/:id/results", s.reconciliationHandler.GetResults)
+
+	s.echo.POST("/counterparty-aliases", s.counterpartyHandler.SetAlias)
+	s.echo.GET("/counterparty-aliases", s.counterpartyHandler.ListAliases)
+	s.echo.DELETE("/counterparty-aliases/:alias", s.counterpartyHandler.DeleteAlias)
+	s.echo.GET("/counterparties/merge-suggestions", s.counterpartyHandler.GetMergeSuggestions)
+
+	s.echo.POST("/category-rules", s.categoryHandler.CreateRule)
+	s.echo.GET("/category-rules", s.categoryHandler.ListRules)
+	s.echo.GET("/category-rules/:id", s.categoryHandler.GetRule)
+	s.echo.PUT("/category-rules/:id", s.categoryHandler.UpdateRule)
+	s.echo.DELETE("/category-rules/:id", s.categoryHandler.DeleteRule)
+
+	s.echo.POST("/alert-rules", s.alertHandler.CreateRule)
+	s.echo.GET("/alert-rules", s.alertHandler.ListRules)
+	s.echo.GET("/alert-rules/:id", s.alertHandler.GetRule)
+	s.echo.DELETE("/alert-rules/:id", s.alertHandler.DeleteRule)
+	s.echo.GET("/alerts", s.alertHandler.ListAlerts)
+
+	s.echo.POST("/watchlist", s.watchlistHandler.CreateEntry)
+	s.echo.GET("/watchlist", s.watchlistHandler.ListEntries)
+	s.echo.POST("/watchlist/import", s.watchlistHandler.Import)
+	s.echo.DELETE("/watchlist/:id", s.watchlistHandler.DeleteEntry)
+
+	s.echo.POST("/webhooks", s.webhookHandler.CreateWebhook)
+	s.echo.GET("/webhooks", s.webhookHandler.ListWebhooks)
+	s.echo.GET("/webhooks/:id", s.webhookHandler.GetWebhook)
+	s.echo.DELETE("/webhooks/:id", s.webhookHandler.DeleteWebhook)
+	s.echo.GET("/webhooks/:id/deliveries", s.webhookHandler.ListDeliveries)
 }
 
 func (s *Server) Handler() *echo.Echo {
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type AccountService interface {
+	CreateAccount(ctx context.Context, account domain.Account) (*domain.Account, error)
+	GetAccount(ctx context.Context, accountID string) (*domain.Account, error)
+	ListAccounts(ctx context.Context) ([]domain.Account, error)
+	UpdateAccount(ctx context.Context, accountID string, account domain.Account) (*domain.Account, error)
+	DeleteAccount(ctx context.Context, accountID string) error
+	AttachUpload(ctx context.Context, accountID, uploadID string) error
+	GetAccountBalance(ctx context.Context, accountID string, asOf *int64) (*domain.AccountBalance, error)
+	GetAccountIssues(ctx context.Context, query domain.AccountIssueQuery) ([]domain.ConsolidatedIssue, int, error)
+	GetAccountCoverage(ctx context.Context, accountID string, gapTolerance time.Duration) (*domain.AccountCoverage, error)
+}
+
+type accountService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewAccountService(repo domain.Repository, log *logger.Logger) AccountService {
+	return &accountService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *accountService) CreateAccount(ctx context.Context, account domain.Account) (*domain.Account, error) {
+	account.Normalize()
+	if err := account.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	account.ID = uuid.New().String()
+	account.CreatedAt = now
+	account.UpdatedAt = now
+
+	s.logger.Info(ctx, "Creating account",
+		"account_id", account.ID,
+	)
+
+	err := s.repo.CreateAccount(ctx, account)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to create account",
+			"account_id", account.ID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (s *accountService) GetAccount(ctx context.Context, accountID string) (*domain.Account, error) {
+	s.logger.Debug(ctx, "Getting account",
+		"account_id", accountID,
+	)
+
+	account, err := s.repo.GetAccount(ctx, accountID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get account",
+			"account_id", accountID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (s *accountService) ListAccounts(ctx context.Context) ([]domain.Account, error) {
+	s.logger.Debug(ctx, "Listing accounts")
+
+	accounts, err := s.repo.ListAccounts(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list accounts",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (s *accountService) UpdateAccount(ctx context.Context, accountID string, account domain.Account) (*domain.Account, error) {
+	account.Normalize()
+	if err := account.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetAccount(ctx, accountID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get account",
+			"account_id", accountID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	account.ID = existing.ID
+	account.CreatedAt = existing.CreatedAt
+	account.UpdatedAt = time.Now()
+
+	s.logger.Info(ctx, "Updating account",
+		"account_id", accountID,
+	)
+
+	err = s.repo.UpdateAccount(ctx, account)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to update account",
+			"account_id", accountID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (s *accountService) DeleteAccount(ctx context.Context, accountID string) error {
+	s.logger.Info(ctx, "Deleting account",
+		"account_id", accountID,
+	)
+
+	err := s.repo.DeleteAccount(ctx, accountID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to delete account",
+			"account_id", accountID,
+			"error", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (s *accountService) AttachUpload(ctx context.Context, accountID, uploadID string) error {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	s.logger.Info(ctx, "Attaching upload to account",
+		"account_id", accountID,
+	)
+
+	err := s.repo.AttachUpload(ctx, uploadID, accountID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to attach upload to account",
+			"account_id", accountID,
+			"error", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (s *accountService) GetAccountBalance(ctx context.Context, accountID string, asOf *int64) (*domain.AccountBalance, error) {
+	s.logger.Debug(ctx, "Getting account balance",
+		"account_id", accountID,
+		"as_of", asOf,
+	)
+
+	balance, err := s.repo.GetAccountBalance(ctx, accountID, asOf)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get account balance",
+			"account_id", accountID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	s.logger.Debug(ctx, "Account balance retrieved",
+		"account_id", accountID,
+		"balance", balance.Balance,
+	)
+
+	return balance, nil
+}
+
+func (s *accountService) GetAccountIssues(ctx context.Context, query domain.AccountIssueQuery) ([]domain.ConsolidatedIssue, int, error) {
+	if query.From != nil && query.To != nil && *query.From > *query.To {
+		return nil, 0, domain.ErrInvalidQuery
+	}
+
+	s.logger.Debug(ctx, "Getting account issues",
+		"account_id", query.AccountID,
+		"page", query.Page,
+		"per_page", query.PerPage,
+		"status", query.Status,
+	)
+
+	issues, total, err := s.repo.GetAccountIssues(ctx, query)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get account issues",
+			"account_id", query.AccountID,
+			"error", err,
+		)
+		return nil, 0, err
+	}
+
+	s.logger.Debug(ctx, "Account issues retrieved",
+		"total", total,
+		"returned", len(issues),
+	)
+
+	return issues, total, nil
+}
+
+func (s *accountService) GetAccountCoverage(ctx context.Context, accountID string, gapTolerance time.Duration) (*domain.AccountCoverage, error) {
+	if gapTolerance < 0 {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	s.logger.Debug(ctx, "Getting account coverage",
+		"account_id", accountID,
+		"gap_tolerance", gapTolerance,
+	)
+
+	periods, err := s.repo.GetAccountUploadPeriods(ctx, accountID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get account upload periods",
+			"account_id", accountID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	coverage := domain.AnalyzeCoverage(accountID, periods, int64(gapTolerance/time.Second))
+
+	s.logger.Debug(ctx, "Account coverage analyzed",
+		"account_id", accountID,
+		"gaps", len(coverage.Gaps),
+		"overlaps", len(coverage.Overlaps),
+	)
+
+	return coverage, nil
+}
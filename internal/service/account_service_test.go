@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAccount_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAccountService(repo, log)
+
+	// Mock expectations
+	repo.EXPECT().
+		CreateAccount(mock.Anything, mock.MatchedBy(func(account domain.Account) bool {
+			return account.ID != "" && account.Currency == "IDR" && account.Bank == "BCA"
+		})).
+		Return(nil).
+		Once()
+
+	// Execute
+	account, err := svc.CreateAccount(context.Background(), domain.Account{
+		AccountNumber: "1234567890",
+		Bank:          " BCA ",
+		Currency:      "idr",
+		Owner:         "John Doe",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEmpty(t, account.ID)
+	assert.Equal(t, "IDR", account.Currency)
+	assert.False(t, account.CreatedAt.IsZero())
+}
+
+func TestCreateAccount_Invalid(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAccountService(repo, log)
+
+	// Execute
+	account, err := svc.CreateAccount(context.Background(), domain.Account{
+		AccountNumber: "1234567890",
+		Bank:          "BCA",
+		Currency:      "RUPIAH",
+		Owner:         "John Doe",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidAccount)
+	assert.Nil(t, account)
+}
+
+func TestUpdateAccount_KeepsIdentity(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAccountService(repo, log)
+
+	existing := &domain.Account{
+		ID:            "account-1",
+		AccountNumber: "1234567890",
+		Bank:          "BCA",
+		Currency:      "IDR",
+		Owner:         "John Doe",
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		GetAccount(mock.Anything, "account-1").
+		Return(existing, nil).
+		Once()
+
+	repo.EXPECT().
+		UpdateAccount(mock.Anything, mock.MatchedBy(func(account domain.Account) bool {
+			return account.ID == "account-1" && account.Owner == "Jane Doe"
+		})).
+		Return(nil).
+		Once()
+
+	// Execute
+	account, err := svc.UpdateAccount(context.Background(), "account-1", domain.Account{
+		AccountNumber: "1234567890",
+		Bank:          "BCA",
+		Currency:      "IDR",
+		Owner:         "Jane Doe",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "account-1", account.ID)
+}
+
+func TestGetAccountIssues_InvalidRange(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAccountService(repo, log)
+
+	from := int64(2000)
+	to := int64(1000)
+
+	// Execute
+	issues, total, err := svc.GetAccountIssues(context.Background(), domain.AccountIssueQuery{
+		AccountID: "account-1",
+		From:      &from,
+		To:        &to,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	assert.Nil(t, issues)
+	assert.Equal(t, 0, total)
+}
+
+func TestGetAccountCoverage_GapsAndOverlaps(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAccountService(repo, log)
+
+	// January and a re-exported January overlap, March follows a missing February
+	periods := []domain.UploadCoverage{
+		{UploadID: "march", Start: 5100, End: 6000, Source: domain.CoverageSourceDeclared},
+		{UploadID: "january", Start: 1000, End: 2000, Source: domain.CoverageSourceDeclared},
+		{UploadID: "january-copy", Start: 1500, End: 2100, Source: domain.CoverageSourceTransactions},
+		{UploadID: "february", Source: domain.CoverageSourceNone},
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		GetAccountUploadPeriods(mock.Anything, "account-1").
+		Return(periods, nil).
+		Once()
+
+	// Execute
+	coverage, err := svc.GetAccountCoverage(context.Background(), "account-1", 100*time.Second)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, coverage.Complete)
+	assert.Equal(t, int64(1000), *coverage.Start)
+	assert.Equal(t, int64(6000), *coverage.End)
+	assert.Equal(t, []string{"february"}, coverage.UncoveredUploads)
+
+	require.Len(t, coverage.Overlaps, 1)
+	assert.Equal(t, domain.CoverageOverlap{
+		Start:     1500,
+		End:       2000,
+		UploadIDs: []string{"january", "january-copy"},
+	}, coverage.Overlaps[0])
+
+	require.Len(t, coverage.Gaps, 1)
+	assert.Equal(t, domain.CoverageGap{
+		Start:          2101,
+		End:            5099,
+		Seconds:        2999,
+		AfterUploadID:  "january-copy",
+		BeforeUploadID: "march",
+	}, coverage.Gaps[0])
+}
+
+func TestGetAccountCoverage_WithinTolerance(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAccountService(repo, log)
+
+	// Mock expectations
+	repo.EXPECT().
+		GetAccountUploadPeriods(mock.Anything, "account-1").
+		Return([]domain.UploadCoverage{
+			{UploadID: "january", Start: 1000, End: 2000, Source: domain.CoverageSourceTransactions},
+			{UploadID: "february", Start: 2050, End: 3000, Source: domain.CoverageSourceTransactions},
+		}, nil).
+		Once()
+
+	// Execute
+	coverage, err := svc.GetAccountCoverage(context.Background(), "account-1", time.Minute)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, coverage.Complete)
+	assert.Empty(t, coverage.Gaps)
+	assert.Empty(t, coverage.Overlaps)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type AdjustmentService interface {
+	Propose(ctx context.Context, uploadID string, adjustment domain.Adjustment) (*domain.Adjustment, error)
+	GetAdjustment(ctx context.Context, uploadID, adjustmentID string) (*domain.Adjustment, error)
+	ListAdjustments(ctx context.Context, uploadID string, state *domain.AdjustmentState) ([]domain.Adjustment, error)
+	Review(ctx context.Context, uploadID, adjustmentID string, review domain.AdjustmentReview) (*domain.Adjustment, error)
+}
+
+type adjustmentService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewAdjustmentService(repo domain.Repository, log *logger.Logger) AdjustmentService {
+	return &adjustmentService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+// Propose records an adjustment that waits for a second user's approval. A
+// missing timestamp defaults to now.
+func (s *adjustmentService) Propose(ctx context.Context, uploadID string, adjustment domain.Adjustment) (*domain.Adjustment, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	now := time.Now()
+	adjustment.Normalize()
+	if adjustment.Timestamp == 0 {
+		adjustment.Timestamp = now.Unix()
+	}
+	if err := adjustment.Validate(); err != nil {
+		return nil, err
+	}
+
+	upload, err := s.repo.GetUpload(ctx, uploadID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get upload",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	// Line numbers of approved adjustments follow the statement's last line
+	if upload.ReconciledAt == nil {
+		return nil, domain.ErrUploadNotReconciled
+	}
+
+	adjustment.ID = uuid.New().String()
+	adjustment.UploadID = uploadID
+	adjustment.State = domain.AdjustmentStateProposed
+	adjustment.ProposedAt = now
+	adjustment.ReviewedBy = ""
+	adjustment.ReviewedAt = nil
+	adjustment.RejectionReason = ""
+	adjustment.LineNumber = 0
+
+	s.logger.Info(ctx, "Proposing adjustment",
+		"adjustment_id", adjustment.ID,
+		"type", adjustment.Type,
+		"amount", adjustment.Amount,
+		"proposed_by", adjustment.ProposedBy,
+	)
+
+	err = s.repo.CreateAdjustment(ctx, adjustment)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to create adjustment",
+			"adjustment_id", adjustment.ID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return &adjustment, nil
+}
+
+func (s *adjustmentService) GetAdjustment(ctx context.Context, uploadID, adjustmentID string) (*domain.Adjustment, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	s.logger.Debug(ctx, "Getting adjustment",
+		"adjustment_id", adjustmentID,
+	)
+
+	return s.repo.GetAdjustment(ctx, uploadID, adjustmentID)
+}
+
+func (s *adjustmentService) ListAdjustments(ctx context.Context, uploadID string, state *domain.AdjustmentState) ([]domain.Adjustment, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	if state != nil && !state.IsValid() {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	s.logger.Debug(ctx, "Listing adjustments")
+
+	adjustments, err := s.repo.ListAdjustments(ctx, uploadID, state)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list adjustments",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return adjustments, nil
+}
+
+func (s *adjustmentService) Review(ctx context.Context, uploadID, adjustmentID string, review domain.AdjustmentReview) (*domain.Adjustment, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	review.Normalize()
+	if err := review.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Reviewing adjustment",
+		"adjustment_id", adjustmentID,
+		"approve", review.Approve,
+		"actor", review.Actor,
+	)
+
+	adjustment, err := s.repo.ReviewAdjustment(ctx, uploadID, adjustmentID, review)
+	if err != nil {
+		if err != domain.ErrAdjustmentNotFound && err != domain.ErrAdjustmentReviewed && err != domain.ErrSelfApproval {
+			s.logger.Error(ctx, "Failed to review adjustment",
+				"adjustment_id", adjustmentID,
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+
+	return adjustment, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposeAdjustment_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAdjustmentService(repo, log)
+
+	reconciledAt := time.Now()
+
+	// Mock expectations
+	repo.EXPECT().
+		GetUpload(mock.Anything, "upload-1").
+		Return(&domain.Upload{ID: "upload-1", ReconciledAt: &reconciledAt}, nil).
+		Once()
+
+	repo.EXPECT().
+		CreateAdjustment(mock.Anything, mock.MatchedBy(func(a domain.Adjustment) bool {
+			return a.UploadID == "upload-1" && a.State == domain.AdjustmentStateProposed &&
+				a.Type == domain.TransactionTypeFee && a.ProposedBy == "alice" && a.ID != ""
+		})).
+		Return(nil).
+		Once()
+
+	// Execute
+	adjustment, err := svc.Propose(context.Background(), "upload-1", domain.Adjustment{
+		Timestamp:    1000,
+		Counterparty: "BANK",
+		Type:         "fee",
+		Amount:       2500,
+		ProposedBy:   " alice ",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.AdjustmentStateProposed, adjustment.State)
+}
+
+func TestProposeAdjustment_UploadStillProcessing(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAdjustmentService(repo, log)
+
+	// Mock expectations
+	repo.EXPECT().
+		GetUpload(mock.Anything, "upload-1").
+		Return(&domain.Upload{ID: "upload-1"}, nil).
+		Once()
+
+	// Execute
+	adjustment, err := svc.Propose(context.Background(), "upload-1", domain.Adjustment{
+		Timestamp:  1000,
+		Type:       domain.TransactionTypeCredit,
+		Amount:     100,
+		ProposedBy: "alice",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUploadNotReconciled)
+	assert.Nil(t, adjustment)
+}
+
+func TestReviewAdjustment_RejectionNeedsReason(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAdjustmentService(repo, log)
+
+	// Execute
+	adjustment, err := svc.Review(context.Background(), "upload-1", "adj-1", domain.AdjustmentReview{Actor: "bob"})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidAdjustment)
+	assert.Nil(t, adjustment)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type AlertService interface {
+	CreateRule(ctx context.Context, rule domain.AlertRule) (*domain.AlertRule, error)
+	GetRule(ctx context.Context, ruleID string) (*domain.AlertRule, error)
+	ListRules(ctx context.Context) ([]domain.AlertRule, error)
+	DeleteRule(ctx context.Context, ruleID string) error
+	ListAlerts(ctx context.Context, query domain.AlertQuery) ([]domain.Alert, error)
+}
+
+type alertService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewAlertService(repo domain.Repository, log *logger.Logger) AlertService {
+	return &alertService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *alertService) CreateRule(ctx context.Context, rule domain.AlertRule) (*domain.AlertRule, error) {
+	rule.Normalize()
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	rule.ID = uuid.New().String()
+	rule.CreatedAt = time.Now()
+
+	s.logger.Info(ctx, "Creating alert rule",
+		"rule_id", rule.ID,
+		"kind", rule.Kind,
+	)
+
+	err := s.repo.CreateAlertRule(ctx, rule)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to create alert rule",
+			"rule_id", rule.ID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (s *alertService) GetRule(ctx context.Context, ruleID string) (*domain.AlertRule, error) {
+	s.logger.Debug(ctx, "Getting alert rule",
+		"rule_id", ruleID,
+	)
+
+	rule, err := s.repo.GetAlertRule(ctx, ruleID)
+	if err != nil {
+		if err != domain.ErrAlertRuleNotFound {
+			s.logger.Error(ctx, "Failed to get alert rule",
+				"rule_id", ruleID,
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *alertService) ListRules(ctx context.Context) ([]domain.AlertRule, error) {
+	s.logger.Debug(ctx, "Listing alert rules")
+
+	rules, err := s.repo.ListAlertRules(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list alert rules",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (s *alertService) DeleteRule(ctx context.Context, ruleID string) error {
+	s.logger.Info(ctx, "Deleting alert rule",
+		"rule_id", ruleID,
+	)
+
+	err := s.repo.DeleteAlertRule(ctx, ruleID)
+	if err != nil {
+		if err != domain.ErrAlertRuleNotFound {
+			s.logger.Error(ctx, "Failed to delete alert rule",
+				"rule_id", ruleID,
+				"error", err,
+			)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *alertService) ListAlerts(ctx context.Context, query domain.AlertQuery) ([]domain.Alert, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug(ctx, "Listing alerts",
+		"upload_id", query.UploadID,
+		"rule_id", query.RuleID,
+		"kinds", query.Kinds,
+	)
+
+	alerts, err := s.repo.ListAlerts(ctx, query)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list alerts",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return alerts, nil
+}
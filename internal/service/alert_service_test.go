@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAlertRule_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAlertService(repo, log)
+
+	// Mock expectations
+	repo.EXPECT().
+		CreateAlertRule(mock.Anything, mock.MatchedBy(func(rule domain.AlertRule) bool {
+			return rule.ID != "" && rule.Kind == domain.AlertRuleWatchlist && len(rule.Counterparties) == 1
+		})).
+		Return(nil).
+		Once()
+
+	// Execute
+	rule, err := svc.CreateRule(context.Background(), domain.AlertRule{
+		Name:           " sanctioned ",
+		Kind:           "Counterparty_Watchlist",
+		Counterparties: []string{"PT Shady", "shady", " "},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "sanctioned", rule.Name)
+	assert.Equal(t, []string{"SHADY"}, rule.Counterparties)
+	assert.False(t, rule.CreatedAt.IsZero())
+}
+
+func TestCreateAlertRule_Invalid(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAlertService(repo, log)
+
+	tests := []domain.AlertRule{
+		{Name: "large debit", Kind: domain.AlertRuleDebitAbove},
+		{Name: "failures", Kind: domain.AlertRuleFailedRatioAbove, Ratio: 1},
+		{Name: "watchlist", Kind: domain.AlertRuleWatchlist},
+		{Name: "unknown", Kind: "velocity"},
+		{Kind: domain.AlertRuleBalanceBelow},
+	}
+
+	for _, input := range tests {
+		// Execute
+		rule, err := svc.CreateRule(context.Background(), input)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidAlertRule)
+		assert.Nil(t, rule)
+	}
+}
+
+func TestListAlerts_InvalidKind(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAlertService(repo, log)
+
+	// Execute
+	alerts, err := svc.ListAlerts(context.Background(), domain.AlertQuery{
+		Kinds: []domain.AlertRuleKind{"velocity"},
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	assert.Nil(t, alerts)
+}
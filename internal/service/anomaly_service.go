@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type AnomalyService interface {
+	ListAnomalies(ctx context.Context, query domain.AnomalyQuery) ([]domain.Anomaly, error)
+}
+
+type anomalyService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewAnomalyService(repo domain.Repository, log *logger.Logger) AnomalyService {
+	return &anomalyService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *anomalyService) ListAnomalies(ctx context.Context, query domain.AnomalyQuery) ([]domain.Anomaly, error) {
+	ctx = logger.WithUploadID(ctx, query.UploadID)
+
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug(ctx, "Listing anomalies",
+		"kinds", query.Kinds,
+		"min_score", query.MinScore,
+	)
+
+	anomalies, err := s.repo.ListAnomalies(ctx, query)
+	if err != nil {
+		if err != domain.ErrUploadNotFound {
+			s.logger.Error(ctx, "Failed to list anomalies",
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+
+	return anomalies, nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAnomalies_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAnomalyService(repo, log)
+
+	query := domain.AnomalyQuery{
+		UploadID: "upload-1",
+		Kinds:    []domain.AnomalyKind{domain.AnomalyKindBurst},
+		MinScore: 0.5,
+	}
+	expected := []domain.Anomaly{
+		{UploadID: "upload-1", LineNumber: 3, Kind: domain.AnomalyKindBurst, Score: 0.55},
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		ListAnomalies(mock.Anything, query).
+		Return(expected, nil).
+		Once()
+
+	// Execute
+	anomalies, err := svc.ListAnomalies(context.Background(), query)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, anomalies)
+}
+
+func TestListAnomalies_InvalidQuery(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewAnomalyService(repo, log)
+
+	// Execute
+	anomalies, err := svc.ListAnomalies(context.Background(), domain.AnomalyQuery{
+		UploadID: "upload-1",
+		Kinds:    []domain.AnomalyKind{"velocity"},
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	assert.Nil(t, anomalies)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type BalanceRuleService interface {
+	GetRules(ctx context.Context) ([]domain.BalanceRule, error)
+	ReplaceRules(ctx context.Context, overrides []domain.BalanceRule) ([]domain.BalanceRule, error)
+}
+
+type balanceRuleService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewBalanceRuleService(repo domain.Repository, log *logger.Logger) BalanceRuleService {
+	return &balanceRuleService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *balanceRuleService) GetRules(ctx context.Context) ([]domain.BalanceRule, error) {
+	s.logger.Debug(ctx, "Getting balance rules")
+
+	rules, err := s.repo.GetBalanceRules(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get balance rules",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return rules.Rules(), nil
+}
+
+// ReplaceRules swaps the whole table, combinations missing from overrides go
+// back to their default
+func (s *balanceRuleService) ReplaceRules(ctx context.Context, overrides []domain.BalanceRule) ([]domain.BalanceRule, error) {
+	for i := range overrides {
+		overrides[i].Type = domain.TransactionType(strings.ToUpper(strings.TrimSpace(string(overrides[i].Type))))
+		overrides[i].Status = domain.TransactionStatus(strings.ToUpper(strings.TrimSpace(string(overrides[i].Status))))
+		overrides[i].Effect = domain.BalanceEffect(strings.ToLower(strings.TrimSpace(string(overrides[i].Effect))))
+	}
+
+	rules, err := domain.NewBalanceRules(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Replacing balance rules",
+		"overrides", len(overrides),
+	)
+
+	err = s.repo.SetBalanceRules(ctx, rules)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to set balance rules",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return rules.Rules(), nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceRules_NormalizesAndFillsDefaults(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewBalanceRuleService(repo, log)
+
+	// Mock expectations
+	var stored *domain.BalanceRules
+	repo.EXPECT().
+		SetBalanceRules(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, rules *domain.BalanceRules) {
+			stored = rules
+		}).
+		Return(nil).
+		Once()
+
+	// Execute
+	rules, err := svc.ReplaceRules(context.Background(), []domain.BalanceRule{
+		{Type: "fee", Status: " pending ", Effect: "Subtract", Include: true},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Len(t, rules, 24)
+	assert.True(t, stored.Includes(domain.Transaction{Type: domain.TransactionTypeFee, Status: domain.TransactionStatusPending}))
+	assert.False(t, stored.Includes(domain.Transaction{Type: domain.TransactionTypeDebit, Status: domain.TransactionStatusPending}))
+}
+
+func TestReplaceRules_InverseOnlyForReversals(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewBalanceRuleService(repo, log)
+
+	// Execute
+	rules, err := svc.ReplaceRules(context.Background(), []domain.BalanceRule{
+		{Type: domain.TransactionTypeCredit, Status: domain.TransactionStatusSuccess, Effect: domain.BalanceEffectInverse, Include: true},
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidBalanceRule)
+	assert.Nil(t, rules)
+}
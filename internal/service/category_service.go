@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type CategoryService interface {
+	CreateRule(ctx context.Context, rule domain.CategoryRule) (*domain.CategoryRule, error)
+	GetRule(ctx context.Context, ruleID string) (*domain.CategoryRule, error)
+	ListRules(ctx context.Context) ([]domain.CategoryRule, error)
+	UpdateRule(ctx context.Context, ruleID string, rule domain.CategoryRule) (*domain.CategoryRule, error)
+	DeleteRule(ctx context.Context, ruleID string) error
+	ApplyRules(ctx context.Context, uploadID string) (int, error)
+	GetCategories(ctx context.Context, uploadID string) ([]domain.CategorySummary, error)
+}
+
+type categoryService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewCategoryService(repo domain.Repository, log *logger.Logger) CategoryService {
+	return &categoryService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *categoryService) CreateRule(ctx context.Context, rule domain.CategoryRule) (*domain.CategoryRule, error) {
+	rule.Normalize()
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule.ID = uuid.New().String()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	s.logger.Info(ctx, "Creating category rule",
+		"rule_id", rule.ID,
+		"category", rule.Category,
+	)
+
+	err := s.repo.CreateCategoryRule(ctx, rule)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to create category rule",
+			"rule_id", rule.ID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (s *categoryService) GetRule(ctx context.Context, ruleID string) (*domain.CategoryRule, error) {
+	s.logger.Debug(ctx, "Getting category rule",
+		"rule_id", ruleID,
+	)
+
+	rule, err := s.repo.GetCategoryRule(ctx, ruleID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get category rule",
+			"rule_id", ruleID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *categoryService) ListRules(ctx context.Context) ([]domain.CategoryRule, error) {
+	s.logger.Debug(ctx, "Listing category rules")
+
+	rules, err := s.repo.ListCategoryRules(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list category rules",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (s *categoryService) UpdateRule(ctx context.Context, ruleID string, rule domain.CategoryRule) (*domain.CategoryRule, error) {
+	rule.Normalize()
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetCategoryRule(ctx, ruleID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get category rule",
+			"rule_id", ruleID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	rule.ID = existing.ID
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = time.Now()
+
+	s.logger.Info(ctx, "Updating category rule",
+		"rule_id", ruleID,
+	)
+
+	err = s.repo.UpdateCategoryRule(ctx, rule)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to update category rule",
+			"rule_id", ruleID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (s *categoryService) DeleteRule(ctx context.Context, ruleID string) error {
+	s.logger.Info(ctx, "Deleting category rule",
+		"rule_id", ruleID,
+	)
+
+	err := s.repo.DeleteCategoryRule(ctx, ruleID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to delete category rule",
+			"rule_id", ruleID,
+			"error", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (s *categoryService) ApplyRules(ctx context.Context, uploadID string) (int, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	s.logger.Info(ctx, "Re-applying category rules")
+
+	ruleSet, err := s.repo.GetRuleSet(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get category rules",
+			"error", err,
+		)
+		return 0, err
+	}
+
+	changed, err := s.repo.ApplyRuleSet(ctx, uploadID, ruleSet)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to apply category rules",
+			"error", err,
+		)
+		return 0, err
+	}
+
+	s.logger.Info(ctx, "Category rules applied",
+		"changed", changed,
+	)
+
+	return changed, nil
+}
+
+func (s *categoryService) GetCategories(ctx context.Context, uploadID string) ([]domain.CategorySummary, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	s.logger.Debug(ctx, "Getting category summary")
+
+	summaries, err := s.repo.AggregateByCategory(ctx, uploadID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to aggregate by category",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return summaries, nil
+}
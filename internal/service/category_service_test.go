@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRule_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCategoryService(repo, log)
+
+	// Mock expectations
+	repo.EXPECT().
+		CreateCategoryRule(mock.Anything, mock.MatchedBy(func(rule domain.CategoryRule) bool {
+			return rule.ID != "" && rule.Category == "groceries" && rule.Counterparty == "SUPERMART"
+		})).
+		Return(nil).
+		Once()
+
+	// Execute
+	rule, err := svc.CreateRule(context.Background(), domain.CategoryRule{
+		Counterparty: "PT SuperMart",
+		Category:     " Groceries ",
+		Tags:         []string{"Food", "food"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "groceries", rule.Category)
+	assert.Equal(t, []string{"food"}, rule.Tags)
+}
+
+func TestCreateRule_InvalidPattern(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCategoryService(repo, log)
+
+	// Execute
+	rule, err := svc.CreateRule(context.Background(), domain.CategoryRule{
+		DescriptionPattern: "(unclosed",
+		Category:           "food",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidCategoryRule)
+	assert.Nil(t, rule)
+}
+
+func TestUpdateRule_KeepsCreatedAt(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCategoryService(repo, log)
+
+	createdAt := time.Now().Add(-time.Hour)
+	existing := &domain.CategoryRule{ID: "rule-1", Type: domain.TransactionTypeDebit, Category: "spend", CreatedAt: createdAt}
+
+	// Mock expectations
+	repo.EXPECT().
+		GetCategoryRule(mock.Anything, "rule-1").
+		Return(existing, nil).
+		Once()
+
+	repo.EXPECT().
+		UpdateCategoryRule(mock.Anything, mock.MatchedBy(func(rule domain.CategoryRule) bool {
+			return rule.ID == "rule-1" && rule.CreatedAt.Equal(createdAt) && rule.Priority == 5
+		})).
+		Return(nil).
+		Once()
+
+	// Execute
+	rule, err := svc.UpdateRule(context.Background(), "rule-1", domain.CategoryRule{
+		Priority: 5,
+		Type:     "debit",
+		Category: "spend",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.TransactionTypeDebit, rule.Type)
+}
+
+func TestApplyRules_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCategoryService(repo, log)
+
+	ruleSet, err := domain.NewRuleSet(nil)
+	require.NoError(t, err)
+
+	// Mock expectations
+	repo.EXPECT().
+		GetRuleSet(mock.Anything).
+		Return(ruleSet, nil).
+		Once()
+
+	repo.EXPECT().
+		ApplyRuleSet(mock.Anything, "upload-1", ruleSet).
+		Return(3, nil).
+		Once()
+
+	// Execute
+	changed, err := svc.ApplyRules(context.Background(), "upload-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, changed)
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type CollectionService interface {
+	CreateCollection(ctx context.Context, name string, uploadIDs []string) (*domain.UploadCollection, error)
+	GetCollection(ctx context.Context, collectionID string) (*domain.UploadCollection, error)
+	UpdateCollection(ctx context.Context, collectionID, name string, uploadIDs []string) (*domain.UploadCollection, error)
+	DeleteCollection(ctx context.Context, collectionID string) error
+	GetConsolidatedBalance(ctx context.Context, uploadIDs []string) (*domain.ConsolidatedBalance, error)
+	GetConsolidatedIssues(ctx context.Context, uploadIDs []string, page, perPage int, status *domain.TransactionStatus) ([]domain.ConsolidatedIssue, int, error)
+}
+
+type collectionService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewCollectionService(repo domain.Repository, log *logger.Logger) CollectionService {
+	return &collectionService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *collectionService) CreateCollection(ctx context.Context, name string, uploadIDs []string) (*domain.UploadCollection, error) {
+	name = strings.TrimSpace(name)
+	uploadIDs = uniqueIDs(uploadIDs)
+	if name == "" || len(uploadIDs) == 0 {
+		return nil, domain.ErrInvalidCollection
+	}
+
+	now := time.Now()
+	collection := domain.UploadCollection{
+		ID:        uuid.New().String(),
+		Name:      name,
+		UploadIDs: uploadIDs,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	s.logger.Info(ctx, "Creating collection",
+		"collection_id", collection.ID,
+		"upload_count", len(uploadIDs),
+	)
+
+	err := s.repo.CreateCollection(ctx, collection)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to create collection",
+			"collection_id", collection.ID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+func (s *collectionService) GetCollection(ctx context.Context, collectionID string) (*domain.UploadCollection, error) {
+	s.logger.Debug(ctx, "Getting collection",
+		"collection_id", collectionID,
+	)
+
+	collection, err := s.repo.GetCollection(ctx, collectionID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get collection",
+			"collection_id", collectionID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (s *collectionService) UpdateCollection(ctx context.Context, collectionID, name string, uploadIDs []string) (*domain.UploadCollection, error) {
+	name = strings.TrimSpace(name)
+	uploadIDs = uniqueIDs(uploadIDs)
+	if name == "" || len(uploadIDs) == 0 {
+		return nil, domain.ErrInvalidCollection
+	}
+
+	collection, err := s.repo.GetCollection(ctx, collectionID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get collection",
+			"collection_id", collectionID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	collection.Name = name
+	collection.UploadIDs = uploadIDs
+	collection.UpdatedAt = time.Now()
+
+	s.logger.Info(ctx, "Updating collection",
+		"collection_id", collectionID,
+		"upload_count", len(uploadIDs),
+	)
+
+	err = s.repo.UpdateCollection(ctx, *collection)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to update collection",
+			"collection_id", collectionID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (s *collectionService) DeleteCollection(ctx context.Context, collectionID string) error {
+	s.logger.Info(ctx, "Deleting collection",
+		"collection_id", collectionID,
+	)
+
+	err := s.repo.DeleteCollection(ctx, collectionID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to delete collection",
+			"collection_id", collectionID,
+			"error", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (s *collectionService) GetConsolidatedBalance(ctx context.Context, uploadIDs []string) (*domain.ConsolidatedBalance, error) {
+	uploadIDs = uniqueIDs(uploadIDs)
+	if len(uploadIDs) == 0 {
+		return nil, domain.ErrInvalidCollection
+	}
+
+	s.logger.Debug(ctx, "Getting consolidated balance",
+		"upload_ids", uploadIDs,
+	)
+
+	balance, err := s.repo.GetConsolidatedBalance(ctx, uploadIDs)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get consolidated balance",
+			"upload_ids", uploadIDs,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	s.logger.Debug(ctx, "Consolidated balance retrieved",
+		"balance", balance.Balance,
+		"duplicates_skipped", balance.DuplicatesSkipped,
+	)
+
+	return balance, nil
+}
+
+func (s *collectionService) GetConsolidatedIssues(ctx context.Context, uploadIDs []string, page, perPage int, status *domain.TransactionStatus) ([]domain.ConsolidatedIssue, int, error) {
+	uploadIDs = uniqueIDs(uploadIDs)
+	if len(uploadIDs) == 0 {
+		return nil, 0, domain.ErrInvalidCollection
+	}
+
+	s.logger.Debug(ctx, "Getting consolidated issues",
+		"upload_ids", uploadIDs,
+		"page", page,
+		"per_page", perPage,
+		"status", status,
+	)
+
+	issues, total, err := s.repo.GetConsolidatedIssues(ctx, uploadIDs, page, perPage, status)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get consolidated issues",
+			"upload_ids", uploadIDs,
+			"error", err,
+		)
+		return nil, 0, err
+	}
+
+	s.logger.Debug(ctx, "Consolidated issues retrieved",
+		"total", total,
+		"returned", len(issues),
+	)
+
+	return issues, total, nil
+}
+
+// uniqueIDs trims, drops empty values and removes repeats while keeping order
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCollection_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCollectionService(repo, log)
+
+	ctx := context.Background()
+
+	// Mock expectations
+	repo.EXPECT().
+		CreateCollection(mock.Anything, mock.MatchedBy(func(collection domain.UploadCollection) bool {
+			return collection.Name == "Q1" && len(collection.UploadIDs) == 2
+		})).
+		Return(nil).
+		Once()
+
+	// Execute - duplicates and blanks are dropped
+	collection, err := svc.CreateCollection(ctx, " Q1 ", []string{"upload-1", "upload-2", "upload-1", " "})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEmpty(t, collection.ID)
+	assert.Equal(t, "Q1", collection.Name)
+	assert.Equal(t, []string{"upload-1", "upload-2"}, collection.UploadIDs)
+}
+
+func TestCreateCollection_Invalid(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCollectionService(repo, log)
+
+	// Execute
+	collection, err := svc.CreateCollection(context.Background(), "Q1", nil)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidCollection)
+	assert.Nil(t, collection)
+}
+
+func TestUpdateCollection_NotFound(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCollectionService(repo, log)
+
+	// Mock expectations
+	repo.EXPECT().
+		GetCollection(mock.Anything, "collection-1").
+		Return(nil, domain.ErrCollectionNotFound).
+		Once()
+
+	// Execute
+	collection, err := svc.UpdateCollection(context.Background(), "collection-1", "Q1", []string{"upload-1"})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrCollectionNotFound)
+	assert.Nil(t, collection)
+}
+
+func TestGetConsolidatedBalance_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCollectionService(repo, log)
+
+	ctx := context.Background()
+	expected := &domain.ConsolidatedBalance{
+		Balance:           550000,
+		TransactionCount:  3,
+		DuplicatesSkipped: 1,
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		GetConsolidatedBalance(mock.Anything, []string{"upload-1", "upload-2"}).
+		Return(expected, nil).
+		Once()
+
+	// Execute
+	balance, err := svc.GetConsolidatedBalance(ctx, []string{"upload-1", "upload-2", "upload-2"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, balance)
+}
+
+func TestGetConsolidatedIssues_NoUploads(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCollectionService(repo, log)
+
+	// Execute
+	issues, total, err := svc.GetConsolidatedIssues(context.Background(), []string{}, 1, 10, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidCollection)
+	assert.Nil(t, issues)
+	assert.Equal(t, 0, total)
+}
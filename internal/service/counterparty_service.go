@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type CounterpartyService interface {
+	SetAlias(ctx context.Context, alias, canonical string) (*domain.CounterpartyAlias, error)
+	ListAliases(ctx context.Context) ([]domain.CounterpartyAlias, error)
+	DeleteAlias(ctx context.Context, alias string) error
+	SuggestMerges(ctx context.Context, uploadID string, threshold float64) ([]domain.MergeSuggestion, error)
+}
+
+type counterpartyService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewCounterpartyService(repo domain.Repository, log *logger.Logger) CounterpartyService {
+	return &counterpartyService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *counterpartyService) SetAlias(ctx context.Context, alias, canonical string) (*domain.CounterpartyAlias, error) {
+	entry := domain.CounterpartyAlias{
+		Alias:     domain.NormalizeCounterparty(alias),
+		Canonical: domain.NormalizeCounterparty(canonical),
+		CreatedAt: time.Now(),
+	}
+	if entry.Alias == "" || entry.Canonical == "" || entry.Alias == entry.Canonical {
+		return nil, domain.ErrInvalidAlias
+	}
+
+	s.logger.Info(ctx, "Setting counterparty alias",
+		"alias", entry.Alias,
+		"canonical", entry.Canonical,
+	)
+
+	stored, err := s.repo.SetCounterpartyAlias(ctx, entry)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to set counterparty alias",
+			"alias", entry.Alias,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func (s *counterpartyService) ListAliases(ctx context.Context) ([]domain.CounterpartyAlias, error) {
+	s.logger.Debug(ctx, "Listing counterparty aliases")
+
+	aliases, err := s.repo.ListCounterpartyAliases(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list counterparty aliases",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+func (s *counterpartyService) DeleteAlias(ctx context.Context, alias string) error {
+	alias = domain.NormalizeCounterparty(alias)
+
+	s.logger.Info(ctx, "Deleting counterparty alias",
+		"alias", alias,
+	)
+
+	err := s.repo.DeleteCounterpartyAlias(ctx, alias)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to delete counterparty alias",
+			"alias", alias,
+			"error", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (s *counterpartyService) SuggestMerges(ctx context.Context, uploadID string, threshold float64) ([]domain.MergeSuggestion, error) {
+	if threshold <= 0 || threshold > 1 {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	if uploadID != "" {
+		ctx = logger.WithUploadID(ctx, uploadID)
+	}
+
+	s.logger.Debug(ctx, "Suggesting counterparty merges",
+		"threshold", threshold,
+	)
+
+	names, err := s.repo.ListCounterpartyNames(ctx, uploadID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list counterparty names",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	suggestions := domain.SuggestMerges(names, threshold)
+
+	s.logger.Debug(ctx, "Counterparty merges suggested",
+		"names", len(names),
+		"suggestions", len(suggestions),
+	)
+
+	return suggestions, nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAlias_Normalizes(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCounterpartyService(repo, log)
+
+	// Mock expectations
+	repo.EXPECT().
+		SetCounterpartyAlias(mock.Anything, mock.MatchedBy(func(alias domain.CounterpartyAlias) bool {
+			return alias.Alias == "J DOE" && alias.Canonical == "JOHN DOE"
+		})).
+		RunAndReturn(func(ctx context.Context, alias domain.CounterpartyAlias) (*domain.CounterpartyAlias, error) {
+			return &alias, nil
+		}).
+		Once()
+
+	// Execute
+	alias, err := svc.SetAlias(context.Background(), "j. doe, pt", "John  Doe")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "J DOE", alias.Alias)
+}
+
+func TestSetAlias_SameName(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCounterpartyService(repo, log)
+
+	// Execute
+	alias, err := svc.SetAlias(context.Background(), "PT John Doe", "JOHN DOE")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidAlias)
+	assert.Nil(t, alias)
+}
+
+func TestSuggestMerges_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCounterpartyService(repo, log)
+
+	names := []domain.CounterpartyName{
+		{Name: "BOB SMITH", TransactionCount: 4},
+		{Name: "J DOE", TransactionCount: 1},
+		{Name: "JOHN DOE", TransactionCount: 3},
+		{Name: "MARHTA", TransactionCount: 2},
+		{Name: "MARTHA", TransactionCount: 1},
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		ListCounterpartyNames(mock.Anything, "upload-1").
+		Return(names, nil).
+		Once()
+
+	// Execute
+	suggestions, err := svc.SuggestMerges(context.Background(), "upload-1", 0.88)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, suggestions, 2)
+
+	assert.Equal(t, "MARHTA", suggestions[0].Name)
+	assert.Equal(t, "MARTHA", suggestions[0].Candidate)
+	assert.InDelta(t, 0.961, suggestions[0].Similarity, 0.001)
+
+	assert.Equal(t, "JOHN DOE", suggestions[1].Name)
+	assert.Equal(t, "J DOE", suggestions[1].Candidate)
+}
+
+func TestSuggestMerges_InvalidThreshold(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewCounterpartyService(repo, log)
+
+	// Execute
+	suggestions, err := svc.SuggestMerges(context.Background(), "", 1.5)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	assert.Nil(t, suggestions)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/csv"
 	"fmt"
@@ -40,11 +41,28 @@ func (p *CSVProcessor) ProcessStream(ctx context.Context, uploadID string, reade
 	csvReader := csv.NewReader(reader)
 	csvReader.ReuseRecord = true // Optimize memory usage
 	csvReader.TrimLeadingSpace = true
+	// The account, reference and reversal columns are optional per row,
+	// parseTransaction checks the field count
+	csvReader.FieldsPerRecord = -1
 
 	lineNumber := 0
 	successCount := 0
 	errorCount := 0
 
+	// Account numbers repeat on most rows, resolve each one once per file
+	accountIDs := make(map[string]string)
+	references := make(map[string]bool)
+
+	// Screen against the watchlist as it was when the file arrived
+	watchlist, err := p.repo.GetWatchlist(ctx)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to load watchlist",
+			"error", err,
+		)
+		p.failUpload(ctx, uploadID)
+		return err
+	}
+
 	for {
 		record, err := csvReader.Read()
 		if err == io.EOF {
@@ -56,6 +74,13 @@ func (p *CSVProcessor) ProcessStream(ctx context.Context, uploadID string, reade
 				"error", err,
 			)
 			errorCount++
+
+			// Field count errors still consume a line, keep numbering aligned
+			// with the file so rejected rows can be located
+			if len(record) > 0 {
+				lineNumber++
+				p.recordRejection(ctx, uploadID, lineNumber, record, err)
+			}
 			continue
 		}
 
@@ -68,11 +93,55 @@ func (p *CSVProcessor) ProcessStream(ctx context.Context, uploadID string, reade
 				"error", err,
 			)
 			errorCount++
+			p.recordRejection(ctx, uploadID, lineNumber, record, err)
 			continue
 		}
 
+		if len(record) >= 7 {
+			tx.AccountID, err = p.resolveAccount(ctx, strings.TrimSpace(record[6]), accountIDs)
+			if err != nil {
+				p.logger.Warn(ctx, "Failed to resolve account",
+					"line", lineNumber,
+					"error", err,
+				)
+				errorCount++
+				p.recordRejection(ctx, uploadID, lineNumber, record, err)
+				continue
+			}
+		}
+
+		if tx.Reference != "" {
+			err = checkReference(tx.Reference, references)
+			if err != nil {
+				p.logger.Warn(ctx, "Duplicate transaction reference",
+					"line", lineNumber,
+					"error", err,
+				)
+				errorCount++
+				p.recordRejection(ctx, uploadID, lineNumber, record, err)
+				continue
+			}
+		}
+
+		tx.WatchlistHits = watchlist.Screen(tx.Counterparty)
+		if len(tx.WatchlistHits) > 0 {
+			p.logger.Warn(ctx, "Counterparty on watchlist",
+				"line", lineNumber,
+				"counterparty", tx.Counterparty,
+				"entry_name", tx.WatchlistHits[0].EntryName,
+				"match", tx.WatchlistHits[0].Match,
+			)
+		}
+
+		// Rows with a bank reference are keyed by it, so the same row keeps
+		// its event ID when the file is re-sorted
+		eventID := fmt.Sprintf("%s-%d", uploadID, lineNumber)
+		if tx.Reference != "" {
+			eventID = fmt.Sprintf("%s-ref-%s", uploadID, tx.Reference)
+		}
+
 		event := eventbus.Event{
-			ID:   fmt.Sprintf("%s-%d", uploadID, lineNumber),
+			ID:   eventID,
 			Type: eventbus.EventTypeReconciliation,
 			Payload: eventbus.ReconciliationEvent{
 				UploadID:    uploadID,
@@ -82,6 +151,8 @@ func (p *CSVProcessor) ProcessStream(ctx context.Context, uploadID string, reade
 			Timestamp: time.Now(),
 		}
 
+		// Only rows actually handed to the workers count towards the total the
+		// upload waits for before it is reconciled
 		err = p.eventBus.Publish(ctx, event)
 		if err != nil {
 			p.logger.Error(ctx, "Failed to publish event",
@@ -96,17 +167,27 @@ func (p *CSVProcessor) ProcessStream(ctx context.Context, uploadID string, reade
 		successCount++
 	}
 
+	err = p.repo.SetUploadTotalRows(ctx, uploadID, successCount)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to set upload total rows",
+			"error", err,
+		)
+	}
+
 	if errorCount > 0 && successCount == 0 {
-		err := p.repo.UpdateUploadStatus(ctx, uploadID, domain.UploadStatusFailed)
+		p.failUpload(ctx, uploadID)
+	} else {
+		err = p.repo.UpdateUploadStatus(ctx, uploadID, domain.UploadStatusCompleted)
 		if err != nil {
-			p.logger.Error(ctx, "Failed to update upload status to failed",
+			p.logger.Error(ctx, "Failed to update upload status to completed",
 				"error", err,
 			)
 		}
-	} else {
-		err := p.repo.UpdateUploadStatus(ctx, uploadID, domain.UploadStatusCompleted)
+
+		// Workers may have drained every row before the status flipped
+		err = eventbus.PublishIfReconciled(ctx, p.eventBus, p.repo, uploadID)
 		if err != nil {
-			p.logger.Error(ctx, "Failed to update upload status to completed",
+			p.logger.Error(ctx, "Failed to publish upload reconciled event",
 				"error", err,
 			)
 		}
@@ -121,9 +202,88 @@ func (p *CSVProcessor) ProcessStream(ctx context.Context, uploadID string, reade
 	return nil
 }
 
+// failUpload marks the upload failed and announces it
+func (p *CSVProcessor) failUpload(ctx context.Context, uploadID string) {
+	err := p.repo.UpdateUploadStatus(ctx, uploadID, domain.UploadStatusFailed)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to update upload status to failed",
+			"error", err,
+		)
+	}
+
+	err = p.eventBus.Publish(ctx, eventbus.Event{
+		ID:   uploadID + "-failed",
+		Type: eventbus.EventTypeUploadFailed,
+		Payload: eventbus.UploadFailedEvent{
+			UploadID: uploadID,
+		},
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		p.logger.Error(ctx, "Failed to publish upload failed event",
+			"error", err,
+		)
+	}
+}
+
+func (p *CSVProcessor) recordRejection(ctx context.Context, uploadID string, lineNumber int, record []string, reason error) {
+	// Re-encode the record so quoting survives, it is reused by the reader
+	var raw bytes.Buffer
+	writer := csv.NewWriter(&raw)
+	_ = writer.Write(record)
+	writer.Flush()
+
+	err := p.repo.AddRejectedRow(ctx, uploadID, domain.RejectedRow{
+		LineNumber: lineNumber,
+		Raw:        strings.TrimRight(raw.String(), "\n"),
+		Reason:     reason.Error(),
+	})
+	if err != nil {
+		p.logger.Error(ctx, "Failed to record rejected row",
+			"line", lineNumber,
+			"error", err,
+		)
+	}
+}
+
+// resolveAccount maps the optional account column to an account ID, an empty
+// column leaves the row on the upload's account
+func (p *CSVProcessor) resolveAccount(ctx context.Context, accountNumber string, cache map[string]string) (string, error) {
+	if accountNumber == "" {
+		return "", nil
+	}
+
+	if accountID, ok := cache[accountNumber]; ok {
+		return accountID, nil
+	}
+
+	account, err := p.repo.FindAccountByNumber(ctx, accountNumber)
+	if err != nil {
+		if err == domain.ErrAccountNotFound {
+			return "", fmt.Errorf("unknown account: %s", accountNumber)
+		}
+		return "", err
+	}
+
+	cache[accountNumber] = account.ID
+	return account.ID, nil
+}
+
+// checkReference rejects a reference seen earlier in the file. Other uploads
+// may carry it too, overlapping statements are deduplicated when they are
+// consolidated.
+func checkReference(reference string, seen map[string]bool) error {
+	if seen[reference] {
+		return fmt.Errorf("duplicate reference in file: %s", reference)
+	}
+	seen[reference] = true
+
+	return nil
+}
+
 func (p *CSVProcessor) parseTransaction(record []string, lineNumber int) (domain.Transaction, error) {
-	if len(record) != 6 {
-		return domain.Transaction{}, fmt.Errorf("invalid record format: expected 6 fields, got %d", len(record))
+	if len(record) < 6 || len(record) > 9 {
+		return domain.Transaction{}, fmt.Errorf("invalid record format: expected 6 to 9 fields, got %d", len(record))
 	}
 
 	timestamp, err := strconv.ParseInt(strings.TrimSpace(record[0]), 10, 64)
@@ -136,24 +296,45 @@ func (p *CSVProcessor) parseTransaction(record []string, lineNumber int) (domain
 		return domain.Transaction{}, fmt.Errorf("invalid amount: %w", err)
 	}
 
-	txType := strings.TrimSpace(strings.ToUpper(record[2]))
-	if txType != string(domain.TransactionTypeCredit) && txType != string(domain.TransactionTypeDebit) {
+	txType := domain.TransactionType(strings.TrimSpace(strings.ToUpper(record[2])))
+	if !txType.IsValid() {
 		return domain.Transaction{}, fmt.Errorf("invalid transaction type: %s", txType)
 	}
 
-	status := strings.TrimSpace(strings.ToUpper(record[4]))
-	if status != string(domain.TransactionStatusSuccess) &&
-		status != string(domain.TransactionStatusFailed) &&
-		status != string(domain.TransactionStatusPending) {
+	status := domain.TransactionStatus(strings.TrimSpace(strings.ToUpper(record[4])))
+	if !status.IsValid() {
 		return domain.Transaction{}, fmt.Errorf("invalid status: %s", status)
 	}
 
-	return domain.Transaction{
+	tx := domain.Transaction{
 		Timestamp:    timestamp,
 		Counterparty: strings.TrimSpace(record[1]),
-		Type:         domain.TransactionType(txType),
+		Type:         txType,
 		Amount:       amount,
-		Status:       domain.TransactionStatus(status),
+		Status:       status,
 		Description:  strings.TrimSpace(record[5]),
-	}, nil
+		Source:       domain.TransactionSourceStatement,
+	}
+	if len(record) >= 8 {
+		tx.Reference = strings.TrimSpace(record[7])
+	}
+
+	// A reversal names the reference of the row it reverses in the last column
+	var reverses string
+	if len(record) == 9 {
+		reverses = strings.TrimSpace(record[8])
+	}
+	if txType == domain.TransactionTypeReversal {
+		if reverses == "" {
+			return domain.Transaction{}, fmt.Errorf("reversal without the reference it reverses")
+		}
+		if reverses == tx.Reference {
+			return domain.Transaction{}, fmt.Errorf("reversal cannot reverse itself: %s", reverses)
+		}
+		tx.ReversalOf = &domain.TransactionLink{Reference: reverses}
+	} else if reverses != "" {
+		return domain.Transaction{}, fmt.Errorf("only REVERSAL rows can reverse a transaction")
+	}
+
+	return tx, nil
 }
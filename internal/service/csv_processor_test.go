@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/internal/eventbus"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProcessStream_WatchlistUnavailable(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	processor := NewCSVProcessor(eventbus.New(log, nil), repo, log)
+
+	ctx := context.Background()
+	uploadID := "test-upload-123"
+	loadErr := errors.New("watchlist unavailable")
+
+	// Mock expectations
+	repo.EXPECT().
+		GetWatchlist(mock.Anything).
+		Return(nil, loadErr).
+		Once()
+	repo.EXPECT().
+		UpdateUploadStatus(mock.Anything, uploadID, domain.UploadStatusFailed).
+		Return(nil).
+		Once()
+
+	// Execute
+	err := processor.ProcessStream(ctx, uploadID, strings.NewReader("1674507883,JOHN DOE,CREDIT,500000,SUCCESS,salary"))
+
+	// Assert
+	assert.ErrorIs(t, err, loadErr)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type IssueService interface {
+	GetIssue(ctx context.Context, uploadID string, lineNumber int) (*domain.IssueDetail, error)
+	UpdateIssue(ctx context.Context, uploadID string, lineNumber int, update domain.IssueUpdate) (*domain.IssueDetail, error)
+}
+
+type issueService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewIssueService(repo domain.Repository, log *logger.Logger) IssueService {
+	return &issueService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *issueService) GetIssue(ctx context.Context, uploadID string, lineNumber int) (*domain.IssueDetail, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	s.logger.Debug(ctx, "Getting issue",
+		"line_number", lineNumber,
+	)
+
+	issue, err := s.repo.GetIssue(ctx, uploadID, lineNumber)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get issue",
+			"line_number", lineNumber,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return issue, nil
+}
+
+func (s *issueService) UpdateIssue(ctx context.Context, uploadID string, lineNumber int, update domain.IssueUpdate) (*domain.IssueDetail, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	update.Normalize()
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Updating issue",
+		"line_number", lineNumber,
+		"state", update.State,
+		"actor", update.Actor,
+	)
+
+	issue, err := s.repo.UpdateIssue(ctx, uploadID, lineNumber, update)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to update issue",
+			"line_number", lineNumber,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return issue, nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateIssue_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewIssueService(repo, log)
+
+	state := domain.IssueState(" Investigating ")
+	detail := &domain.IssueDetail{}
+
+	// Mock expectations
+	repo.EXPECT().
+		UpdateIssue(mock.Anything, "upload-1", 3, mock.MatchedBy(func(update domain.IssueUpdate) bool {
+			return *update.State == domain.IssueStateInvestigating && update.Note == "checking" && update.Actor == "bob"
+		})).
+		Return(detail, nil).
+		Once()
+
+	// Execute
+	issue, err := svc.UpdateIssue(context.Background(), "upload-1", 3, domain.IssueUpdate{
+		State: &state,
+		Note:  " checking ",
+		Actor: "bob",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, detail, issue)
+}
+
+func TestUpdateIssue_Invalid(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewIssueService(repo, log)
+
+	state := domain.IssueState("closed")
+
+	// Execute
+	_, errState := svc.UpdateIssue(context.Background(), "upload-1", 3, domain.IssueUpdate{State: &state})
+	_, errEmpty := svc.UpdateIssue(context.Background(), "upload-1", 3, domain.IssueUpdate{Actor: "bob"})
+
+	// Assert
+	assert.ErrorIs(t, errState, domain.ErrInvalidIssueUpdate)
+	assert.ErrorIs(t, errEmpty, domain.ErrInvalidIssueUpdate)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+type matchCandidate struct {
+	bank        int
+	ledger      int
+	exactAmount bool
+	similarity  float64
+	dateDiff    int64
+}
+
+// matchLedger pairs statement rows with ledger entries. Every pair of the same
+// type within the date tolerance is a candidate when the amounts are equal or
+// the text is similar enough; candidates are then taken greedily, exact amounts
+// first, most similar next, closest in time last. Both slices must be ordered
+// by timestamp.
+func matchLedger(bank []domain.IssueTransaction, ledger []domain.LedgerEntry, opts domain.ReconciliationOptions, aliases domain.CounterpartyAliases) []domain.ReconciliationResult {
+	var candidates []matchCandidate
+	for i, tx := range bank {
+		first := sort.Search(len(ledger), func(j int) bool {
+			return ledger[j].Timestamp >= tx.Timestamp-opts.DateTolerance
+		})
+
+		for j := first; j < len(ledger) && ledger[j].Timestamp <= tx.Timestamp+opts.DateTolerance; j++ {
+			entry := ledger[j]
+			if entry.Type != tx.Type {
+				continue
+			}
+
+			similarity := entrySimilarity(tx, entry, aliases)
+			exactAmount := entry.Amount == tx.Amount
+			if !exactAmount && similarity < opts.MinSimilarity {
+				continue
+			}
+
+			candidates = append(candidates, matchCandidate{
+				bank:        i,
+				ledger:      j,
+				exactAmount: exactAmount,
+				similarity:  similarity,
+				dateDiff:    absInt64(entry.Timestamp - tx.Timestamp),
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.exactAmount != b.exactAmount {
+			return a.exactAmount
+		}
+		if a.similarity != b.similarity {
+			return a.similarity > b.similarity
+		}
+		return a.dateDiff < b.dateDiff
+	})
+
+	bankMatched := make([]bool, len(bank))
+	ledgerMatched := make([]bool, len(ledger))
+	results := make([]domain.ReconciliationResult, 0, len(bank)+len(ledger))
+
+	for _, candidate := range candidates {
+		if bankMatched[candidate.bank] || ledgerMatched[candidate.ledger] {
+			continue
+		}
+		bankMatched[candidate.bank] = true
+		ledgerMatched[candidate.ledger] = true
+
+		tx := bank[candidate.bank]
+		entry := ledger[candidate.ledger]
+
+		classification := domain.MatchClassificationMatched
+		if !candidate.exactAmount {
+			classification = domain.MatchClassificationAmountMismatch
+		}
+
+		results = append(results, domain.ReconciliationResult{
+			Classification:   classification,
+			Bank:             &tx,
+			Ledger:           &entry,
+			Similarity:       candidate.similarity,
+			DateDifference:   candidate.dateDiff,
+			AmountDifference: tx.Amount - entry.Amount,
+		})
+	}
+
+	for i := range bank {
+		if !bankMatched[i] {
+			tx := bank[i]
+			results = append(results, domain.ReconciliationResult{
+				Classification: domain.MatchClassificationUnmatchedBank,
+				Bank:           &tx,
+			})
+		}
+	}
+
+	for j := range ledger {
+		if !ledgerMatched[j] {
+			entry := ledger[j]
+			results = append(results, domain.ReconciliationResult{
+				Classification: domain.MatchClassificationUnmatchedLedger,
+				Ledger:         &entry,
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return resultTimestamp(results[i]) < resultTimestamp(results[j])
+	})
+
+	return results
+}
+
+// entrySimilarity scores how likely a ledger entry describes a statement row,
+// from 0 to 1. A ledger reference equal to the row's bank reference or quoted
+// in its description is a certain hit, otherwise counterparty name similarity
+// and description overlap weigh half each.
+func entrySimilarity(tx domain.IssueTransaction, entry domain.LedgerEntry, aliases domain.CounterpartyAliases) float64 {
+	if reference := normalizeText(entry.Reference); reference != "" {
+		if normalizeText(tx.Reference) == reference || strings.Contains(normalizeText(tx.Description), reference) {
+			return 1
+		}
+	}
+
+	textScore := tokenSimilarity(tx.Description, entry.Description)
+	nameScore := domain.CounterpartySimilarity(aliases, tx.Counterparty, entry.Counterparty)
+
+	return (textScore + nameScore) / 2
+}
+
+// tokenSimilarity is the Jaccard index of the words of both texts
+func tokenSimilarity(a, b string) float64 {
+	tokensA := tokenize(a)
+	tokensB := tokenize(b)
+	if len(tokensA) == 0 && len(tokensB) == 0 {
+		return 1
+	}
+
+	shared := 0
+	for token := range tokensA {
+		if tokensB[token] {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(tokensA)+len(tokensB)-shared)
+}
+
+func tokenize(text string) map[string]bool {
+	tokens := make(map[string]bool)
+	for _, token := range strings.FieldsFunc(strings.ToUpper(text), isSeparator) {
+		tokens[token] = true
+	}
+	return tokens
+}
+
+// normalizeText upper-cases and drops everything but letters and digits so
+// references match regardless of punctuation
+func normalizeText(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToUpper(text), isSeparator), "")
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func resultTimestamp(result domain.ReconciliationResult) int64 {
+	if result.Bank != nil {
+		return result.Bank.Timestamp
+	}
+	return result.Ledger.Timestamp
+}
+
+func absInt64(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
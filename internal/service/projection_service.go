@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type ProjectionService interface {
+	ProjectBalance(ctx context.Context, query domain.ProjectionQuery) (*domain.Projection, error)
+}
+
+type projectionService struct {
+	repo              domain.Repository
+	rules             domain.RecurringRules
+	settleProbability float64
+	logger            *logger.Logger
+}
+
+func NewProjectionService(repo domain.Repository, log *logger.Logger, settleProbability float64) ProjectionService {
+	return &projectionService{
+		repo:              repo,
+		rules:             domain.DefaultRecurringRules(),
+		settleProbability: settleProbability,
+		logger:            log,
+	}
+}
+
+func (s *projectionService) ProjectBalance(ctx context.Context, query domain.ProjectionQuery) (*domain.Projection, error) {
+	ctx = logger.WithUploadID(ctx, query.UploadID)
+
+	if query.SettleProbability == nil {
+		probability := s.settleProbability
+		query.SettleProbability = &probability
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug(ctx, "Projecting balance",
+		"horizon", query.Horizon.String(),
+		"settle_probability", *query.SettleProbability,
+	)
+
+	projection, err := s.repo.ProjectBalance(ctx, query, s.rules, time.Now())
+	if err != nil {
+		if err != domain.ErrUploadNotFound {
+			s.logger.Error(ctx, "Failed to project balance",
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+
+	if len(projection.NegativeDates) > 0 {
+		s.logger.Info(ctx, "Projected balance goes negative",
+			"first_date", projection.NegativeDates[0],
+			"lowest_balance", projection.LowestBalance,
+		)
+	}
+
+	return projection, nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectBalance_DefaultSettleProbability(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewProjectionService(repo, log, 0.8)
+
+	expected := &domain.Projection{UploadID: "upload-1", SettleProbability: 0.8}
+
+	// Mock expectations
+	repo.EXPECT().
+		ProjectBalance(mock.Anything, mock.MatchedBy(func(query domain.ProjectionQuery) bool {
+			return query.SettleProbability != nil && *query.SettleProbability == 0.8
+		}), domain.DefaultRecurringRules(), mock.Anything).
+		Return(expected, nil).
+		Once()
+
+	// Execute
+	projection, err := svc.ProjectBalance(context.Background(), domain.ProjectionQuery{
+		UploadID: "upload-1",
+		Horizon:  domain.DefaultProjectionHorizon,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, projection)
+}
+
+func TestProjectBalance_InvalidQuery(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewProjectionService(repo, log, 0.8)
+
+	probability := 1.5
+
+	tests := []domain.ProjectionQuery{
+		{UploadID: "upload-1", Horizon: 0},
+		{UploadID: "upload-1", Horizon: 400 * 24 * time.Hour},
+		{UploadID: "upload-1", Horizon: time.Hour, SettleProbability: &probability},
+	}
+
+	for _, query := range tests {
+		// Execute
+		projection, err := svc.ProjectBalance(context.Background(), query)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+		assert.Nil(t, projection)
+	}
+}
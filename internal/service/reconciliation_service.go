@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type ReconciliationService interface {
+	StartRun(ctx context.Context, uploadID string, ledger io.Reader, opts domain.ReconciliationOptions) (*domain.ReconciliationRun, error)
+	GetRun(ctx context.Context, runID string) (*domain.ReconciliationRun, error)
+	GetResults(ctx context.Context, query domain.ReconciliationResultQuery) ([]domain.ReconciliationResult, int, error)
+}
+
+type reconciliationService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewReconciliationService(repo domain.Repository, log *logger.Logger) ReconciliationService {
+	return &reconciliationService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *reconciliationService) StartRun(ctx context.Context, uploadID string, ledger io.Reader, opts domain.ReconciliationOptions) (*domain.ReconciliationRun, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	err := opts.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := s.repo.GetUpload(ctx, uploadID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get upload",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	// Matching a statement that is still streaming in would report its
+	// missing rows as unmatched ledger entries
+	if upload.ReconciledAt == nil {
+		return nil, domain.ErrUploadNotReconciled
+	}
+
+	// Watchlist hits are screened before funds are matched against the ledger
+	if upload.Status == domain.UploadStatusNeedsReview {
+		return nil, domain.ErrUploadNeedsReview
+	}
+
+	// The ledger is parsed up front, the request body is gone once we return
+	entries, invalid, err := parseLedger(ledger)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to read ledger",
+			"error", err,
+		)
+		return nil, domain.ErrInvalidLedger
+	}
+	if len(entries) == 0 && invalid > 0 {
+		return nil, domain.ErrInvalidLedger
+	}
+
+	run := domain.ReconciliationRun{
+		ID:                uuid.New().String(),
+		UploadID:          uploadID,
+		Status:            domain.ReconciliationRunStatusProcessing,
+		Options:           opts,
+		LedgerEntries:     len(entries),
+		InvalidLedgerRows: invalid,
+		CreatedAt:         time.Now(),
+	}
+
+	s.logger.Info(ctx, "Creating reconciliation run",
+		"run_id", run.ID,
+		"ledger_entries", len(entries),
+		"invalid_ledger_rows", invalid,
+	)
+
+	err = s.repo.CreateReconciliationRun(ctx, run)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to create reconciliation run",
+			"run_id", run.ID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	go func() {
+		runCtx := logger.WithUploadID(context.Background(), uploadID)
+		s.execute(runCtx, run, entries)
+	}()
+
+	return &run, nil
+}
+
+func (s *reconciliationService) GetRun(ctx context.Context, runID string) (*domain.ReconciliationRun, error) {
+	s.logger.Debug(ctx, "Getting reconciliation run",
+		"run_id", runID,
+	)
+
+	run, err := s.repo.GetReconciliationRun(ctx, runID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get reconciliation run",
+			"run_id", runID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (s *reconciliationService) GetResults(ctx context.Context, query domain.ReconciliationResultQuery) ([]domain.ReconciliationResult, int, error) {
+	if query.Classification != nil && !query.Classification.IsValid() {
+		return nil, 0, domain.ErrInvalidQuery
+	}
+
+	s.logger.Debug(ctx, "Getting reconciliation results",
+		"run_id", query.RunID,
+		"page", query.Page,
+		"per_page", query.PerPage,
+	)
+
+	results, total, err := s.repo.GetReconciliationResults(ctx, query)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get reconciliation results",
+			"run_id", query.RunID,
+			"error", err,
+		)
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// execute matches the ledger against the settled statement rows and stores
+// the outcome, the run is marked failed when anything goes wrong
+func (s *reconciliationService) execute(ctx context.Context, run domain.ReconciliationRun, entries []domain.LedgerEntry) {
+	s.logger.Info(ctx, "Starting reconciliation run",
+		"run_id", run.ID,
+	)
+
+	results, err := s.matchRun(ctx, run, entries)
+
+	now := time.Now()
+	run.CompletedAt = &now
+	if err != nil {
+		s.logger.Error(ctx, "Reconciliation run failed",
+			"run_id", run.ID,
+			"error", err,
+		)
+		run.Status = domain.ReconciliationRunStatusFailed
+		run.Error = err.Error()
+	} else {
+		run.Status = domain.ReconciliationRunStatusCompleted
+		for _, result := range results {
+			run.Summary.Add(result.Classification)
+		}
+	}
+
+	err = s.repo.UpdateReconciliationRun(ctx, run)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to update reconciliation run",
+			"run_id", run.ID,
+			"error", err,
+		)
+		return
+	}
+
+	s.logger.Info(ctx, "Reconciliation run finished",
+		"run_id", run.ID,
+		"status", run.Status,
+		"matched", run.Summary.Matched,
+		"amount_mismatch", run.Summary.AmountMismatch,
+		"unmatched_bank", run.Summary.UnmatchedBank,
+		"unmatched_ledger", run.Summary.UnmatchedLedger,
+	)
+}
+
+func (s *reconciliationService) matchRun(ctx context.Context, run domain.ReconciliationRun, entries []domain.LedgerEntry) ([]domain.ReconciliationResult, error) {
+	bank, err := s.settledTransactions(ctx, run.UploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := s.repo.ListCounterpartyAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := matchLedger(bank, entries, run.Options, domain.NewCounterpartyAliases(aliases))
+
+	err = s.repo.SaveReconciliationResults(ctx, run.ID, results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// settledTransactions loads the SUCCESS rows of an upload in timestamp order,
+// failed and pending rows never reach a ledger
+func (s *reconciliationService) settledTransactions(ctx context.Context, uploadID string) ([]domain.IssueTransaction, error) {
+	query := domain.TransactionQuery{
+		UploadID:  uploadID,
+		Statuses:  []domain.TransactionStatus{domain.TransactionStatusSuccess},
+		SortBy:    domain.SortByTimestamp,
+		SortOrder: domain.SortOrderAsc,
+		PerPage:   exportBatchSize,
+	}
+
+	var transactions []domain.IssueTransaction
+	var cursor *domain.Cursor
+	for {
+		items, hasMore, err := s.repo.SeekTransactions(ctx, query, cursor)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, items...)
+
+		if !hasMore || len(items) == 0 {
+			break
+		}
+
+		next := domain.NewCursor(domain.CursorDirectionNext, domain.SortByTimestamp, items[len(items)-1])
+		cursor = &next
+	}
+
+	return transactions, nil
+}
+
+// parseLedger reads timestamp,counterparty,type,amount,description[,reference]
+// rows and returns them in timestamp order with the number of unusable rows
+func parseLedger(reader io.Reader) ([]domain.LedgerEntry, int, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	var entries []domain.LedgerEntry
+	invalid := 0
+	lineNumber := 0
+
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if _, ok := err.(*csv.ParseError); ok {
+				lineNumber++
+				invalid++
+				continue
+			}
+			return nil, 0, err
+		}
+
+		lineNumber++
+
+		entry, err := parseLedgerEntry(record, lineNumber)
+		if err != nil {
+			invalid++
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp < entries[j].Timestamp
+	})
+
+	return entries, invalid, nil
+}
+
+func parseLedgerEntry(record []string, lineNumber int) (domain.LedgerEntry, error) {
+	if len(record) != 5 && len(record) != 6 {
+		return domain.LedgerEntry{}, fmt.Errorf("invalid ledger format: expected 5 or 6 fields, got %d", len(record))
+	}
+
+	timestamp, err := strconv.ParseInt(strings.TrimSpace(record[0]), 10, 64)
+	if err != nil {
+		return domain.LedgerEntry{}, fmt.Errorf("invalid timestamp: %w", err)
+	}
+
+	amount, err := strconv.ParseInt(strings.TrimSpace(record[3]), 10, 64)
+	if err != nil {
+		return domain.LedgerEntry{}, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	txType := domain.TransactionType(strings.ToUpper(strings.TrimSpace(record[2])))
+	if !txType.IsValid() {
+		return domain.LedgerEntry{}, fmt.Errorf("invalid transaction type: %s", txType)
+	}
+
+	entry := domain.LedgerEntry{
+		LineNumber:   lineNumber,
+		Timestamp:    timestamp,
+		Counterparty: strings.TrimSpace(record[1]),
+		Type:         txType,
+		Amount:       amount,
+		Description:  strings.TrimSpace(record[4]),
+	}
+	if len(record) == 6 {
+		entry.Reference = strings.TrimSpace(record[5])
+	}
+
+	return entry, nil
+}
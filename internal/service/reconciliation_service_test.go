@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchLedger_Classifications(t *testing.T) {
+	bank := []domain.IssueTransaction{
+		{Transaction: domain.Transaction{Timestamp: 1000, Counterparty: "JOHN DOE", Type: domain.TransactionTypeDebit, Amount: 250000, Description: "restaurant"}, LineNumber: 1},
+		{Transaction: domain.Transaction{Timestamp: 2000, Counterparty: "JANE DOE", Type: domain.TransactionTypeCredit, Amount: 500000, Description: "salary INV-0042"}, LineNumber: 2},
+		{Transaction: domain.Transaction{Timestamp: 3000, Counterparty: "BOB SMITH", Type: domain.TransactionTypeDebit, Amount: 100000, Description: "groceries"}, LineNumber: 3},
+	}
+	ledger := []domain.LedgerEntry{
+		// Same row booked a day later
+		{LineNumber: 1, Timestamp: 1000 + 86400, Counterparty: "John Doe", Type: domain.TransactionTypeDebit, Amount: 250000, Description: "dinner"},
+		// Reference quoted in the statement, amount differs
+		{LineNumber: 2, Timestamp: 2100, Counterparty: "Jane Doe Ltd", Type: domain.TransactionTypeCredit, Amount: 450000, Description: "payroll", Reference: "inv 0042"},
+		// Wrong type, never paired
+		{LineNumber: 3, Timestamp: 3000, Counterparty: "BOB SMITH", Type: domain.TransactionTypeCredit, Amount: 100000, Description: "groceries"},
+	}
+
+	results := matchLedger(bank, ledger, domain.ReconciliationOptions{
+		DateTolerance: 2 * 86400,
+		MinSimilarity: 0.75,
+	}, nil)
+
+	require.Len(t, results, 4)
+
+	summary := domain.ReconciliationSummary{}
+	for _, result := range results {
+		summary.Add(result.Classification)
+	}
+	assert.Equal(t, domain.ReconciliationSummary{
+		Matched:         1,
+		UnmatchedBank:   1,
+		UnmatchedLedger: 1,
+		AmountMismatch:  1,
+	}, summary)
+
+	assert.Equal(t, domain.MatchClassificationMatched, results[0].Classification)
+	assert.Equal(t, int64(86400), results[0].DateDifference)
+
+	assert.Equal(t, domain.MatchClassificationAmountMismatch, results[1].Classification)
+	assert.Equal(t, float64(1), results[1].Similarity)
+	assert.Equal(t, int64(50000), results[1].AmountDifference)
+}
+
+func TestMatchLedger_PrefersExactAmount(t *testing.T) {
+	bank := []domain.IssueTransaction{
+		{Transaction: domain.Transaction{Timestamp: 1000, Counterparty: "JOHN DOE", Type: domain.TransactionTypeDebit, Amount: 100, Description: "coffee"}, LineNumber: 1},
+	}
+	ledger := []domain.LedgerEntry{
+		{LineNumber: 1, Timestamp: 1000, Counterparty: "JOHN DOE", Type: domain.TransactionTypeDebit, Amount: 120, Description: "coffee"},
+		{LineNumber: 2, Timestamp: 1500, Counterparty: "SOMEONE", Type: domain.TransactionTypeDebit, Amount: 100, Description: "misc"},
+	}
+
+	results := matchLedger(bank, ledger, domain.ReconciliationOptions{DateTolerance: 3600, MinSimilarity: 0.5}, nil)
+
+	require.Len(t, results, 2)
+	assert.Equal(t, domain.MatchClassificationMatched, results[0].Classification)
+	assert.Equal(t, 2, results[0].Ledger.LineNumber)
+	assert.Equal(t, domain.MatchClassificationUnmatchedLedger, results[1].Classification)
+}
+
+func TestMatchLedger_UsesAliases(t *testing.T) {
+	bank := []domain.IssueTransaction{
+		{Transaction: domain.Transaction{Timestamp: 1000, Counterparty: "J. DOE PT", Type: domain.TransactionTypeDebit, Amount: 100, Description: "rent"}, LineNumber: 1},
+	}
+	ledger := []domain.LedgerEntry{
+		{LineNumber: 1, Timestamp: 1000, Counterparty: "Acme Property", Type: domain.TransactionTypeDebit, Amount: 90, Description: "rent"},
+	}
+	opts := domain.ReconciliationOptions{DateTolerance: 0, MinSimilarity: 0.9}
+
+	results := matchLedger(bank, ledger, opts, nil)
+	require.Len(t, results, 2)
+
+	results = matchLedger(bank, ledger, opts, domain.CounterpartyAliases{"J DOE": "ACME PROPERTY"})
+	require.Len(t, results, 1)
+	assert.Equal(t, domain.MatchClassificationAmountMismatch, results[0].Classification)
+}
+
+func TestParseLedger(t *testing.T) {
+	entries, invalid, err := parseLedger(strings.NewReader(`1674507884,JANE DOE,CREDIT,500000,salary,INV-0042
+1674507883,JOHN DOE,debit,250000,restaurant
+bad,JOHN DOE,DEBIT,1,x
+1674507885,BOB SMITH,TRANSFER,1,x`))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, invalid)
+	require.Len(t, entries, 2)
+	assert.Equal(t, int64(1674507883), entries[0].Timestamp)
+	assert.Equal(t, 2, entries[0].LineNumber)
+	assert.Equal(t, domain.TransactionTypeDebit, entries[0].Type)
+	assert.Equal(t, "INV-0042", entries[1].Reference)
+}
+
+func TestStartRun_UploadNotReconciled(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewReconciliationService(repo, log)
+
+	// Mock expectations
+	repo.EXPECT().
+		GetUpload(mock.Anything, "upload-1").
+		Return(&domain.Upload{ID: "upload-1", Status: domain.UploadStatusProcessing}, nil).
+		Once()
+
+	// Execute
+	run, err := svc.StartRun(context.Background(), "upload-1", strings.NewReader(""), domain.ReconciliationOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUploadNotReconciled)
+	assert.Nil(t, run)
+}
+
+func TestStartRun_UploadNeedsReview(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewReconciliationService(repo, log)
+
+	reconciledAt := time.Now()
+
+	// Mock expectations
+	repo.EXPECT().
+		GetUpload(mock.Anything, "upload-1").
+		Return(&domain.Upload{ID: "upload-1", Status: domain.UploadStatusNeedsReview, ReconciledAt: &reconciledAt}, nil).
+		Once()
+
+	// Execute
+	run, err := svc.StartRun(context.Background(), "upload-1", strings.NewReader("1674507883,JOHN DOE,DEBIT,250000,restaurant"), domain.ReconciliationOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUploadNeedsReview)
+	assert.Nil(t, run)
+}
+
+func TestStartRun_InvalidLedger(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewReconciliationService(repo, log)
+
+	reconciledAt := time.Now()
+
+	// Mock expectations
+	repo.EXPECT().
+		GetUpload(mock.Anything, "upload-1").
+		Return(&domain.Upload{ID: "upload-1", ReconciledAt: &reconciledAt}, nil).
+		Once()
+
+	// Execute
+	run, err := svc.StartRun(context.Background(), "upload-1", strings.NewReader("not,a,ledger"), domain.ReconciliationOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidLedger)
+	assert.Nil(t, run)
+}
+
+func TestGetResults_InvalidClassification(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewReconciliationService(repo, log)
+
+	classification := domain.MatchClassification("partial")
+
+	// Execute
+	results, total, err := svc.GetResults(context.Background(), domain.ReconciliationResultQuery{
+		RunID:          "run-1",
+		Classification: &classification,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	assert.Nil(t, results)
+	assert.Equal(t, 0, total)
+}
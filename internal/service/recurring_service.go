@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type RecurringService interface {
+	DetectRecurring(ctx context.Context, query domain.RecurringQuery) ([]domain.RecurringSeries, error)
+}
+
+type recurringService struct {
+	repo   domain.Repository
+	rules  domain.RecurringRules
+	logger *logger.Logger
+}
+
+func NewRecurringService(repo domain.Repository, log *logger.Logger) RecurringService {
+	return &recurringService{
+		repo:   repo,
+		rules:  domain.DefaultRecurringRules(),
+		logger: log,
+	}
+}
+
+func (s *recurringService) DetectRecurring(ctx context.Context, query domain.RecurringQuery) ([]domain.RecurringSeries, error) {
+	if query.UploadID != "" {
+		ctx = logger.WithUploadID(ctx, query.UploadID)
+	}
+
+	s.logger.Debug(ctx, "Detecting recurring series",
+		"account_id", query.AccountID,
+		"as_of", query.AsOf,
+	)
+
+	series, err := s.repo.DetectRecurring(ctx, query, s.rules)
+	if err != nil {
+		if err != domain.ErrUploadNotFound && err != domain.ErrAccountNotFound {
+			s.logger.Error(ctx, "Failed to detect recurring series",
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+
+	return series, nil
+}
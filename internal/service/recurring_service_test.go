@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectRecurring_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewRecurringService(repo, log)
+
+	query := domain.RecurringQuery{UploadID: "upload-1"}
+	expected := []domain.RecurringSeries{
+		{Counterparty: "ACME", Type: domain.TransactionTypeCredit, Interval: domain.RecurrenceMonthly, OccurrenceCount: 3},
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		DetectRecurring(mock.Anything, query, domain.DefaultRecurringRules()).
+		Return(expected, nil).
+		Once()
+
+	// Execute
+	series, err := svc.DetectRecurring(context.Background(), query)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, series)
+}
+
+func TestDetectRecurring_AccountNotFound(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewRecurringService(repo, log)
+
+	query := domain.RecurringQuery{AccountID: "missing"}
+
+	// Mock expectations
+	repo.EXPECT().
+		DetectRecurring(mock.Anything, query, mock.Anything).
+		Return(nil, domain.ErrAccountNotFound).
+		Once()
+
+	// Execute
+	series, err := svc.DetectRecurring(context.Background(), query)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	assert.Nil(t, series)
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type SettlementService interface {
+	UpdateStatus(ctx context.Context, uploadID string, update domain.StatusUpdate) (*domain.IssueTransaction, error)
+	UpdateStatusByReference(ctx context.Context, update domain.StatusUpdate) (*domain.ConsolidatedIssue, error)
+	ApplyStatusFile(ctx context.Context, uploadID string, reader io.Reader, matchBy domain.StatusUpdateMatch) (*domain.StatusUpdateReport, error)
+	GetStatusHistory(ctx context.Context, uploadID string, lineNumber int) ([]domain.StatusChange, error)
+}
+
+type settlementService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewSettlementService(repo domain.Repository, log *logger.Logger) SettlementService {
+	return &settlementService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *settlementService) UpdateStatus(ctx context.Context, uploadID string, update domain.StatusUpdate) (*domain.IssueTransaction, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	update.Normalize()
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+	if update.Source == "" {
+		update.Source = domain.StatusUpdateSourceAPI
+	}
+
+	s.logger.Info(ctx, "Updating transaction status",
+		"line_number", update.LineNumber,
+		"status", update.Status,
+		"source", update.Source,
+	)
+
+	tx, err := s.repo.UpdateTransactionStatus(ctx, uploadID, update)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to update transaction status",
+			"line_number", update.LineNumber,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// UpdateStatusByReference settles a row wherever it was uploaded
+func (s *settlementService) UpdateStatusByReference(ctx context.Context, update domain.StatusUpdate) (*domain.ConsolidatedIssue, error) {
+	update.LineNumber = 0
+	update.Normalize()
+	if update.Reference == "" {
+		return nil, domain.ErrInvalidStatusUpdate
+	}
+
+	existing, err := s.repo.FindTransactionByReference(ctx, update.Reference)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to find transaction by reference",
+			"reference", update.Reference,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	tx, err := s.UpdateStatus(ctx, existing.UploadID, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ConsolidatedIssue{
+		UploadID:         existing.UploadID,
+		IssueTransaction: *tx,
+	}, nil
+}
+
+func (s *settlementService) ApplyStatusFile(ctx context.Context, uploadID string, reader io.Reader, matchBy domain.StatusUpdateMatch) (*domain.StatusUpdateReport, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	if !matchBy.IsValid() {
+		return nil, domain.ErrInvalidStatusUpdate
+	}
+
+	_, err := s.repo.GetUpload(ctx, uploadID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get upload",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Applying status update file",
+		"match_by", matchBy,
+	)
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	report := &domain.StatusUpdateReport{
+		UploadID: uploadID,
+		Outcomes: []domain.StatusUpdateOutcome{},
+	}
+	fileLine := 0
+
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if _, ok := err.(*csv.ParseError); !ok {
+				return nil, err
+			}
+		}
+
+		fileLine++
+		outcome := domain.StatusUpdateOutcome{FileLine: fileLine}
+
+		// Rows are applied one by one, a bad row does not stop the rest
+		var update domain.StatusUpdate
+		if err == nil {
+			update, err = parseStatusUpdate(record, matchBy)
+		}
+		if err == nil {
+			outcome.LineNumber = update.LineNumber
+			outcome.Reference = update.Reference
+			outcome.Status = update.Status
+
+			_, err = s.UpdateStatus(ctx, uploadID, update)
+			if err != nil && !isStatusUpdateRejection(err) {
+				return nil, err
+			}
+		}
+
+		if err != nil {
+			outcome.Error = err.Error()
+			report.Rejected++
+		} else {
+			outcome.Applied = true
+			report.Applied++
+		}
+
+		report.Outcomes = append(report.Outcomes, outcome)
+	}
+
+	s.logger.Info(ctx, "Status update file applied",
+		"applied", report.Applied,
+		"rejected", report.Rejected,
+	)
+
+	return report, nil
+}
+
+func (s *settlementService) GetStatusHistory(ctx context.Context, uploadID string, lineNumber int) ([]domain.StatusChange, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	s.logger.Debug(ctx, "Getting status history",
+		"line_number", lineNumber,
+	)
+
+	history, err := s.repo.GetStatusHistory(ctx, uploadID, lineNumber)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get status history",
+			"line_number", lineNumber,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// parseStatusUpdate reads line_number,status[,reason] or, when matching by
+// reference, reference,status[,reason]
+func parseStatusUpdate(record []string, matchBy domain.StatusUpdateMatch) (domain.StatusUpdate, error) {
+	if len(record) != 2 && len(record) != 3 {
+		return domain.StatusUpdate{}, fmt.Errorf("invalid status update format: expected 2 or 3 fields, got %d", len(record))
+	}
+
+	update := domain.StatusUpdate{
+		Status: domain.TransactionStatus(record[1]),
+		Source: domain.StatusUpdateSourceFile,
+	}
+
+	if matchBy == domain.StatusUpdateMatchReference {
+		update.Reference = record[0]
+	} else {
+		lineNumber, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			return domain.StatusUpdate{}, fmt.Errorf("invalid line number: %w", err)
+		}
+		update.LineNumber = lineNumber
+	}
+	if len(record) == 3 {
+		update.Reason = record[2]
+	}
+	update.Normalize()
+
+	return update, nil
+}
+
+// isStatusUpdateRejection separates problems with a single file row from
+// storage failures that should abort the whole file
+func isStatusUpdateRejection(err error) bool {
+	switch err {
+	case domain.ErrInvalidStatusUpdate, domain.ErrInvalidStatusTransition, domain.ErrTransactionNotFound:
+		return true
+	}
+	return false
+}
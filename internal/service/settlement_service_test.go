@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateStatus_InvalidTarget(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewSettlementService(repo, log)
+
+	// Execute
+	tx, err := svc.UpdateStatus(context.Background(), "upload-1", domain.StatusUpdate{
+		LineNumber: 2,
+		Status:     domain.TransactionStatusPending,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusUpdate)
+	assert.Nil(t, tx)
+}
+
+func TestApplyStatusFile_MixedRows(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewSettlementService(repo, log)
+
+	file := "2,success,cleared\n3,FAILED\nabc,SUCCESS\n4,PENDING\n5,SUCCESS\n"
+
+	// Mock expectations
+	repo.EXPECT().
+		GetUpload(mock.Anything, "upload-1").
+		Return(&domain.Upload{ID: "upload-1"}, nil).
+		Once()
+
+	repo.EXPECT().
+		UpdateTransactionStatus(mock.Anything, "upload-1", domain.StatusUpdate{
+			LineNumber: 2,
+			Status:     domain.TransactionStatusSuccess,
+			Reason:     "cleared",
+			Source:     domain.StatusUpdateSourceFile,
+		}).
+		Return(&domain.IssueTransaction{}, nil).
+		Once()
+
+	repo.EXPECT().
+		UpdateTransactionStatus(mock.Anything, "upload-1", mock.MatchedBy(func(update domain.StatusUpdate) bool {
+			return update.LineNumber == 3
+		})).
+		Return(&domain.IssueTransaction{}, nil).
+		Once()
+
+	repo.EXPECT().
+		UpdateTransactionStatus(mock.Anything, "upload-1", mock.MatchedBy(func(update domain.StatusUpdate) bool {
+			return update.LineNumber == 5
+		})).
+		Return(nil, domain.ErrInvalidStatusTransition).
+		Once()
+
+	// Execute
+	report, err := svc.ApplyStatusFile(context.Background(), "upload-1", strings.NewReader(file), domain.StatusUpdateMatchLineNumber)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Applied)
+	assert.Equal(t, 3, report.Rejected)
+	require.Len(t, report.Outcomes, 5)
+	assert.True(t, report.Outcomes[0].Applied)
+	assert.False(t, report.Outcomes[2].Applied)
+	assert.Equal(t, domain.ErrInvalidStatusUpdate.Error(), report.Outcomes[3].Error)
+	assert.Equal(t, domain.ErrInvalidStatusTransition.Error(), report.Outcomes[4].Error)
+}
+
+func TestApplyStatusFile_UploadNotFound(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewSettlementService(repo, log)
+
+	// Mock expectations
+	repo.EXPECT().
+		GetUpload(mock.Anything, "missing").
+		Return(nil, domain.ErrUploadNotFound).
+		Once()
+
+	// Execute
+	report, err := svc.ApplyStatusFile(context.Background(), "missing", strings.NewReader("2,SUCCESS\n"), domain.StatusUpdateMatchLineNumber)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+	assert.Nil(t, report)
+}
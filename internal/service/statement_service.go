@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/grachmannico95/flip-test-be/internal/domain"
@@ -10,12 +11,25 @@ import (
 )
 
 type StatementService interface {
-	UploadStatement(ctx context.Context, reader io.Reader) (string, error)
+	UploadStatement(ctx context.Context, reader io.Reader, opts domain.UploadOptions) (string, error)
 	GetBalance(ctx context.Context, uploadID string) (int64, error)
+	GetBalanceAsOf(ctx context.Context, uploadID string, asOf int64) (int64, error)
+	GetBalanceBreakdown(ctx context.Context, uploadID string) (*domain.BalanceBreakdown, error)
+	GetBalanceSeries(ctx context.Context, uploadID string, interval domain.SeriesInterval) ([]domain.BalancePoint, error)
 	GetIssues(ctx context.Context, uploadID string, page, perPage int, status *domain.TransactionStatus) ([]domain.IssueTransaction, int, error)
+	QueryTransactions(ctx context.Context, query domain.TransactionQuery) ([]domain.IssueTransaction, int, error)
+	SeekTransactions(ctx context.Context, query domain.TransactionQuery, cursor *domain.Cursor) (*domain.CursorPage, error)
+	GetPeriodSummary(ctx context.Context, uploadID string, period domain.SummaryPeriod, loc *time.Location) ([]domain.PeriodSummary, error)
+	GetCounterparties(ctx context.Context, query domain.CounterpartyQuery) ([]domain.CounterpartySummary, int, error)
+	GetTransactionByReference(ctx context.Context, reference string) (*domain.ConsolidatedIssue, error)
+	ExportTransactions(ctx context.Context, query domain.TransactionQuery, fn func(domain.IssueTransaction) error) error
+	ExportRejectedRows(ctx context.Context, uploadID string, fn func(domain.RejectedRow) error) error
 	GetUploadStatus(ctx context.Context, uploadID string) (*domain.Upload, error)
 }
 
+// exportBatchSize bounds how many rows are held in memory while streaming an export
+const exportBatchSize = 500
+
 type statementService struct {
 	repo         domain.Repository
 	csvProcessor CSVProcessorInterface
@@ -30,11 +44,29 @@ func NewStatementService(repo domain.Repository, csvProcessor CSVProcessorInterf
 	}
 }
 
-func (s *statementService) UploadStatement(ctx context.Context, reader io.Reader) (string, error) {
+func (s *statementService) UploadStatement(ctx context.Context, reader io.Reader, opts domain.UploadOptions) (string, error) {
 	uploadID := uuid.New().String()
 
 	ctx = logger.WithUploadID(ctx, uploadID)
 
+	if opts.Period != nil {
+		if err := opts.Period.Validate(); err != nil {
+			return "", err
+		}
+	}
+
+	// Reject unknown accounts before an upload record exists
+	if opts.AccountID != "" {
+		_, err := s.repo.GetAccount(ctx, opts.AccountID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get account",
+				"account_id", opts.AccountID,
+				"error", err,
+			)
+			return "", err
+		}
+	}
+
 	s.logger.Info(ctx, "Creating upload record")
 
 	err := s.repo.CreateUpload(ctx, uploadID)
@@ -45,6 +77,54 @@ func (s *statementService) UploadStatement(ctx context.Context, reader io.Reader
 		return "", err
 	}
 
+	if opts.AccountID != "" {
+		err = s.repo.AttachUpload(ctx, uploadID, opts.AccountID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to attach upload to account",
+				"account_id", opts.AccountID,
+				"error", err,
+			)
+			return "", err
+		}
+	}
+
+	if opts.Period != nil {
+		err = s.repo.SetUploadPeriod(ctx, uploadID, *opts.Period)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to store declared period",
+				"error", err,
+			)
+			return "", err
+		}
+	}
+
+	if opts.OpeningBalance != nil || opts.ExpectedClosingBalance != nil {
+		check := domain.BalanceCheck{
+			ExpectedClosingBalance: opts.ExpectedClosingBalance,
+		}
+		if opts.OpeningBalance != nil {
+			check.OpeningBalance = *opts.OpeningBalance
+		}
+
+		err = s.repo.SetUploadBalanceCheck(ctx, uploadID, check)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to store declared balances",
+				"error", err,
+			)
+			return "", err
+		}
+	}
+
+	if opts.HoldForReview {
+		err = s.repo.SetUploadHoldForReview(ctx, uploadID, true)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to hold upload for review",
+				"error", err,
+			)
+			return "", err
+		}
+	}
+
 	go func() {
 		processCtx := context.Background()
 		processCtx = logger.WithUploadID(processCtx, uploadID)
@@ -86,6 +166,133 @@ func (s *statementService) GetBalance(ctx context.Context, uploadID string) (int
 	return balance, nil
 }
 
+func (s *statementService) GetBalanceAsOf(ctx context.Context, uploadID string, asOf int64) (int64, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	s.logger.Debug(ctx, "Getting balance as of timestamp",
+		"as_of", asOf,
+	)
+
+	balance, err := s.repo.GetBalanceAsOf(ctx, uploadID, asOf)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get balance as of timestamp",
+			"as_of", asOf,
+			"error", err,
+		)
+		return 0, err
+	}
+
+	s.logger.Debug(ctx, "Balance retrieved",
+		"as_of", asOf,
+		"balance", balance,
+	)
+
+	return balance, nil
+}
+
+func (s *statementService) GetBalanceSeries(ctx context.Context, uploadID string, interval domain.SeriesInterval) ([]domain.BalancePoint, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	if !interval.IsValid() {
+		return nil, domain.ErrInvalidInterval
+	}
+
+	s.logger.Debug(ctx, "Getting balance series",
+		"interval", interval,
+	)
+
+	series, err := s.repo.GetBalanceSeries(ctx, uploadID, interval)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get balance series",
+			"interval", interval,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	s.logger.Debug(ctx, "Balance series retrieved",
+		"buckets", len(series),
+	)
+
+	return series, nil
+}
+
+func (s *statementService) GetBalanceBreakdown(ctx context.Context, uploadID string) (*domain.BalanceBreakdown, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	s.logger.Debug(ctx, "Getting balance breakdown")
+
+	balance, err := s.repo.GetBalance(ctx, uploadID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get balance",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	settled, err := s.repo.SumByStatus(ctx, uploadID, domain.TransactionStatusSuccess)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to sum settled transactions",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	pending, err := s.repo.SumByStatus(ctx, uploadID, domain.TransactionStatusPending)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to sum pending transactions",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	failed, err := s.repo.SumByStatus(ctx, uploadID, domain.TransactionStatusFailed)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to sum failed transactions",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	refunded, err := s.repo.SumByStatus(ctx, uploadID, domain.TransactionStatusRefunded)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to sum refunded transactions",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	counts, err := s.repo.CountByStatus(ctx, uploadID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to count transactions by status",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	// Projected balance assumes every PENDING row eventually settles
+	breakdown := &domain.BalanceBreakdown{
+		Balance:          balance,
+		TotalCredits:     settled.Credit,
+		TotalDebits:      settled.Debit,
+		StatusCounts:     counts,
+		PendingInbound:   pending.Credit,
+		PendingOutbound:  pending.Debit,
+		FailedCredits:    failed.Credit,
+		FailedDebits:     failed.Debit,
+		RefundedCredits:  refunded.Credit,
+		RefundedDebits:   refunded.Debit,
+		ProjectedBalance: balance + pending.Credit - pending.Debit,
+	}
+
+	s.logger.Debug(ctx, "Balance breakdown retrieved",
+		"balance", breakdown.Balance,
+		"projected_balance", breakdown.ProjectedBalance,
+	)
+
+	return breakdown, nil
+}
+
 func (s *statementService) GetIssues(ctx context.Context, uploadID string, page, perPage int, status *domain.TransactionStatus) ([]domain.IssueTransaction, int, error) {
 	ctx = logger.WithUploadID(ctx, uploadID)
 
@@ -111,6 +318,263 @@ func (s *statementService) GetIssues(ctx context.Context, uploadID string, page,
 	return issues, total, nil
 }
 
+func (s *statementService) GetTransactionByReference(ctx context.Context, reference string) (*domain.ConsolidatedIssue, error) {
+	s.logger.Debug(ctx, "Getting transaction by reference",
+		"reference", reference,
+	)
+
+	tx, err := s.repo.FindTransactionByReference(ctx, reference)
+	if err != nil {
+		if err != domain.ErrTransactionNotFound {
+			s.logger.Error(ctx, "Failed to find transaction by reference",
+				"reference", reference,
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func (s *statementService) QueryTransactions(ctx context.Context, query domain.TransactionQuery) ([]domain.IssueTransaction, int, error) {
+	ctx = logger.WithUploadID(ctx, query.UploadID)
+
+	err := query.Validate()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.logger.Debug(ctx, "Querying transactions",
+		"page", query.Page,
+		"per_page", query.PerPage,
+		"sort_by", query.SortBy,
+		"sort_order", query.SortOrder,
+	)
+
+	transactions, total, err := s.repo.QueryTransactions(ctx, query)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to query transactions",
+			"error", err,
+		)
+		return nil, 0, err
+	}
+
+	s.logger.Debug(ctx, "Transactions retrieved",
+		"total", total,
+		"returned", len(transactions),
+	)
+
+	return transactions, total, nil
+}
+
+func (s *statementService) SeekTransactions(ctx context.Context, query domain.TransactionQuery, cursor *domain.Cursor) (*domain.CursorPage, error) {
+	ctx = logger.WithUploadID(ctx, query.UploadID)
+
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	sortBy, err := query.CursorSortField()
+	if err != nil {
+		return nil, err
+	}
+
+	// A token is only valid for the ordering it was issued for
+	if cursor != nil && cursor.SortBy != sortBy {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	s.logger.Debug(ctx, "Seeking transactions",
+		"per_page", query.PerPage,
+		"sort_by", sortBy,
+		"cursor", cursor,
+	)
+
+	items, hasMore, err := s.repo.SeekTransactions(ctx, query, cursor)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to seek transactions",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	page := &domain.CursorPage{Items: items}
+	if len(items) == 0 {
+		return page, nil
+	}
+
+	first := items[0]
+	last := items[len(items)-1]
+	backward := cursor != nil && cursor.Direction == domain.CursorDirectionPrev
+
+	if (!backward && hasMore) || backward {
+		page.NextCursor = domain.NewCursor(domain.CursorDirectionNext, sortBy, last).Encode()
+	}
+	if (backward && hasMore) || (!backward && cursor != nil) {
+		page.PrevCursor = domain.NewCursor(domain.CursorDirectionPrev, sortBy, first).Encode()
+	}
+
+	s.logger.Debug(ctx, "Transactions retrieved",
+		"returned", len(items),
+		"has_more", hasMore,
+	)
+
+	return page, nil
+}
+
+func (s *statementService) GetPeriodSummary(ctx context.Context, uploadID string, period domain.SummaryPeriod, loc *time.Location) ([]domain.PeriodSummary, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	if !period.IsValid() {
+		return nil, domain.ErrInvalidPeriod
+	}
+
+	s.logger.Debug(ctx, "Getting period summary",
+		"period", period,
+		"timezone", loc.String(),
+	)
+
+	summaries, err := s.repo.SummarizeByPeriod(ctx, uploadID, period, loc)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to summarize by period",
+			"period", period,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	s.logger.Debug(ctx, "Period summary retrieved",
+		"buckets", len(summaries),
+	)
+
+	return summaries, nil
+}
+
+func (s *statementService) GetCounterparties(ctx context.Context, query domain.CounterpartyQuery) ([]domain.CounterpartySummary, int, error) {
+	ctx = logger.WithUploadID(ctx, query.UploadID)
+
+	err := query.Validate()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.logger.Debug(ctx, "Getting counterparty summaries",
+		"page", query.Page,
+		"per_page", query.PerPage,
+		"sort_by", query.SortBy,
+	)
+
+	summaries, total, err := s.repo.AggregateByCounterparty(ctx, query)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to aggregate by counterparty",
+			"error", err,
+		)
+		return nil, 0, err
+	}
+
+	s.logger.Debug(ctx, "Counterparty summaries retrieved",
+		"total", total,
+		"returned", len(summaries),
+	)
+
+	return summaries, total, nil
+}
+
+func (s *statementService) ExportTransactions(ctx context.Context, query domain.TransactionQuery, fn func(domain.IssueTransaction) error) error {
+	ctx = logger.WithUploadID(ctx, query.UploadID)
+
+	err := query.Validate()
+	if err != nil {
+		return err
+	}
+
+	sortBy, err := query.CursorSortField()
+	if err != nil {
+		return err
+	}
+
+	s.logger.Debug(ctx, "Exporting transactions",
+		"sort_by", sortBy,
+	)
+
+	// Walk the upload in cursor-sized batches so only one batch is in memory
+	query.PerPage = exportBatchSize
+	var cursor *domain.Cursor
+	exported := 0
+	for {
+		items, hasMore, err := s.repo.SeekTransactions(ctx, query, cursor)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to seek transactions for export",
+				"exported", exported,
+				"error", err,
+			)
+			return err
+		}
+
+		for _, item := range items {
+			err = fn(item)
+			if err != nil {
+				return err
+			}
+		}
+		exported += len(items)
+
+		if !hasMore || len(items) == 0 {
+			break
+		}
+
+		next := domain.NewCursor(domain.CursorDirectionNext, sortBy, items[len(items)-1])
+		cursor = &next
+	}
+
+	s.logger.Debug(ctx, "Transactions exported",
+		"exported", exported,
+	)
+
+	return nil
+}
+
+func (s *statementService) ExportRejectedRows(ctx context.Context, uploadID string, fn func(domain.RejectedRow) error) error {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	s.logger.Debug(ctx, "Exporting rejected rows")
+
+	afterLine := 0
+	exported := 0
+	for {
+		rows, hasMore, err := s.repo.GetRejectedRows(ctx, uploadID, afterLine, exportBatchSize)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get rejected rows for export",
+				"exported", exported,
+				"error", err,
+			)
+			return err
+		}
+
+		for _, row := range rows {
+			err = fn(row)
+			if err != nil {
+				return err
+			}
+		}
+		exported += len(rows)
+
+		if !hasMore || len(rows) == 0 {
+			break
+		}
+
+		afterLine = rows[len(rows)-1].LineNumber
+	}
+
+	s.logger.Debug(ctx, "Rejected rows exported",
+		"exported", exported,
+	)
+
+	return nil
+}
+
 func (s *statementService) GetUploadStatus(ctx context.Context, uploadID string) (*domain.Upload, error) {
 	ctx = logger.WithUploadID(ctx, uploadID)
 
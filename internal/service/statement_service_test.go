@@ -48,7 +48,7 @@ func TestUploadStatement_Success(t *testing.T) {
 		Maybe()
 
 	// Execute
-	uploadID, err := svc.UploadStatement(ctx, reader)
+	uploadID, err := svc.UploadStatement(ctx, reader, domain.UploadOptions{})
 
 	// Assert
 	require.NoError(t, err)
@@ -58,6 +58,112 @@ func TestUploadStatement_Success(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 }
 
+func TestUploadStatement_WithDeclaredBalances(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	reader := bytes.NewReader([]byte("test csv content"))
+	opening := int64(1000000)
+	expectedClosing := int64(3150000)
+
+	// Mock expectations
+	repo.EXPECT().
+		CreateUpload(mock.Anything, mock.AnythingOfType("string")).
+		Return(nil).
+		Once()
+
+	repo.EXPECT().
+		SetUploadBalanceCheck(mock.Anything, mock.AnythingOfType("string"), domain.BalanceCheck{
+			OpeningBalance:         opening,
+			ExpectedClosingBalance: &expectedClosing,
+		}).
+		Return(nil).
+		Once()
+
+	csvProcessor.EXPECT().
+		ProcessStream(mock.Anything, mock.AnythingOfType("string"), mock.Anything).
+		Return(nil).
+		Maybe()
+
+	// Execute
+	uploadID, err := svc.UploadStatement(ctx, reader, domain.UploadOptions{
+		OpeningBalance:         &opening,
+		ExpectedClosingBalance: &expectedClosing,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEmpty(t, uploadID)
+
+	time.Sleep(10 * time.Millisecond)
+}
+
+func TestUploadStatement_WithAccount(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	reader := bytes.NewReader([]byte("test csv content"))
+
+	// Mock expectations
+	repo.EXPECT().
+		GetAccount(mock.Anything, "account-1").
+		Return(&domain.Account{ID: "account-1"}, nil).
+		Once()
+
+	repo.EXPECT().
+		CreateUpload(mock.Anything, mock.AnythingOfType("string")).
+		Return(nil).
+		Once()
+
+	repo.EXPECT().
+		AttachUpload(mock.Anything, mock.AnythingOfType("string"), "account-1").
+		Return(nil).
+		Once()
+
+	csvProcessor.EXPECT().
+		ProcessStream(mock.Anything, mock.AnythingOfType("string"), mock.Anything).
+		Return(nil).
+		Maybe()
+
+	// Execute
+	uploadID, err := svc.UploadStatement(ctx, reader, domain.UploadOptions{AccountID: "account-1"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEmpty(t, uploadID)
+
+	time.Sleep(10 * time.Millisecond)
+}
+
+func TestUploadStatement_UnknownAccount(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	// Mock expectations
+	repo.EXPECT().
+		GetAccount(mock.Anything, "nonexistent").
+		Return(nil, domain.ErrAccountNotFound).
+		Once()
+
+	// Execute
+	uploadID, err := svc.UploadStatement(context.Background(), bytes.NewReader(nil), domain.UploadOptions{AccountID: "nonexistent"})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	assert.Empty(t, uploadID)
+}
+
 func TestUploadStatement_CreateUploadError(t *testing.T) {
 	// Setup
 	repo := mocks.NewMockRepository(t)
@@ -76,7 +182,7 @@ func TestUploadStatement_CreateUploadError(t *testing.T) {
 		Once()
 
 	// Execute
-	uploadID, err := svc.UploadStatement(ctx, reader)
+	uploadID, err := svc.UploadStatement(ctx, reader, domain.UploadOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -135,6 +241,157 @@ func TestGetBalance_Error(t *testing.T) {
 	assert.Equal(t, int64(0), balance)
 }
 
+func TestGetBalanceAsOf_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	uploadID := "test-upload-123"
+	asOf := int64(1674507885)
+
+	// Mock expectations
+	repo.EXPECT().
+		GetBalanceAsOf(mock.Anything, uploadID, asOf).
+		Return(int64(250000), nil).
+		Once()
+
+	// Execute
+	balance, err := svc.GetBalanceAsOf(ctx, uploadID, asOf)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(250000), balance)
+}
+
+func TestGetBalanceSeries_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	uploadID := "test-upload-123"
+	expectedSeries := []domain.BalancePoint{
+		{BucketStart: 1674432000, Credits: 500000, Debits: 250000, Balance: 250000},
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		GetBalanceSeries(mock.Anything, uploadID, domain.SeriesIntervalDay).
+		Return(expectedSeries, nil).
+		Once()
+
+	// Execute
+	series, err := svc.GetBalanceSeries(ctx, uploadID, domain.SeriesIntervalDay)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expectedSeries, series)
+}
+
+func TestGetBalanceSeries_InvalidInterval(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	// Execute
+	series, err := svc.GetBalanceSeries(context.Background(), "test-upload-123", domain.SeriesInterval("week"))
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidInterval)
+	assert.Nil(t, series)
+}
+
+func TestGetBalanceBreakdown_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	uploadID := "test-upload-123"
+	counts := map[domain.TransactionStatus]int{
+		domain.TransactionStatusSuccess:  2,
+		domain.TransactionStatusFailed:   1,
+		domain.TransactionStatusPending:  2,
+		domain.TransactionStatusRefunded: 1,
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		GetBalance(mock.Anything, uploadID).
+		Return(int64(250000), nil).
+		Once()
+	repo.EXPECT().
+		SumByStatus(mock.Anything, uploadID, domain.TransactionStatusSuccess).
+		Return(domain.AmountTotals{Credit: 500000, Debit: 250000}, nil).
+		Once()
+	repo.EXPECT().
+		SumByStatus(mock.Anything, uploadID, domain.TransactionStatusPending).
+		Return(domain.AmountTotals{Credit: 300000, Debit: 80000}, nil).
+		Once()
+	repo.EXPECT().
+		SumByStatus(mock.Anything, uploadID, domain.TransactionStatusFailed).
+		Return(domain.AmountTotals{Debit: 100000}, nil).
+		Once()
+	repo.EXPECT().
+		SumByStatus(mock.Anything, uploadID, domain.TransactionStatusRefunded).
+		Return(domain.AmountTotals{Debit: 40000}, nil).
+		Once()
+	repo.EXPECT().
+		CountByStatus(mock.Anything, uploadID).
+		Return(counts, nil).
+		Once()
+
+	// Execute
+	breakdown, err := svc.GetBalanceBreakdown(ctx, uploadID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(250000), breakdown.Balance)
+	assert.Equal(t, int64(500000), breakdown.TotalCredits)
+	assert.Equal(t, int64(250000), breakdown.TotalDebits)
+	assert.Equal(t, int64(300000), breakdown.PendingInbound)
+	assert.Equal(t, int64(80000), breakdown.PendingOutbound)
+	assert.Equal(t, int64(0), breakdown.FailedCredits)
+	assert.Equal(t, int64(100000), breakdown.FailedDebits)
+	assert.Equal(t, int64(0), breakdown.RefundedCredits)
+	assert.Equal(t, int64(40000), breakdown.RefundedDebits)
+	assert.Equal(t, int64(470000), breakdown.ProjectedBalance)
+	assert.Equal(t, counts, breakdown.StatusCounts)
+}
+
+func TestGetBalanceBreakdown_Error(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	uploadID := "test-upload-123"
+
+	// Mock expectations
+	repo.EXPECT().
+		GetBalance(mock.Anything, uploadID).
+		Return(int64(0), domain.ErrUploadNotFound).
+		Once()
+
+	// Execute
+	breakdown, err := svc.GetBalanceBreakdown(ctx, uploadID)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+	assert.Nil(t, breakdown)
+}
+
 func TestGetIssues_Success(t *testing.T) {
 	// Setup
 	repo := mocks.NewMockRepository(t)
@@ -309,6 +566,332 @@ func TestGetIssues_Pagination(t *testing.T) {
 	assert.Equal(t, expectedTotal, total)
 }
 
+func TestQueryTransactions_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	query := domain.TransactionQuery{
+		UploadID: "test-upload-123",
+		Statuses: []domain.TransactionStatus{domain.TransactionStatusSuccess},
+		SortBy:   domain.SortByAmount,
+		Page:     1,
+		PerPage:  10,
+	}
+	expected := []domain.IssueTransaction{
+		{
+			Transaction: domain.Transaction{
+				Timestamp:    1674507884,
+				Counterparty: "JANE DOE",
+				Type:         domain.TransactionTypeCredit,
+				Amount:       500000,
+				Status:       domain.TransactionStatusSuccess,
+				Description:  "salary",
+			},
+			LineNumber: 2,
+		},
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		QueryTransactions(mock.Anything, query).
+		Return(expected, 1, nil).
+		Once()
+
+	// Execute
+	transactions, total, err := svc.QueryTransactions(ctx, query)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, transactions)
+	assert.Equal(t, 1, total)
+}
+
+func TestQueryTransactions_InvalidQuery(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	minAmount := int64(500)
+	maxAmount := int64(100)
+
+	// Execute
+	transactions, total, err := svc.QueryTransactions(context.Background(), domain.TransactionQuery{
+		UploadID:  "test-upload-123",
+		MinAmount: &minAmount,
+		MaxAmount: &maxAmount,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	assert.Nil(t, transactions)
+	assert.Equal(t, 0, total)
+}
+
+func TestSeekTransactions_Cursors(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	query := domain.TransactionQuery{
+		UploadID: "test-upload-123",
+		PerPage:  2,
+	}
+	items := []domain.IssueTransaction{
+		{Transaction: domain.Transaction{Timestamp: 1674507883}, LineNumber: 1},
+		{Transaction: domain.Transaction{Timestamp: 1674507884}, LineNumber: 2},
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		SeekTransactions(mock.Anything, query, (*domain.Cursor)(nil)).
+		Return(items, true, nil).
+		Once()
+
+	// Execute
+	page, err := svc.SeekTransactions(ctx, query, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, items, page.Items)
+	assert.Empty(t, page.PrevCursor)
+
+	next, err := domain.DecodeCursor(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewCursor(domain.CursorDirectionNext, domain.SortByTimestamp, items[1]), next)
+}
+
+func TestSeekTransactions_CursorSortMismatch(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	cursor := domain.Cursor{
+		Direction:  domain.CursorDirectionNext,
+		SortBy:     domain.SortByTimestamp,
+		LineNumber: 2,
+	}
+
+	// Execute
+	page, err := svc.SeekTransactions(context.Background(), domain.TransactionQuery{
+		UploadID: "test-upload-123",
+		SortBy:   domain.SortByLineNumber,
+	}, &cursor)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	assert.Nil(t, page)
+}
+
+func TestGetTransactionByReference_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	expected := &domain.ConsolidatedIssue{
+		UploadID: "upload-1",
+		IssueTransaction: domain.IssueTransaction{
+			Transaction: domain.Transaction{Reference: "TRX-1", Amount: 100},
+			LineNumber:  3,
+		},
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		FindTransactionByReference(mock.Anything, "TRX-1").
+		Return(expected, nil).
+		Once()
+
+	// Execute
+	tx, err := svc.GetTransactionByReference(ctx, "TRX-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, tx)
+}
+
+func TestGetPeriodSummary_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	uploadID := "test-upload-123"
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := []domain.PeriodSummary{
+		{PeriodStart: start, PeriodEnd: start.AddDate(0, 1, 0), Credits: 500000, Debits: 250000, Net: 250000, ClosingBalance: 250000},
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		SummarizeByPeriod(mock.Anything, uploadID, domain.SummaryPeriodMonth, time.UTC).
+		Return(expected, nil).
+		Once()
+
+	// Execute
+	summaries, err := svc.GetPeriodSummary(ctx, uploadID, domain.SummaryPeriodMonth, time.UTC)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, summaries)
+}
+
+func TestGetPeriodSummary_InvalidPeriod(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	// Execute
+	summaries, err := svc.GetPeriodSummary(context.Background(), "test-upload-123", domain.SummaryPeriod("quarter"), time.UTC)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidPeriod)
+	assert.Nil(t, summaries)
+}
+
+func TestGetCounterparties_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	query := domain.CounterpartyQuery{
+		UploadID:  "test-upload-123",
+		SortBy:    domain.CounterpartySortByTotalDebit,
+		SortOrder: domain.SortOrderDesc,
+		Page:      1,
+		PerPage:   10,
+	}
+	expected := []domain.CounterpartySummary{
+		{Counterparty: "JOHN DOE", TotalDebit: 250000, TransactionCount: 1},
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		AggregateByCounterparty(mock.Anything, query).
+		Return(expected, 1, nil).
+		Once()
+
+	// Execute
+	summaries, total, err := svc.GetCounterparties(ctx, query)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, summaries)
+	assert.Equal(t, 1, total)
+}
+
+func TestGetCounterparties_InvalidSort(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	// Execute
+	summaries, _, err := svc.GetCounterparties(context.Background(), domain.CounterpartyQuery{
+		UploadID: "test-upload-123",
+		SortBy:   domain.CounterpartySortField("amount"),
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	assert.Nil(t, summaries)
+}
+
+func TestExportTransactions_Batches(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	query := domain.TransactionQuery{UploadID: "test-upload-123"}
+	batchQuery := query
+	batchQuery.PerPage = exportBatchSize
+
+	first := []domain.IssueTransaction{
+		{Transaction: domain.Transaction{Timestamp: 1674507883}, LineNumber: 1},
+		{Transaction: domain.Transaction{Timestamp: 1674507884}, LineNumber: 2},
+	}
+	second := []domain.IssueTransaction{
+		{Transaction: domain.Transaction{Timestamp: 1674507885}, LineNumber: 3},
+	}
+	cursor := domain.NewCursor(domain.CursorDirectionNext, domain.SortByTimestamp, first[1])
+
+	// Mock expectations
+	repo.EXPECT().
+		SeekTransactions(mock.Anything, batchQuery, (*domain.Cursor)(nil)).
+		Return(first, true, nil).
+		Once()
+	repo.EXPECT().
+		SeekTransactions(mock.Anything, batchQuery, &cursor).
+		Return(second, false, nil).
+		Once()
+
+	// Execute
+	var lines []int
+	err := svc.ExportTransactions(ctx, query, func(tx domain.IssueTransaction) error {
+		lines = append(lines, tx.LineNumber)
+		return nil
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, lines)
+}
+
+func TestExportRejectedRows_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	csvProcessor := mocks.NewMockCSVProcessorInterface(t)
+	log := logger.New("info")
+	svc := NewStatementService(repo, csvProcessor, log)
+
+	ctx := context.Background()
+	uploadID := "test-upload-123"
+	rows := []domain.RejectedRow{
+		{LineNumber: 4, Raw: "1674507886,ALICE,TRANSFER,1,SUCCESS,x", Reason: "invalid transaction type: TRANSFER"},
+	}
+
+	// Mock expectations
+	repo.EXPECT().
+		GetRejectedRows(mock.Anything, uploadID, 0, exportBatchSize).
+		Return(rows, false, nil).
+		Once()
+
+	// Execute
+	var exported []domain.RejectedRow
+	err := svc.ExportRejectedRows(ctx, uploadID, func(row domain.RejectedRow) error {
+		exported = append(exported, row)
+		return nil
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, rows, exported)
+}
+
 func TestGetUploadStatus_Success(t *testing.T) {
 	// Setup
 	repo := mocks.NewMockRepository(t)
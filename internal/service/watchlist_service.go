@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type WatchlistService interface {
+	CreateEntry(ctx context.Context, entry domain.WatchlistEntry) (*domain.WatchlistEntry, error)
+	ListEntries(ctx context.Context) ([]domain.WatchlistEntry, error)
+	DeleteEntry(ctx context.Context, entryID string) error
+	ImportEntries(ctx context.Context, reader io.Reader, source domain.WatchlistSource) (int, int, error)
+	ListScreening(ctx context.Context, uploadID string, state *domain.ScreeningState) ([]domain.ScreeningResult, error)
+	ClearScreening(ctx context.Context, uploadID string, review domain.ScreeningReview) ([]domain.ScreeningResult, *domain.Upload, error)
+}
+
+type watchlistService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewWatchlistService(repo domain.Repository, log *logger.Logger) WatchlistService {
+	return &watchlistService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *watchlistService) CreateEntry(ctx context.Context, entry domain.WatchlistEntry) (*domain.WatchlistEntry, error) {
+	entry.Normalize()
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	entry.ID = uuid.New().String()
+	entry.CreatedAt = time.Now()
+	if entry.Source == "" {
+		entry.Source = domain.WatchlistSourceAPI
+	}
+
+	s.logger.Info(ctx, "Adding watchlist entry",
+		"entry_id", entry.ID,
+		"name", entry.Name,
+	)
+
+	err := s.repo.CreateWatchlistEntry(ctx, entry)
+	if err != nil {
+		if err != domain.ErrDuplicateWatchlistEntry {
+			s.logger.Error(ctx, "Failed to add watchlist entry",
+				"entry_id", entry.ID,
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (s *watchlistService) ListEntries(ctx context.Context) ([]domain.WatchlistEntry, error) {
+	s.logger.Debug(ctx, "Listing watchlist entries")
+
+	entries, err := s.repo.ListWatchlistEntries(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list watchlist entries",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (s *watchlistService) DeleteEntry(ctx context.Context, entryID string) error {
+	s.logger.Info(ctx, "Deleting watchlist entry",
+		"entry_id", entryID,
+	)
+
+	err := s.repo.DeleteWatchlistEntry(ctx, entryID)
+	if err != nil {
+		if err != domain.ErrWatchlistEntryNotFound {
+			s.logger.Error(ctx, "Failed to delete watchlist entry",
+				"entry_id", entryID,
+				"error", err,
+			)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ImportEntries reads one entity per line as name[,reason], an optional
+// name,reason header is skipped. Blank and already listed names are skipped
+// rather than failing the file.
+func (s *watchlistService) ImportEntries(ctx context.Context, reader io.Reader, source domain.WatchlistSource) (int, int, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	imported, skipped := 0, 0
+	first := true
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			s.logger.Error(ctx, "Failed to read watchlist file",
+				"error", err,
+			)
+			return imported, skipped, domain.ErrInvalidWatchlistEntry
+		}
+
+		if first {
+			first = false
+			if strings.EqualFold(strings.TrimSpace(record[0]), "name") {
+				continue
+			}
+		}
+
+		entry := domain.WatchlistEntry{Name: record[0], Source: source}
+		if len(record) > 1 {
+			entry.Reason = record[1]
+		}
+
+		_, err = s.CreateEntry(ctx, entry)
+		if err == domain.ErrInvalidWatchlistEntry || err == domain.ErrDuplicateWatchlistEntry {
+			skipped++
+			continue
+		}
+		if err != nil {
+			return imported, skipped, err
+		}
+		imported++
+	}
+
+	s.logger.Info(ctx, "Watchlist imported",
+		"source", source,
+		"imported", imported,
+		"skipped", skipped,
+	)
+
+	return imported, skipped, nil
+}
+
+func (s *watchlistService) ListScreening(ctx context.Context, uploadID string, state *domain.ScreeningState) ([]domain.ScreeningResult, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	if state != nil && !state.IsValid() {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	s.logger.Debug(ctx, "Listing screening results",
+		"state", state,
+	)
+
+	results, err := s.repo.ListScreeningResults(ctx, uploadID, state)
+	if err != nil {
+		if err != domain.ErrUploadNotFound {
+			s.logger.Error(ctx, "Failed to list screening results",
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (s *watchlistService) ClearScreening(ctx context.Context, uploadID string, review domain.ScreeningReview) ([]domain.ScreeningResult, *domain.Upload, error) {
+	ctx = logger.WithUploadID(ctx, uploadID)
+
+	review.Normalize()
+	if err := review.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	s.logger.Info(ctx, "Clearing watchlist hits",
+		"reviewer", review.Reviewer,
+		"line_numbers", review.LineNumbers,
+	)
+
+	cleared, err := s.repo.ClearScreeningResults(ctx, uploadID, review)
+	if err != nil {
+		if err != domain.ErrUploadNotFound && err != domain.ErrScreeningNotFound {
+			s.logger.Error(ctx, "Failed to clear watchlist hits",
+				"error", err,
+			)
+		}
+		return nil, nil, err
+	}
+
+	upload, err := s.repo.GetUpload(ctx, uploadID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get upload",
+			"error", err,
+		)
+		return nil, nil, err
+	}
+
+	return cleared, upload, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportWatchlistEntries_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewWatchlistService(repo, log)
+
+	file := "name,reason\nacme holdings,sanctions list\n ,blank\nJOHN DOE\n"
+
+	// Mock expectations
+	repo.EXPECT().
+		CreateWatchlistEntry(mock.Anything, mock.MatchedBy(func(entry domain.WatchlistEntry) bool {
+			return entry.Name == "ACME HOLDINGS" && entry.Reason == "sanctions list" && entry.Source == domain.WatchlistSourceFile
+		})).
+		Return(nil).
+		Once()
+	repo.EXPECT().
+		CreateWatchlistEntry(mock.Anything, mock.MatchedBy(func(entry domain.WatchlistEntry) bool {
+			return entry.Name == "JOHN DOE"
+		})).
+		Return(domain.ErrDuplicateWatchlistEntry).
+		Once()
+
+	// Execute
+	imported, skipped, err := svc.ImportEntries(context.Background(), strings.NewReader(file), domain.WatchlistSourceFile)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+	assert.Equal(t, 2, skipped)
+}
+
+func TestClearScreening_MissingReviewer(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewWatchlistService(repo, log)
+
+	// Execute
+	results, upload, err := svc.ClearScreening(context.Background(), "upload-1", domain.ScreeningReview{Note: "false positive"})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidScreeningReview)
+	assert.Nil(t, results)
+	assert.Nil(t, upload)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, webhook domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	GetWebhook(ctx context.Context, webhookID string) (*domain.WebhookSubscription, error)
+	ListWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	ListDeliveries(ctx context.Context, query domain.WebhookDeliveryQuery) ([]domain.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	repo   domain.Repository
+	logger *logger.Logger
+}
+
+func NewWebhookService(repo domain.Repository, log *logger.Logger) WebhookService {
+	return &webhookService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (s *webhookService) CreateWebhook(ctx context.Context, webhook domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	webhook.Normalize()
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	webhook.ID = uuid.New().String()
+	webhook.CreatedAt = time.Now()
+
+	s.logger.Info(ctx, "Creating webhook subscription",
+		"webhook_id", webhook.ID,
+		"url", webhook.URL,
+		"event_types", webhook.EventTypes,
+	)
+
+	err := s.repo.CreateWebhook(ctx, webhook)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to create webhook subscription",
+			"webhook_id", webhook.ID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (s *webhookService) GetWebhook(ctx context.Context, webhookID string) (*domain.WebhookSubscription, error) {
+	s.logger.Debug(ctx, "Getting webhook subscription",
+		"webhook_id", webhookID,
+	)
+
+	webhook, err := s.repo.GetWebhook(ctx, webhookID)
+	if err != nil {
+		if err != domain.ErrWebhookNotFound {
+			s.logger.Error(ctx, "Failed to get webhook subscription",
+				"webhook_id", webhookID,
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	s.logger.Debug(ctx, "Listing webhook subscriptions")
+
+	webhooks, err := s.repo.ListWebhooks(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list webhook subscriptions",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, webhookID string) error {
+	s.logger.Info(ctx, "Deleting webhook subscription",
+		"webhook_id", webhookID,
+	)
+
+	err := s.repo.DeleteWebhook(ctx, webhookID)
+	if err != nil {
+		if err != domain.ErrWebhookNotFound {
+			s.logger.Error(ctx, "Failed to delete webhook subscription",
+				"webhook_id", webhookID,
+				"error", err,
+			)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, query domain.WebhookDeliveryQuery) ([]domain.WebhookDelivery, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug(ctx, "Listing webhook deliveries",
+		"webhook_id", query.SubscriptionID,
+		"event_type", query.EventType,
+		"status", query.Status,
+	)
+
+	deliveries, err := s.repo.ListWebhookDeliveries(ctx, query)
+	if err != nil {
+		if err != domain.ErrWebhookNotFound {
+			s.logger.Error(ctx, "Failed to list webhook deliveries",
+				"webhook_id", query.SubscriptionID,
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/grachmannico95/flip-test-be/mocks"
+	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhook_Success(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewWebhookService(repo, log)
+
+	// Mock expectations
+	repo.EXPECT().
+		CreateWebhook(mock.Anything, mock.AnythingOfType("domain.WebhookSubscription")).
+		Return(nil).
+		Once()
+
+	// Execute
+	webhook, err := svc.CreateWebhook(context.Background(), domain.WebhookSubscription{
+		URL:        " https://example.com/hook ",
+		Secret:     "s3cret",
+		EventTypes: []domain.WebhookEventType{"Upload.Failed", "upload.completed", "upload.failed"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEmpty(t, webhook.ID)
+	assert.Equal(t, "https://example.com/hook", webhook.URL)
+	assert.Equal(t, []domain.WebhookEventType{domain.WebhookEventUploadCompleted, domain.WebhookEventUploadFailed}, webhook.EventTypes)
+}
+
+func TestCreateWebhook_Invalid(t *testing.T) {
+	// Setup
+	repo := mocks.NewMockRepository(t)
+	log := logger.New("info")
+	svc := NewWebhookService(repo, log)
+
+	invalid := []domain.WebhookSubscription{
+		{URL: "ftp://example.com/hook", Secret: "s3cret", EventTypes: []domain.WebhookEventType{domain.WebhookEventUploadCompleted}},
+		{URL: "https://example.com/hook", EventTypes: []domain.WebhookEventType{domain.WebhookEventUploadCompleted}},
+		{URL: "https://example.com/hook", Secret: "s3cret", EventTypes: []domain.WebhookEventType{"upload.started"}},
+	}
+
+	for _, webhook := range invalid {
+		// Execute
+		result, err := svc.CreateWebhook(context.Background(), webhook)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidWebhook)
+		assert.Nil(t, result)
+	}
+}
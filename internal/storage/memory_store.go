@@ -2,6 +2,8 @@ package storage
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +18,31 @@ type TransactionWithLine struct {
 type MemoryStore struct {
 	uploads         map[string]*domain.Upload
 	transactions    map[string][]TransactionWithLine
+	indexes         map[string]*uploadIndex
+	rejectedRows    map[string][]domain.RejectedRow
+	collections     map[string]*domain.UploadCollection
+	accounts        map[string]*domain.Account
+	accountUploads  map[string]map[string]bool
+	runs            map[string]*domain.ReconciliationRun
+	runResults      map[string][]domain.ReconciliationResult
+	aliases         map[string]domain.CounterpartyAlias
+	categoryRules   map[string]*domain.CategoryRule
+	ruleSet         *domain.RuleSet
+	issueHistory    map[string]map[int][]domain.IssueHistoryEntry
+	statusHistory   map[string]map[int][]domain.StatusChange
+	references      map[string][]rowLocation
+	pendingLinks    map[string][]rowLocation
+	balanceRules    *domain.BalanceRules
+	adjustments     map[string]*domain.Adjustment
+	anomalyWindows  map[string]*anomalyWindow
+	amountHistory   map[amountHistoryKey]*amountHistory
+	anomalies       map[string]map[anomalyKey]domain.Anomaly
+	alertRules      map[string]*domain.AlertRule
+	alerts          map[string]domain.Alert
+	watchlist       map[string]*domain.WatchlistEntry
+	screening       map[string]map[int]*domain.ScreeningResult
+	webhooks        map[string]*domain.WebhookSubscription
+	deliveries      map[string]domain.WebhookDelivery
 	processedEvents map[string]bool
 	mu              sync.RWMutex
 }
@@ -24,6 +51,30 @@ func NewMemoryStore() *MemoryStore {
 	return &MemoryStore{
 		uploads:         make(map[string]*domain.Upload),
 		transactions:    make(map[string][]TransactionWithLine),
+		indexes:         make(map[string]*uploadIndex),
+		rejectedRows:    make(map[string][]domain.RejectedRow),
+		collections:     make(map[string]*domain.UploadCollection),
+		accounts:        make(map[string]*domain.Account),
+		accountUploads:  make(map[string]map[string]bool),
+		runs:            make(map[string]*domain.ReconciliationRun),
+		runResults:      make(map[string][]domain.ReconciliationResult),
+		aliases:         make(map[string]domain.CounterpartyAlias),
+		categoryRules:   make(map[string]*domain.CategoryRule),
+		issueHistory:    make(map[string]map[int][]domain.IssueHistoryEntry),
+		statusHistory:   make(map[string]map[int][]domain.StatusChange),
+		references:      make(map[string][]rowLocation),
+		pendingLinks:    make(map[string][]rowLocation),
+		balanceRules:    domain.DefaultBalanceRules(),
+		adjustments:     make(map[string]*domain.Adjustment),
+		anomalyWindows:  make(map[string]*anomalyWindow),
+		amountHistory:   make(map[amountHistoryKey]*amountHistory),
+		anomalies:       make(map[string]map[anomalyKey]domain.Anomaly),
+		alertRules:      make(map[string]*domain.AlertRule),
+		alerts:          make(map[string]domain.Alert),
+		watchlist:       make(map[string]*domain.WatchlistEntry),
+		screening:       make(map[string]map[int]*domain.ScreeningResult),
+		webhooks:        make(map[string]*domain.WebhookSubscription),
+		deliveries:      make(map[string]domain.WebhookDelivery),
 		processedEvents: make(map[string]bool),
 	}
 }
@@ -41,6 +92,7 @@ func (s *MemoryStore) CreateUpload(ctx context.Context, uploadID string) error {
 	}
 
 	s.transactions[uploadID] = []TransactionWithLine{}
+	s.indexes[uploadID] = &uploadIndex{}
 
 	return nil
 }
@@ -54,7 +106,10 @@ func (s *MemoryStore) GetUpload(ctx context.Context, uploadID string) (*domain.U
 		return nil, domain.ErrUploadNotFound
 	}
 
-	return upload, nil
+	// A copy, workers keep updating the stored upload. The fields it points
+	// at are replaced rather than modified, so they can be shared.
+	result := *upload
+	return &result, nil
 }
 
 func (s *MemoryStore) UpdateUploadStatus(ctx context.Context, uploadID string, status domain.UploadStatus) error {
@@ -89,6 +144,90 @@ func (s *MemoryStore) IncrementProcessedRows(ctx context.Context, uploadID strin
 	return nil
 }
 
+func (s *MemoryStore) SetUploadTotalRows(ctx context.Context, uploadID string, total int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, exists := s.uploads[uploadID]
+	if !exists {
+		return domain.ErrUploadNotFound
+	}
+
+	upload.TotalRows = total
+
+	return nil
+}
+
+func (s *MemoryStore) MarkUploadReconciled(ctx context.Context, uploadID string) (bool, error) {
+	// Only the first caller to observe a completed upload with every row
+	// processed gets true, so follow-up work runs exactly once
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, exists := s.uploads[uploadID]
+	if !exists {
+		return false, domain.ErrUploadNotFound
+	}
+
+	if upload.ReconciledAt != nil ||
+		upload.Status != domain.UploadStatusCompleted ||
+		upload.ProcessedRows < upload.TotalRows {
+		return false, nil
+	}
+
+	now := time.Now()
+	upload.ReconciledAt = &now
+
+	if upload.HoldForReview && s.openScreeningCount(uploadID) > 0 {
+		upload.Status = domain.UploadStatusNeedsReview
+	}
+
+	return true, nil
+}
+
+func (s *MemoryStore) SetUploadBalanceCheck(ctx context.Context, uploadID string, check domain.BalanceCheck) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, exists := s.uploads[uploadID]
+	if !exists {
+		return domain.ErrUploadNotFound
+	}
+
+	upload.BalanceCheck = &check
+
+	return nil
+}
+
+// refreshBalanceCheck recomputes a balance check that already ran, settling
+// rows, approving adjustments and changing the balance rules all move the
+// settled balance. The check is replaced rather than modified since uploads
+// handed out share it. Callers must hold the write lock.
+func (s *MemoryStore) refreshBalanceCheck(uploadID string) {
+	upload, exists := s.uploads[uploadID]
+	if !exists || upload.BalanceCheck == nil || upload.BalanceCheck.CheckedAt == nil {
+		return
+	}
+
+	check := upload.BalanceCheck.Evaluate(s.settledBalance(uploadID), time.Now())
+	upload.BalanceCheck = &check
+}
+
+func (s *MemoryStore) SetUploadPeriod(ctx context.Context, uploadID string, period domain.StatementPeriod) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, exists := s.uploads[uploadID]
+	if !exists {
+		return domain.ErrUploadNotFound
+	}
+
+	upload.Period = &period
+
+	return nil
+}
+
 func (s *MemoryStore) AddTransaction(ctx context.Context, uploadID string, tx domain.Transaction, lineNumber int) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -98,16 +237,42 @@ func (s *MemoryStore) AddTransaction(ctx context.Context, uploadID string, tx do
 		return domain.ErrUploadNotFound
 	}
 
+	if tx.Reference != "" {
+		// Overlapping statements share references, consolidation dedupes
+		// them across uploads
+		if _, taken := s.referenceIn(uploadID, tx.Reference); taken {
+			return domain.ErrDuplicateReference
+		}
+		s.references[tx.Reference] = append(s.references[tx.Reference], rowLocation{uploadID: uploadID, lineNumber: lineNumber})
+	}
+
+	s.linkReversals(uploadID, lineNumber, &tx)
+	s.openIssue(uploadID, lineNumber, &tx)
+	s.insertRow(uploadID, tx, lineNumber)
+
+	return nil
+}
+
+// insertRow appends a row in arrival order and indexes it, workers deliver
+// rows out of order. Callers must hold the lock.
+func (s *MemoryStore) insertRow(uploadID string, tx domain.Transaction, lineNumber int) {
 	s.transactions[uploadID] = append(s.transactions[uploadID], TransactionWithLine{
 		Transaction: tx,
 		LineNumber:  lineNumber,
 	})
+	s.indexRow(uploadID, len(s.transactions[uploadID])-1)
 
-	return nil
+	if tx.AccountID != "" {
+		s.indexAccountUpload(tx.AccountID, uploadID)
+	}
+
+	s.recordAmountHistory(uploadID, tx)
+
+	s.recordScreening(uploadID, lineNumber, tx)
 }
 
 func (s *MemoryStore) GetBalance(ctx context.Context, uploadID string) (int64, error) {
-	// Balance = sum of CREDIT (+) and DEBIT (-) from SUCCESS transactions only
+	// Balance = sum of the rows the balance rules include, signed by their effect
 
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -117,42 +282,95 @@ func (s *MemoryStore) GetBalance(ctx context.Context, uploadID string) (int64, e
 		return 0, domain.ErrUploadNotFound
 	}
 
-	transactions, exists := s.transactions[uploadID]
+	return s.settledBalance(uploadID), nil
+}
+
+// settledBalance sums the rows of an upload as the balance rules settle them.
+// Callers must hold the lock.
+func (s *MemoryStore) settledBalance(uploadID string) int64 {
+	var balance int64
+	for _, txWithLine := range s.transactions[uploadID] {
+		balance += s.balanceRules.Settled(txWithLine.Transaction)
+	}
+	return balance
+}
+
+func (s *MemoryStore) GetBalanceAsOf(ctx context.Context, uploadID string, asOf int64) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.uploads[uploadID]
 	if !exists {
-		return 0, nil
+		return 0, domain.ErrUploadNotFound
 	}
 
 	var balance int64
-	for _, txWithLine := range transactions {
+	for txWithLine := range s.rowsOf(uploadID) {
 		tx := txWithLine.Transaction
-		if tx.Status == domain.TransactionStatusSuccess {
-			if tx.Type == domain.TransactionTypeCredit {
-				balance += tx.Amount
-			} else if tx.Type == domain.TransactionTypeDebit {
-				balance -= tx.Amount
-			}
+		if tx.Timestamp > asOf {
+			break
 		}
+
+		balance += s.balanceRules.Settled(tx)
 	}
 
 	return balance, nil
 }
 
-func (s *MemoryStore) GetIssues(ctx context.Context, uploadID string, page, perPage int, status *domain.TransactionStatus) ([]domain.IssueTransaction, int, error) {
+func (s *MemoryStore) GetBalanceSeries(ctx context.Context, uploadID string, interval domain.SeriesInterval) ([]domain.BalancePoint, error) {
+	// Buckets without settled activity are omitted, the balance carries forward
+
+	width := interval.Seconds()
+	if width <= 0 {
+		return nil, domain.ErrInvalidInterval
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	_, exists := s.uploads[uploadID]
 	if !exists {
-		return nil, 0, domain.ErrUploadNotFound
+		return nil, domain.ErrUploadNotFound
+	}
+
+	series := []domain.BalancePoint{}
+	var balance int64
+	for txWithLine := range s.rowsOf(uploadID) {
+		tx := txWithLine.Transaction
+		if !s.balanceRules.Includes(tx) {
+			continue
+		}
+
+		bucketStart := tx.Timestamp - mod(tx.Timestamp, width)
+		if len(series) == 0 || series[len(series)-1].BucketStart != bucketStart {
+			series = append(series, domain.BalancePoint{
+				BucketStart: bucketStart,
+				Balance:     balance,
+			})
+		}
+
+		point := &series[len(series)-1]
+		amount := s.balanceRules.Settled(tx)
+		splitAmount(amount, &point.Credits, &point.Debits)
+
+		balance += amount
+		point.Balance = balance
 	}
 
-	transactions, exists := s.transactions[uploadID]
+	return series, nil
+}
+
+func (s *MemoryStore) GetIssues(ctx context.Context, uploadID string, page, perPage int, status *domain.TransactionStatus) ([]domain.IssueTransaction, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.uploads[uploadID]
 	if !exists {
-		return []domain.IssueTransaction{}, 0, nil
+		return nil, 0, domain.ErrUploadNotFound
 	}
 
 	var filtered []domain.IssueTransaction
-	for _, txWithLine := range transactions {
+	for txWithLine := range s.rowsOf(uploadID) {
 		tx := txWithLine.Transaction
 
 		if status != nil && tx.Status != *status {
@@ -189,6 +407,351 @@ func (s *MemoryStore) GetIssues(ctx context.Context, uploadID string, page, perP
 	return filtered[start:end], total, nil
 }
 
+func (s *MemoryStore) QueryTransactions(ctx context.Context, query domain.TransactionQuery) ([]domain.IssueTransaction, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.uploads[query.UploadID]
+	if !exists {
+		return nil, 0, domain.ErrUploadNotFound
+	}
+
+	filtered := []domain.IssueTransaction{}
+	for txWithLine := range s.rowsOf(query.UploadID) {
+		if !query.Matches(txWithLine.Transaction) {
+			continue
+		}
+
+		filtered = append(filtered, domain.IssueTransaction{
+			Transaction: txWithLine.Transaction,
+			LineNumber:  txWithLine.LineNumber,
+		})
+	}
+
+	sortTransactions(filtered, query.SortBy, query.SortOrder)
+
+	total := len(filtered)
+
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	perPage := query.PerPage
+	if perPage < 1 {
+		perPage = 10
+	}
+
+	start := (page - 1) * perPage
+	end := start + perPage
+
+	if start >= total {
+		return []domain.IssueTransaction{}, total, nil
+	}
+	if end > total {
+		end = total
+	}
+
+	return filtered[start:end], total, nil
+}
+
+func (s *MemoryStore) SeekTransactions(ctx context.Context, query domain.TransactionQuery, cursor *domain.Cursor) ([]domain.IssueTransaction, bool, error) {
+	// Returns up to PerPage rows on the cursor's side of the boundary row, in
+	// query order, and whether more rows exist further in that direction
+
+	sortBy, err := query.CursorSortField()
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.uploads[query.UploadID]
+	if !exists {
+		return nil, false, domain.ErrUploadNotFound
+	}
+
+	// Both orders are indexed, the cursor is found by binary search
+	index := s.indexes[query.UploadID]
+	order := &index.byTime
+	if sortBy == domain.SortByLineNumber {
+		order = &index.byLine
+	}
+	rows := s.transactions[query.UploadID]
+
+	limit := query.PerPage
+	if limit < 1 {
+		limit = 10
+	}
+
+	backward := cursor != nil && cursor.Direction == domain.CursorDirectionPrev
+	ascending := (query.SortOrder != domain.SortOrderDesc) != backward
+
+	at := order.first()
+	if !ascending {
+		at = order.last()
+	}
+	if cursor != nil {
+		at = order.search(func(pos int) bool {
+			return cursor.Compare(toIssueTransaction(rows[pos])) > 0
+		})
+		if !ascending {
+			at = order.prev(order.search(func(pos int) bool {
+				return cursor.Compare(toIssueTransaction(rows[pos])) >= 0
+			}))
+		}
+	}
+
+	items := []domain.IssueTransaction{}
+	for order.valid(at) && len(items) <= limit {
+		row := rows[order.at(at)]
+		if query.Matches(row.Transaction) {
+			items = append(items, toIssueTransaction(row))
+		}
+
+		if ascending {
+			at = order.next(at)
+		} else {
+			at = order.prev(at)
+		}
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	return items, hasMore, nil
+}
+
+func (s *MemoryStore) AddRejectedRow(ctx context.Context, uploadID string, row domain.RejectedRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, exists := s.uploads[uploadID]
+	if !exists {
+		return domain.ErrUploadNotFound
+	}
+
+	// Most rows arrive from the sequential reader, workers can still reject a
+	// row later on, so insert in line order
+	rows := s.rejectedRows[uploadID]
+	idx := sort.Search(len(rows), func(i int) bool {
+		return rows[i].LineNumber > row.LineNumber
+	})
+
+	rows = append(rows, domain.RejectedRow{})
+	copy(rows[idx+1:], rows[idx:])
+	rows[idx] = row
+	s.rejectedRows[uploadID] = rows
+
+	return nil
+}
+
+func (s *MemoryStore) GetRejectedRows(ctx context.Context, uploadID string, afterLine, limit int) ([]domain.RejectedRow, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.uploads[uploadID]
+	if !exists {
+		return nil, false, domain.ErrUploadNotFound
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	rows := s.rejectedRows[uploadID]
+	start := sort.Search(len(rows), func(i int) bool {
+		return rows[i].LineNumber > afterLine
+	})
+
+	end := start + limit
+	hasMore := end < len(rows)
+	if !hasMore {
+		end = len(rows)
+	}
+
+	page := make([]domain.RejectedRow, end-start)
+	copy(page, rows[start:end])
+
+	return page, hasMore, nil
+}
+
+func (s *MemoryStore) SumByStatus(ctx context.Context, uploadID string, status domain.TransactionStatus) (domain.AmountTotals, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.uploads[uploadID]
+	if !exists {
+		return domain.AmountTotals{}, domain.ErrUploadNotFound
+	}
+
+	var totals domain.AmountTotals
+	for _, txWithLine := range s.transactions[uploadID] {
+		tx := txWithLine.Transaction
+		if tx.Status != status {
+			continue
+		}
+
+		// Split by direction, the rules may leave this status out of the balance
+		splitAmount(s.balanceRules.Signed(tx), &totals.Credit, &totals.Debit)
+	}
+
+	return totals, nil
+}
+
+func (s *MemoryStore) CountByStatus(ctx context.Context, uploadID string) (map[domain.TransactionStatus]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.uploads[uploadID]
+	if !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	counts := map[domain.TransactionStatus]int{
+		domain.TransactionStatusSuccess:  0,
+		domain.TransactionStatusFailed:   0,
+		domain.TransactionStatusPending:  0,
+		domain.TransactionStatusRefunded: 0,
+	}
+	for _, txWithLine := range s.transactions[uploadID] {
+		counts[txWithLine.Transaction.Status]++
+	}
+
+	return counts, nil
+}
+
+func (s *MemoryStore) AggregateByCounterparty(ctx context.Context, query domain.CounterpartyQuery) ([]domain.CounterpartySummary, int, error) {
+	// Credit and debit totals only count rows the balance rules include,
+	// status counts cover every row
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.uploads[query.UploadID]
+	if !exists {
+		return nil, 0, domain.ErrUploadNotFound
+	}
+
+	aliases := s.aliasTable()
+	byName := make(map[string]*domain.CounterpartySummary)
+	variants := make(map[string]map[string]bool)
+	for txWithLine := range s.rowsOf(query.UploadID) {
+		tx := txWithLine.Transaction
+		name := aliases.Canonical(tx.Counterparty)
+
+		summary, ok := byName[name]
+		if !ok {
+			summary = &domain.CounterpartySummary{
+				Counterparty:       name,
+				StatusCounts:       make(map[domain.TransactionStatus]int),
+				FirstTransactionAt: tx.Timestamp,
+			}
+			byName[name] = summary
+			variants[name] = make(map[string]bool)
+		}
+		variants[name][strings.TrimSpace(tx.Counterparty)] = true
+
+		summary.TransactionCount++
+		summary.StatusCounts[tx.Status]++
+		// Rows are stored in timestamp order
+		summary.LastTransactionAt = tx.Timestamp
+
+		splitAmount(s.balanceRules.Settled(tx), &summary.TotalCredit, &summary.TotalDebit)
+	}
+
+	summaries := make([]domain.CounterpartySummary, 0, len(byName))
+	for name, summary := range byName {
+		summary.Variants = sortedKeys(variants[name])
+		summaries = append(summaries, *summary)
+	}
+
+	sortCounterparties(summaries, query.SortBy, query.SortOrder)
+
+	total := len(summaries)
+
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	perPage := query.PerPage
+	if perPage < 1 {
+		perPage = 10
+	}
+
+	start := (page - 1) * perPage
+	end := start + perPage
+
+	if start >= total {
+		return []domain.CounterpartySummary{}, total, nil
+	}
+	if end > total {
+		end = total
+	}
+
+	return summaries[start:end], total, nil
+}
+
+func (s *MemoryStore) SummarizeByPeriod(ctx context.Context, uploadID string, period domain.SummaryPeriod, loc *time.Location) ([]domain.PeriodSummary, error) {
+	// Periods without any rows are omitted. Credits, debits and the closing
+	// balance count the rows the balance rules include, issue counts cover
+	// FAILED and PENDING rows.
+
+	if !period.IsValid() {
+		return nil, domain.ErrInvalidPeriod
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.uploads[uploadID]
+	if !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	summaries := []domain.PeriodSummary{}
+	var balance int64
+	for txWithLine := range s.rowsOf(uploadID) {
+		tx := txWithLine.Transaction
+
+		start := period.Start(time.Unix(tx.Timestamp, 0).In(loc))
+		if len(summaries) == 0 || !summaries[len(summaries)-1].PeriodStart.Equal(start) {
+			summaries = append(summaries, domain.PeriodSummary{
+				PeriodStart:    start,
+				PeriodEnd:      period.Next(start),
+				ClosingBalance: balance,
+			})
+		}
+
+		summary := &summaries[len(summaries)-1]
+		switch tx.Status {
+		case domain.TransactionStatusFailed:
+			summary.FailedCount++
+		case domain.TransactionStatusPending:
+			summary.PendingCount++
+		}
+
+		amount := s.balanceRules.Settled(tx)
+		splitAmount(amount, &summary.Credits, &summary.Debits)
+		balance += amount
+		summary.Net = summary.Credits - summary.Debits
+		summary.ClosingBalance = balance
+	}
+
+	return summaries, nil
+}
+
 func (s *MemoryStore) IsEventProcessed(ctx context.Context, eventID string) (bool, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -204,3 +767,87 @@ func (s *MemoryStore) MarkEventProcessed(ctx context.Context, eventID string) er
 
 	return nil
 }
+
+// splitAmount adds a signed amount to the credit or the debit total
+func splitAmount(amount int64, credit, debit *int64) {
+	if amount > 0 {
+		*credit += amount
+	} else {
+		*debit -= amount
+	}
+}
+
+// mod is a floor modulo so timestamps before the epoch land in the right bucket
+func mod(a, b int64) int64 {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
+
+// sortTransactions orders rows by the requested field, falling back to line
+// number so pages stay stable between requests. Rows are already stored in
+// timestamp order, which is the default.
+func sortTransactions(rows []domain.IssueTransaction, sortBy domain.SortField, order domain.SortOrder) {
+	less := func(a, b domain.IssueTransaction) bool {
+		switch sortBy {
+		case domain.SortByAmount:
+			if a.Amount != b.Amount {
+				return a.Amount < b.Amount
+			}
+		case domain.SortByLineNumber:
+		default:
+			if a.Timestamp != b.Timestamp {
+				return a.Timestamp < b.Timestamp
+			}
+		}
+		return a.LineNumber < b.LineNumber
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if order == domain.SortOrderDesc {
+			return less(rows[j], rows[i])
+		}
+		return less(rows[i], rows[j])
+	})
+}
+
+// sortCounterparties falls back to the name so ties are ordered deterministically
+func sortCounterparties(summaries []domain.CounterpartySummary, sortBy domain.CounterpartySortField, order domain.SortOrder) {
+	less := func(a, b domain.CounterpartySummary) bool {
+		switch sortBy {
+		case domain.CounterpartySortByTotalCredit:
+			if a.TotalCredit != b.TotalCredit {
+				return a.TotalCredit < b.TotalCredit
+			}
+		case domain.CounterpartySortByTotalDebit:
+			if a.TotalDebit != b.TotalDebit {
+				return a.TotalDebit < b.TotalDebit
+			}
+		case domain.CounterpartySortByCount:
+			if a.TransactionCount != b.TransactionCount {
+				return a.TransactionCount < b.TransactionCount
+			}
+		case domain.CounterpartySortByLastSeen:
+			if a.LastTransactionAt != b.LastTransactionAt {
+				return a.LastTransactionAt < b.LastTransactionAt
+			}
+		}
+		return a.Counterparty < b.Counterparty
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if order == domain.SortOrderDesc {
+			return less(summaries[j], summaries[i])
+		}
+		return less(summaries[i], summaries[j])
+	})
+}
+
+func toIssueTransaction(txWithLine TransactionWithLine) domain.IssueTransaction {
+	return domain.IssueTransaction{
+		Transaction: txWithLine.Transaction,
+		LineNumber:  txWithLine.LineNumber,
+	}
+}
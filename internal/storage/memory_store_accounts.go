@@ -0,0 +1,301 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+func (s *MemoryStore) CreateAccount(ctx context.Context, account domain.Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accountNumberTaken(account.AccountNumber, account.ID) {
+		return domain.ErrDuplicateAccount
+	}
+
+	s.accounts[account.ID] = &account
+
+	return nil
+}
+
+func (s *MemoryStore) GetAccount(ctx context.Context, accountID string) (*domain.Account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	account, exists := s.accounts[accountID]
+	if !exists {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	result := *account
+	return &result, nil
+}
+
+func (s *MemoryStore) FindAccountByNumber(ctx context.Context, accountNumber string) (*domain.Account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, account := range s.accounts {
+		if account.AccountNumber == accountNumber {
+			result := *account
+			return &result, nil
+		}
+	}
+
+	return nil, domain.ErrAccountNotFound
+}
+
+func (s *MemoryStore) ListAccounts(ctx context.Context) ([]domain.Account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	accounts := make([]domain.Account, 0, len(s.accounts))
+	for _, account := range s.accounts {
+		accounts = append(accounts, *account)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		if !accounts[i].CreatedAt.Equal(accounts[j].CreatedAt) {
+			return accounts[i].CreatedAt.Before(accounts[j].CreatedAt)
+		}
+		return accounts[i].ID < accounts[j].ID
+	})
+
+	return accounts, nil
+}
+
+func (s *MemoryStore) UpdateAccount(ctx context.Context, account domain.Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.accounts[account.ID]; !exists {
+		return domain.ErrAccountNotFound
+	}
+
+	if s.accountNumberTaken(account.AccountNumber, account.ID) {
+		return domain.ErrDuplicateAccount
+	}
+
+	s.accounts[account.ID] = &account
+
+	return nil
+}
+
+func (s *MemoryStore) DeleteAccount(ctx context.Context, accountID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.accounts[accountID]; !exists {
+		return domain.ErrAccountNotFound
+	}
+
+	if len(s.accountUploads[accountID]) > 0 {
+		return domain.ErrAccountInUse
+	}
+
+	delete(s.accounts, accountID)
+	delete(s.accountUploads, accountID)
+
+	return nil
+}
+
+func (s *MemoryStore) AttachUpload(ctx context.Context, uploadID, accountID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, exists := s.uploads[uploadID]
+	if !exists {
+		return domain.ErrUploadNotFound
+	}
+
+	if _, exists := s.accounts[accountID]; !exists {
+		return domain.ErrAccountNotFound
+	}
+
+	previous := upload.AccountID
+	upload.AccountID = accountID
+	s.indexAccountUpload(accountID, uploadID)
+
+	// Keep the previous account indexed only while rows still name it explicitly
+	if previous != "" && previous != accountID {
+		routed := false
+		for _, txWithLine := range s.transactions[uploadID] {
+			if txWithLine.Transaction.AccountID == previous {
+				routed = true
+				break
+			}
+		}
+		if !routed {
+			delete(s.accountUploads[previous], uploadID)
+		}
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) GetAccountBalance(ctx context.Context, accountID string, asOf *int64) (*domain.AccountBalance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.accounts[accountID]; !exists {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	uploadIDs := s.accountUploadIDs(accountID)
+	rows, perUpload, err := s.consolidate(uploadIDs, func(uploadID string, txWithLine TransactionWithLine) bool {
+		if s.rowAccountID(uploadID, txWithLine) != accountID {
+			return false
+		}
+		return asOf == nil || txWithLine.Transaction.Timestamp <= *asOf
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.AccountBalance{
+		AccountID: accountID,
+		AsOf:      asOf,
+		ConsolidatedBalance: domain.ConsolidatedBalance{
+			Uploads: make([]domain.UploadBalance, 0, len(uploadIDs)),
+		},
+	}
+	for _, row := range rows {
+		perUpload[row.uploadID].Balance += s.balanceRules.Settled(row.txWithLine.Transaction)
+		perUpload[row.uploadID].TransactionCount++
+	}
+
+	for _, uploadID := range uploadIDs {
+		uploadBalance := perUpload[uploadID]
+		result.Balance += uploadBalance.Balance
+		result.TransactionCount += uploadBalance.TransactionCount
+		result.DuplicatesSkipped += uploadBalance.DuplicatesSkipped
+		result.Uploads = append(result.Uploads, *uploadBalance)
+	}
+
+	return result, nil
+}
+
+func (s *MemoryStore) GetAccountIssues(ctx context.Context, query domain.AccountIssueQuery) ([]domain.ConsolidatedIssue, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.accounts[query.AccountID]; !exists {
+		return nil, 0, domain.ErrAccountNotFound
+	}
+
+	rows, _, err := s.consolidate(s.accountUploadIDs(query.AccountID), func(uploadID string, txWithLine TransactionWithLine) bool {
+		if s.rowAccountID(uploadID, txWithLine) != query.AccountID {
+			return false
+		}
+
+		timestamp := txWithLine.Transaction.Timestamp
+		if query.From != nil && timestamp < *query.From {
+			return false
+		}
+		return query.To == nil || timestamp <= *query.To
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	issues, total := paginateConsolidatedIssues(rows, query.Page, query.PerPage, query.Status)
+	return issues, total, nil
+}
+
+func (s *MemoryStore) GetAccountUploadPeriods(ctx context.Context, accountID string) ([]domain.UploadCoverage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.accounts[accountID]; !exists {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	uploadIDs := s.accountUploadIDs(accountID)
+	periods := make([]domain.UploadCoverage, 0, len(uploadIDs))
+	for _, uploadID := range uploadIDs {
+		coverage := domain.UploadCoverage{
+			UploadID: uploadID,
+			Source:   domain.CoverageSourceNone,
+		}
+
+		// Rows are kept in timestamp order, the first and last match bound the period
+		for txWithLine := range s.rowsOf(uploadID) {
+			if s.rowAccountID(uploadID, txWithLine) != accountID {
+				continue
+			}
+
+			if coverage.TransactionCount == 0 {
+				coverage.Start = txWithLine.Transaction.Timestamp
+			}
+			coverage.End = txWithLine.Transaction.Timestamp
+			coverage.TransactionCount++
+		}
+		if coverage.TransactionCount > 0 {
+			coverage.Source = domain.CoverageSourceTransactions
+		}
+
+		// A declared period describes the statement of the upload's own account
+		upload := s.uploads[uploadID]
+		if upload.Period != nil && upload.AccountID == accountID {
+			coverage.Start = upload.Period.Start
+			coverage.End = upload.Period.End
+			coverage.Source = domain.CoverageSourceDeclared
+		}
+
+		periods = append(periods, coverage)
+	}
+
+	return periods, nil
+}
+
+// rowAccountID resolves the account a stored row belongs to, an explicit CSV
+// account column wins over the upload's account. Callers must hold the read lock.
+func (s *MemoryStore) rowAccountID(uploadID string, txWithLine TransactionWithLine) string {
+	if txWithLine.Transaction.AccountID != "" {
+		return txWithLine.Transaction.AccountID
+	}
+	return s.uploads[uploadID].AccountID
+}
+
+// accountUploadIDs lists the uploads holding rows of the account in creation
+// order. Callers must hold the read lock.
+func (s *MemoryStore) accountUploadIDs(accountID string) []string {
+	uploadIDs := make([]string, 0, len(s.accountUploads[accountID]))
+	for uploadID := range s.accountUploads[accountID] {
+		uploadIDs = append(uploadIDs, uploadID)
+	}
+
+	sort.Slice(uploadIDs, func(i, j int) bool {
+		a, b := s.uploads[uploadIDs[i]], s.uploads[uploadIDs[j]]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return uploadIDs[i] < uploadIDs[j]
+	})
+
+	return uploadIDs
+}
+
+// indexAccountUpload records that the upload holds rows of the account.
+// Callers must hold the write lock.
+func (s *MemoryStore) indexAccountUpload(accountID, uploadID string) {
+	uploads, exists := s.accountUploads[accountID]
+	if !exists {
+		uploads = make(map[string]bool)
+		s.accountUploads[accountID] = uploads
+	}
+	uploads[uploadID] = true
+}
+
+// accountNumberTaken reports whether another account already uses the number.
+// Callers must hold the lock.
+func (s *MemoryStore) accountNumberTaken(accountNumber, exceptID string) bool {
+	for id, account := range s.accounts {
+		if id != exceptID && account.AccountNumber == accountNumber {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_AccountCRUD(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	account := domain.Account{
+		ID:            "account-1",
+		AccountNumber: "1234567890",
+		Bank:          "BCA",
+		Currency:      "IDR",
+		Owner:         "John Doe",
+	}
+	err := store.CreateAccount(ctx, account)
+	require.NoError(t, err)
+
+	err = store.CreateAccount(ctx, domain.Account{ID: "account-2", AccountNumber: "1234567890"})
+	assert.ErrorIs(t, err, domain.ErrDuplicateAccount)
+
+	found, err := store.FindAccountByNumber(ctx, "1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, "account-1", found.ID)
+
+	account.Owner = "Jane Doe"
+	err = store.UpdateAccount(ctx, account)
+	require.NoError(t, err)
+
+	stored, err := store.GetAccount(ctx, "account-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", stored.Owner)
+
+	accounts, err := store.ListAccounts(ctx)
+	require.NoError(t, err)
+	assert.Len(t, accounts, 1)
+
+	err = store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+	err = store.AttachUpload(ctx, "upload-1", "account-1")
+	require.NoError(t, err)
+
+	err = store.DeleteAccount(ctx, "account-1")
+	assert.ErrorIs(t, err, domain.ErrAccountInUse)
+
+	err = store.AttachUpload(ctx, "upload-1", "nonexistent")
+	assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+
+	_, err = store.GetAccount(ctx, "nonexistent")
+	assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+}
+
+func TestMemoryStore_GetAccountBalance(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	for _, id := range []string{"checking", "savings"} {
+		err := store.CreateAccount(ctx, domain.Account{ID: id, AccountNumber: id})
+		require.NoError(t, err)
+	}
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	// Routed to savings through the account column
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 1000,
+		Type:      domain.TransactionTypeCredit,
+		Amount:    500000,
+		Status:    domain.TransactionStatusSuccess,
+		AccountID: "savings",
+	}, 1)
+	require.NoError(t, err)
+
+	// Follows the upload's account once attached
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 2000,
+		Type:      domain.TransactionTypeDebit,
+		Amount:    100000,
+		Status:    domain.TransactionStatusSuccess,
+	}, 2)
+	require.NoError(t, err)
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 3000,
+		Type:      domain.TransactionTypeDebit,
+		Amount:    50000,
+		Status:    domain.TransactionStatusPending,
+	}, 3)
+	require.NoError(t, err)
+
+	balance, err := store.GetAccountBalance(ctx, "checking", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), balance.Balance)
+	assert.Equal(t, 0, balance.TransactionCount)
+
+	err = store.AttachUpload(ctx, "upload-1", "checking")
+	require.NoError(t, err)
+
+	balance, err = store.GetAccountBalance(ctx, "checking", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-100000), balance.Balance)
+	assert.Equal(t, 2, balance.TransactionCount)
+
+	asOf := int64(999)
+	balance, err = store.GetAccountBalance(ctx, "savings", &asOf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), balance.Balance)
+
+	balance, err = store.GetAccountBalance(ctx, "savings", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500000), balance.Balance)
+
+	issues, total, err := store.GetAccountIssues(ctx, domain.AccountIssueQuery{AccountID: "checking", Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, 3, issues[0].LineNumber)
+
+	to := int64(2500)
+	_, total, err = store.GetAccountIssues(ctx, domain.AccountIssueQuery{AccountID: "checking", To: &to, Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	_, err = store.GetAccountBalance(ctx, "nonexistent", nil)
+	assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+}
+
+func TestMemoryStore_GetAccountUploadPeriods(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateAccount(ctx, domain.Account{ID: "account-1", AccountNumber: "1234567890"})
+	require.NoError(t, err)
+
+	for _, id := range []string{"derived", "declared", "empty"} {
+		err = store.CreateUpload(ctx, id)
+		require.NoError(t, err)
+		err = store.AttachUpload(ctx, id, "account-1")
+		require.NoError(t, err)
+	}
+
+	for i, ts := range []int64{3000, 1000, 2000} {
+		err = store.AddTransaction(ctx, "derived", domain.Transaction{Timestamp: ts}, i+1)
+		require.NoError(t, err)
+	}
+
+	err = store.SetUploadPeriod(ctx, "declared", domain.StatementPeriod{Start: 5000, End: 9000})
+	require.NoError(t, err)
+	err = store.AddTransaction(ctx, "declared", domain.Transaction{Timestamp: 6000}, 1)
+	require.NoError(t, err)
+
+	periods, err := store.GetAccountUploadPeriods(ctx, "account-1")
+	require.NoError(t, err)
+	require.Len(t, periods, 3)
+
+	byUpload := make(map[string]domain.UploadCoverage)
+	for _, period := range periods {
+		byUpload[period.UploadID] = period
+	}
+
+	assert.Equal(t, domain.UploadCoverage{
+		UploadID:         "derived",
+		Start:            1000,
+		End:              3000,
+		Source:           domain.CoverageSourceTransactions,
+		TransactionCount: 3,
+	}, byUpload["derived"])
+	assert.Equal(t, domain.UploadCoverage{
+		UploadID:         "declared",
+		Start:            5000,
+		End:              9000,
+		Source:           domain.CoverageSourceDeclared,
+		TransactionCount: 1,
+	}, byUpload["declared"])
+	assert.Equal(t, domain.CoverageSourceNone, byUpload["empty"].Source)
+
+	_, err = store.GetAccountUploadPeriods(ctx, "nonexistent")
+	assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+func (s *MemoryStore) CreateAdjustment(ctx context.Context, adjustment domain.Adjustment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.uploads[adjustment.UploadID]; !exists {
+		return domain.ErrUploadNotFound
+	}
+
+	stored := adjustment
+	s.adjustments[adjustment.ID] = &stored
+
+	return nil
+}
+
+func (s *MemoryStore) GetAdjustment(ctx context.Context, uploadID, adjustmentID string) (*domain.Adjustment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	adjustment, exists := s.adjustments[adjustmentID]
+	if !exists || adjustment.UploadID != uploadID {
+		return nil, domain.ErrAdjustmentNotFound
+	}
+
+	result := *adjustment
+	return &result, nil
+}
+
+// ListAdjustments returns an upload's adjustments in the order they were
+// proposed, optionally only those in one state
+func (s *MemoryStore) ListAdjustments(ctx context.Context, uploadID string, state *domain.AdjustmentState) ([]domain.Adjustment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.uploads[uploadID]; !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	adjustments := []domain.Adjustment{}
+	for _, adjustment := range s.adjustments {
+		if adjustment.UploadID != uploadID {
+			continue
+		}
+		if state != nil && adjustment.State != *state {
+			continue
+		}
+		adjustments = append(adjustments, *adjustment)
+	}
+
+	sort.Slice(adjustments, func(i, j int) bool {
+		if !adjustments[i].ProposedAt.Equal(adjustments[j].ProposedAt) {
+			return adjustments[i].ProposedAt.Before(adjustments[j].ProposedAt)
+		}
+		return adjustments[i].ID < adjustments[j].ID
+	})
+
+	return adjustments, nil
+}
+
+// ReviewAdjustment approves or rejects a proposed adjustment. An approved
+// adjustment is stored as a row after the last line of its upload.
+func (s *MemoryStore) ReviewAdjustment(ctx context.Context, uploadID, adjustmentID string, review domain.AdjustmentReview) (*domain.Adjustment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	adjustment, exists := s.adjustments[adjustmentID]
+	if !exists || adjustment.UploadID != uploadID {
+		return nil, domain.ErrAdjustmentNotFound
+	}
+
+	reviewed, err := adjustment.Review(review, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if reviewed.State == domain.AdjustmentStateApproved {
+		reviewed.LineNumber = s.nextLineNumber(uploadID)
+		s.insertRow(uploadID, s.ruleSet.Categorize(reviewed.Transaction()), reviewed.LineNumber)
+		s.refreshBalanceCheck(uploadID)
+	}
+
+	s.adjustments[adjustmentID] = reviewed
+
+	result := *reviewed
+	return &result, nil
+}
+
+// nextLineNumber returns the first line number past the statement and every
+// row stored so far. Callers must hold the lock.
+func (s *MemoryStore) nextLineNumber(uploadID string) int {
+	last := 0
+	if upload, exists := s.uploads[uploadID]; exists {
+		last = upload.TotalRows
+	}
+
+	if index, exists := s.indexes[uploadID]; exists {
+		if at := index.byLine.last(); index.byLine.valid(at) && s.transactions[uploadID][index.byLine.at(at)].LineNumber > last {
+			last = s.transactions[uploadID][index.byLine.at(at)].LineNumber
+		}
+	}
+
+	if rows := s.rejectedRows[uploadID]; len(rows) > 0 && rows[len(rows)-1].LineNumber > last {
+		last = rows[len(rows)-1].LineNumber
+	}
+
+	return last + 1
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_AdjustmentApproval(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+	err = store.SetUploadTotalRows(ctx, "upload-1", 3)
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 1000, Type: domain.TransactionTypeCredit, Amount: 1000, Status: domain.TransactionStatusSuccess,
+	}, 1)
+	require.NoError(t, err)
+	err = store.AddRejectedRow(ctx, "upload-1", domain.RejectedRow{LineNumber: 3, Reason: "invalid amount"})
+	require.NoError(t, err)
+
+	for _, id := range []string{"adj-1", "adj-2"} {
+		err = store.CreateAdjustment(ctx, domain.Adjustment{
+			ID: id, UploadID: "upload-1", Timestamp: 500, Type: domain.TransactionTypeFee, Amount: 25,
+			State: domain.AdjustmentStateProposed, ProposedBy: "alice", ProposedAt: time.Now(),
+		})
+		require.NoError(t, err)
+	}
+
+	// Proposed adjustments leave the balance alone
+	balance, err := store.GetBalance(ctx, "upload-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), balance)
+
+	_, err = store.ReviewAdjustment(ctx, "upload-1", "adj-1", domain.AdjustmentReview{Approve: true, Actor: "Alice"})
+	assert.ErrorIs(t, err, domain.ErrSelfApproval)
+
+	approved, err := store.ReviewAdjustment(ctx, "upload-1", "adj-1", domain.AdjustmentReview{Approve: true, Actor: "bob"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.AdjustmentStateApproved, approved.State)
+	assert.Equal(t, 4, approved.LineNumber)
+
+	_, err = store.ReviewAdjustment(ctx, "upload-1", "adj-1", domain.AdjustmentReview{Actor: "bob", Reason: "again"})
+	assert.ErrorIs(t, err, domain.ErrAdjustmentReviewed)
+
+	rejected, err := store.ReviewAdjustment(ctx, "upload-1", "adj-2", domain.AdjustmentReview{Actor: "bob", Reason: "duplicate"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.AdjustmentStateRejected, rejected.State)
+	assert.Zero(t, rejected.LineNumber)
+
+	balance, err = store.GetBalance(ctx, "upload-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(975), balance)
+
+	rows, total, err := store.QueryTransactions(ctx, domain.TransactionQuery{
+		UploadID: "upload-1",
+		Sources:  []domain.TransactionSource{domain.TransactionSourceAdjustment},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, "adj-1", rows[0].AdjustmentID)
+	assert.Equal(t, 4, rows[0].LineNumber)
+
+	state := domain.AdjustmentStateProposed
+	pending, err := store.ListAdjustments(ctx, "upload-1", &state)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	_, err = store.GetAdjustment(ctx, "upload-2", "adj-1")
+	assert.ErrorIs(t, err, domain.ErrAdjustmentNotFound)
+}
@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+func (s *MemoryStore) CreateAlertRule(ctx context.Context, rule domain.AlertRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule.Counterparties = append([]string(nil), rule.Counterparties...)
+	s.alertRules[rule.ID] = &rule
+
+	return nil
+}
+
+func (s *MemoryStore) GetAlertRule(ctx context.Context, ruleID string) (*domain.AlertRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rule, exists := s.alertRules[ruleID]
+	if !exists {
+		return nil, domain.ErrAlertRuleNotFound
+	}
+
+	result := *rule
+	result.Counterparties = append([]string(nil), rule.Counterparties...)
+	return &result, nil
+}
+
+func (s *MemoryStore) ListAlertRules(ctx context.Context) ([]domain.AlertRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sortedAlertRules(), nil
+}
+
+func (s *MemoryStore) DeleteAlertRule(ctx context.Context, ruleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.alertRules[ruleID]; !exists {
+		return domain.ErrAlertRuleNotFound
+	}
+
+	delete(s.alertRules, ruleID)
+
+	return nil
+}
+
+// EvaluateRowAlerts checks the per-row rules against a stored row and
+// returns only the alerts it raised for the first time
+func (s *MemoryStore) EvaluateRowAlerts(ctx context.Context, uploadID string, row domain.IssueTransaction, now time.Time) ([]domain.Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.uploads[uploadID]; !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	aliases := s.aliasTable()
+	var raised []domain.Alert
+	for _, rule := range s.sortedAlertRules() {
+		if !rule.Kind.PerRow() {
+			continue
+		}
+		if alert, triggered := rule.EvaluateRow(uploadID, row, aliases, s.balanceRules); triggered && s.recordAlert(&alert, now) {
+			raised = append(raised, alert)
+		}
+	}
+
+	return raised, nil
+}
+
+// EvaluateUploadAlerts checks the upload-wide rules once every row is stored
+// and returns only the alerts it raised for the first time
+func (s *MemoryStore) EvaluateUploadAlerts(ctx context.Context, uploadID string, now time.Time) ([]domain.Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, exists := s.uploads[uploadID]
+	if !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	var opening int64
+	if upload.BalanceCheck != nil {
+		opening = upload.BalanceCheck.OpeningBalance
+	}
+
+	rows := make([]domain.IssueTransaction, 0, len(s.transactions[uploadID]))
+	for txWithLine := range s.rowsOf(uploadID) {
+		rows = append(rows, toIssueTransaction(txWithLine))
+	}
+
+	var raised []domain.Alert
+	for _, rule := range s.sortedAlertRules() {
+		if rule.Kind.PerRow() {
+			continue
+		}
+		if alert, triggered := rule.EvaluateUpload(uploadID, rows, opening, s.balanceRules); triggered && s.recordAlert(&alert, now) {
+			raised = append(raised, alert)
+		}
+	}
+
+	return raised, nil
+}
+
+// ListAlerts returns the matching alerts, oldest first
+func (s *MemoryStore) ListAlerts(ctx context.Context, query domain.AlertQuery) ([]domain.Alert, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	alerts := []domain.Alert{}
+	for _, alert := range s.alerts {
+		if query.Matches(alert) {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		if !alerts[i].TriggeredAt.Equal(alerts[j].TriggeredAt) {
+			return alerts[i].TriggeredAt.Before(alerts[j].TriggeredAt)
+		}
+		return alerts[i].ID < alerts[j].ID
+	})
+
+	return alerts, nil
+}
+
+// recordAlert stores an alert unless the same one was raised before. Callers
+// must hold the lock.
+func (s *MemoryStore) recordAlert(alert *domain.Alert, now time.Time) bool {
+	alert.ID = alert.Key()
+	if _, exists := s.alerts[alert.ID]; exists {
+		return false
+	}
+
+	alert.TriggeredAt = now
+	s.alerts[alert.ID] = *alert
+
+	return true
+}
+
+// sortedAlertRules returns copies of the rules in creation order. Callers
+// must hold the read lock.
+func (s *MemoryStore) sortedAlertRules() []domain.AlertRule {
+	rules := make([]domain.AlertRule, 0, len(s.alertRules))
+	for _, rule := range s.alertRules {
+		result := *rule
+		result.Counterparties = append([]string(nil), rule.Counterparties...)
+		rules = append(rules, result)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if !rules[i].CreatedAt.Equal(rules[j].CreatedAt) {
+			return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+		}
+		return rules[i].ID < rules[j].ID
+	})
+
+	return rules
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_EvaluateAlerts(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	_, err = store.SetCounterpartyAlias(ctx, domain.CounterpartyAlias{Alias: "SHADY", Canonical: "SHADY TRADING"})
+	require.NoError(t, err)
+
+	rules := []domain.AlertRule{
+		{ID: "rule-1", Name: "overdraft", Kind: domain.AlertRuleBalanceBelow, Amount: 0, CreatedAt: now},
+		{ID: "rule-2", Name: "large debit", Kind: domain.AlertRuleDebitAbove, Amount: 1000, CreatedAt: now.Add(time.Second)},
+		{ID: "rule-3", Name: "watchlist", Kind: domain.AlertRuleWatchlist, Counterparties: []string{"SHADY TRADING"}, CreatedAt: now.Add(2 * time.Second)},
+		{ID: "rule-4", Name: "failures", Kind: domain.AlertRuleFailedRatioAbove, Ratio: 0.15, CreatedAt: now.Add(3 * time.Second)},
+	}
+	for _, rule := range rules {
+		err = store.CreateAlertRule(ctx, rule)
+		require.NoError(t, err)
+	}
+
+	rows := []domain.Transaction{
+		{Timestamp: 1000, Counterparty: "JOHN", Type: domain.TransactionTypeCredit, Amount: 500, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 2000, Counterparty: "SHADY LTD", Type: domain.TransactionTypeDebit, Amount: 1500, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 3000, Counterparty: "JANE", Type: domain.TransactionTypeCredit, Amount: 2000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 4000, Counterparty: "JANE", Type: domain.TransactionTypeDebit, Amount: 900, Status: domain.TransactionStatusFailed},
+		{Timestamp: 5000, Counterparty: "JANE", Type: domain.TransactionTypeDebit, Amount: 1200, Status: domain.TransactionStatusSuccess},
+	}
+
+	var raised []domain.Alert
+	for i, tx := range rows {
+		err = store.AddTransaction(ctx, "upload-1", tx, i+1)
+		require.NoError(t, err)
+
+		alerts, err := store.EvaluateRowAlerts(ctx, "upload-1", domain.IssueTransaction{Transaction: tx, LineNumber: i + 1}, now)
+		require.NoError(t, err)
+		raised = append(raised, alerts...)
+	}
+
+	// Line 2 breaks two rules, line 5 only the debit limit
+	require.Len(t, raised, 3)
+	assert.Equal(t, "rule-2", raised[0].RuleID)
+	assert.Equal(t, "rule-3", raised[1].RuleID)
+	assert.Equal(t, []int{5}, raised[2].LineNumbers)
+
+	// A retried event raises nothing new
+	again, err := store.EvaluateRowAlerts(ctx, "upload-1", domain.IssueTransaction{Transaction: rows[1], LineNumber: 2}, now)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	upload, err := store.EvaluateUploadAlerts(ctx, "upload-1", now)
+	require.NoError(t, err)
+	require.Len(t, upload, 2)
+
+	// The balance dips on line 2 (500 - 1500) and again on line 5 (1000 - 1200)
+	assert.Equal(t, domain.AlertRuleBalanceBelow, upload[0].Kind)
+	assert.Equal(t, []int{2, 5}, upload[0].LineNumbers)
+	assert.Equal(t, float64(-1000), upload[0].Value)
+	assert.Equal(t, []int{4}, upload[1].LineNumbers)
+	assert.Equal(t, 0.2, upload[1].Value)
+
+	upload, err = store.EvaluateUploadAlerts(ctx, "upload-1", now)
+	require.NoError(t, err)
+	assert.Empty(t, upload)
+
+	alerts, err := store.ListAlerts(ctx, domain.AlertQuery{UploadID: "upload-1"})
+	require.NoError(t, err)
+	assert.Len(t, alerts, 5)
+
+	alerts, err = store.ListAlerts(ctx, domain.AlertQuery{Kinds: []domain.AlertRuleKind{domain.AlertRuleDebitAbove}})
+	require.NoError(t, err)
+	assert.Len(t, alerts, 2)
+
+	err = store.DeleteAlertRule(ctx, "rule-4")
+	require.NoError(t, err)
+
+	_, err = store.GetAlertRule(ctx, "rule-4")
+	assert.ErrorIs(t, err, domain.ErrAlertRuleNotFound)
+
+	_, err = store.EvaluateUploadAlerts(ctx, "missing", now)
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+}
+
+func TestMemoryStore_EvaluateAlerts_SettledOutflows(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	err = store.CreateAlertRule(ctx, domain.AlertRule{ID: "rule-1", Name: "large outflow", Kind: domain.AlertRuleDebitAbove, Amount: 1000, CreatedAt: now})
+	require.NoError(t, err)
+
+	rows := []domain.Transaction{
+		{Timestamp: 1000, Counterparty: "BANK", Type: domain.TransactionTypeFee, Amount: 1500, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 2000, Counterparty: "JANE", Type: domain.TransactionTypeDebit, Amount: 5000, Status: domain.TransactionStatusFailed},
+		{Timestamp: 3000, Counterparty: "JOHN", Type: domain.TransactionTypeCredit, Amount: 5000, Status: domain.TransactionStatusSuccess},
+	}
+
+	var raised []domain.Alert
+	for i, tx := range rows {
+		alerts, err := store.EvaluateRowAlerts(ctx, "upload-1", domain.IssueTransaction{Transaction: tx, LineNumber: i + 1}, now)
+		require.NoError(t, err)
+		raised = append(raised, alerts...)
+	}
+
+	// Only the fee moved money out
+	require.Len(t, raised, 1)
+	assert.Equal(t, []int{1}, raised[0].LineNumbers)
+	assert.Equal(t, float64(1500), raised[0].Value)
+}
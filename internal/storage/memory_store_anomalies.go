@@ -0,0 +1,266 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+type anomalyKey struct {
+	lineNumber int
+	kind       domain.AnomalyKind
+}
+
+// amountHistoryKey groups stored amounts by normalized counterparty and type.
+// Aliases can change at any time, so they are resolved when looking up.
+type amountHistoryKey struct {
+	name   string
+	txType domain.TransactionType
+}
+
+// amountHistory keeps running amount statistics over every upload and per
+// upload, so one upload's share can be taken out
+type amountHistory struct {
+	total    domain.AmountStats
+	byUpload map[string]*domain.AmountStats
+}
+
+// anomalyWindow holds the rows of an upload inspected so far, bucketed by
+// timestamp so the rows near a new one are found without a scan
+type anomalyWindow struct {
+	lines             map[int]bool
+	all               *timeBuckets
+	byCounterparty    map[string]*timeBuckets
+	counterpartyWidth int64
+}
+
+func newAnomalyWindow(rules domain.AnomalyRules) *anomalyWindow {
+	return &anomalyWindow{
+		lines:             make(map[int]bool),
+		all:               newTimeBuckets(rules.BurstWindow),
+		byCounterparty:    make(map[string]*timeBuckets),
+		counterpartyWidth: rules.StructuringWindow,
+	}
+}
+
+// add records a row once, inspecting it again must not count it twice
+func (w *anomalyWindow) add(row domain.IssueTransaction) {
+	if w.lines[row.LineNumber] {
+		return
+	}
+	w.lines[row.LineNumber] = true
+
+	w.all.add(row)
+
+	name := domain.NormalizeCounterparty(row.Counterparty)
+	buckets, exists := w.byCounterparty[name]
+	if !exists {
+		buckets = newTimeBuckets(w.counterpartyWidth)
+		w.byCounterparty[name] = buckets
+	}
+	buckets.add(row)
+}
+
+// timeBuckets groups rows into buckets of a fixed number of seconds
+type timeBuckets struct {
+	width int64
+	rows  map[int64][]domain.IssueTransaction
+}
+
+func newTimeBuckets(width int64) *timeBuckets {
+	if width < 1 {
+		width = 1
+	}
+	return &timeBuckets{
+		width: width,
+		rows:  make(map[int64][]domain.IssueTransaction),
+	}
+}
+
+func (b *timeBuckets) bucket(timestamp int64) int64 {
+	return (timestamp - mod(timestamp, b.width)) / b.width
+}
+
+func (b *timeBuckets) add(row domain.IssueTransaction) {
+	bucket := b.bucket(row.Timestamp)
+	b.rows[bucket] = append(b.rows[bucket], row)
+}
+
+// within returns the rows at most span seconds from timestamp
+func (b *timeBuckets) within(timestamp, span int64) []domain.IssueTransaction {
+	var rows []domain.IssueTransaction
+	for bucket := b.bucket(timestamp - span); bucket <= b.bucket(timestamp+span); bucket++ {
+		for _, row := range b.rows[bucket] {
+			if row.Timestamp >= timestamp-span && row.Timestamp <= timestamp+span {
+				rows = append(rows, row)
+			}
+		}
+	}
+	return rows
+}
+
+// DetectAnomalies inspects a stored row against the rows of its upload
+// inspected before it and the counterparty's rows in other uploads.
+// Inspecting the same row again changes nothing, so retried events are safe.
+func (s *MemoryStore) DetectAnomalies(ctx context.Context, uploadID string, row domain.IssueTransaction, rules domain.AnomalyRules, now time.Time) ([]domain.Anomaly, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.uploads[uploadID]; !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	window, exists := s.anomalyWindows[uploadID]
+	if !exists {
+		window = newAnomalyWindow(rules)
+		s.anomalyWindows[uploadID] = window
+	}
+	window.add(row)
+
+	var sameCounterparty []domain.IssueTransaction
+	if buckets, ok := window.byCounterparty[domain.NormalizeCounterparty(row.Counterparty)]; ok {
+		sameCounterparty = buckets.within(row.Timestamp, rules.StructuringWindow)
+	}
+
+	found := rules.Inspect(row, domain.AnomalyContext{
+		Nearby:           window.all.within(row.Timestamp, rules.BurstWindow),
+		SameCounterparty: sameCounterparty,
+		History:          s.counterpartyHistory(uploadID, row.Transaction),
+		Now:              now,
+	})
+
+	for i := range found {
+		found[i].UploadID = uploadID
+		found[i].DetectedAt = now
+		s.recordAnomaly(found[i])
+	}
+
+	return found, nil
+}
+
+// ListAnomalies returns an upload's findings by line number, then kind
+func (s *MemoryStore) ListAnomalies(ctx context.Context, query domain.AnomalyQuery) ([]domain.Anomaly, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.uploads[query.UploadID]; !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	anomalies := []domain.Anomaly{}
+	for _, anomaly := range s.anomalies[query.UploadID] {
+		if query.Matches(anomaly) {
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+
+	sort.Slice(anomalies, func(i, j int) bool {
+		if anomalies[i].LineNumber != anomalies[j].LineNumber {
+			return anomalies[i].LineNumber < anomalies[j].LineNumber
+		}
+		return anomalies[i].Kind < anomalies[j].Kind
+	})
+
+	return anomalies, nil
+}
+
+// counterpartyHistory summarizes the amounts of the same counterparty and
+// type in other uploads. Callers must hold the lock.
+func (s *MemoryStore) counterpartyHistory(uploadID string, tx domain.Transaction) domain.AmountStats {
+	aliases := s.aliasTable()
+	canonical := aliases.Canonical(tx.Counterparty)
+
+	// Every spelling resolving to the canonical name, aliases are one level deep
+	names := []string{canonical}
+	for alias, target := range aliases {
+		if target == canonical {
+			names = append(names, alias)
+		}
+	}
+
+	var total, own domain.AmountStats
+	for _, name := range names {
+		history, exists := s.amountHistory[amountHistoryKey{name: name, txType: tx.Type}]
+		if !exists {
+			continue
+		}
+
+		total = total.Merge(history.total)
+		if stats, ok := history.byUpload[uploadID]; ok {
+			own = own.Merge(*stats)
+		}
+	}
+
+	return total.Without(own)
+}
+
+// recordAmountHistory adds a stored row to the running statistics. Callers
+// must hold the write lock.
+func (s *MemoryStore) recordAmountHistory(uploadID string, tx domain.Transaction) {
+	key := amountHistoryKey{name: domain.NormalizeCounterparty(tx.Counterparty), txType: tx.Type}
+	history, exists := s.amountHistory[key]
+	if !exists {
+		history = &amountHistory{byUpload: make(map[string]*domain.AmountStats)}
+		s.amountHistory[key] = history
+	}
+
+	stats, exists := history.byUpload[uploadID]
+	if !exists {
+		stats = &domain.AmountStats{}
+		history.byUpload[uploadID] = stats
+	}
+
+	history.total.Add(tx.Amount)
+	stats.Add(tx.Amount)
+}
+
+// recordAnomaly keeps the highest scoring finding of each kind per row and
+// flags the row when it is already stored. Callers must hold the write lock.
+func (s *MemoryStore) recordAnomaly(anomaly domain.Anomaly) {
+	byKey, exists := s.anomalies[anomaly.UploadID]
+	if !exists {
+		byKey = make(map[anomalyKey]domain.Anomaly)
+		s.anomalies[anomaly.UploadID] = byKey
+	}
+
+	key := anomalyKey{lineNumber: anomaly.LineNumber, kind: anomaly.Kind}
+	if existing, ok := byKey[key]; ok && existing.Score >= anomaly.Score {
+		return
+	}
+	byKey[key] = anomaly
+
+	idx, found := s.findRow(anomaly.UploadID, anomaly.LineNumber)
+	if found {
+		s.flagAnomalies(anomaly.UploadID, anomaly.LineNumber, &s.transactions[anomaly.UploadID][idx].Transaction)
+	}
+}
+
+// flagAnomalies copies the findings recorded for a row onto it and opens its
+// issue. Callers must hold the write lock.
+func (s *MemoryStore) flagAnomalies(uploadID string, lineNumber int, tx *domain.Transaction) {
+	var kinds []domain.AnomalyKind
+	for key := range s.anomalies[uploadID] {
+		if key.lineNumber == lineNumber {
+			kinds = append(kinds, key.kind)
+		}
+	}
+	if len(kinds) == 0 {
+		return
+	}
+
+	// The slice is replaced, rows handed out earlier keep the previous one
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	tx.Anomalies = kinds
+
+	if tx.Issue == nil {
+		now := time.Now()
+		tx.Issue = domain.NewIssueWorkflow(now)
+		s.recordIssueChange(uploadID, lineNumber, domain.IssueHistoryEntry{
+			ToState:   domain.IssueStateOpen,
+			Note:      "flagged as anomalous",
+			ChangedAt: now,
+		})
+	}
+}
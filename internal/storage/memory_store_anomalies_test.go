@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_DetectAnomalies(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	rules := domain.DefaultAnomalyRules()
+	now := time.Now()
+	base := now.Add(-24 * time.Hour).Unix()
+
+	for _, uploadID := range []string{"upload-1", "upload-2"} {
+		err := store.CreateUpload(ctx, uploadID)
+		require.NoError(t, err)
+	}
+
+	// History for the outlier check lives in another upload
+	for i, amount := range []int64{100000, 110000, 95000, 105000, 100000} {
+		err := store.AddTransaction(ctx, "upload-1", domain.Transaction{
+			Timestamp: base - 86400*int64(i+1), Counterparty: "COFFEE SHOP", Type: domain.TransactionTypeDebit,
+			Amount: amount, Status: domain.TransactionStatusSuccess,
+		}, i+1)
+		require.NoError(t, err)
+	}
+
+	rows := []domain.IssueTransaction{
+		{LineNumber: 1, Transaction: domain.Transaction{Timestamp: base, Counterparty: "coffee shop", Type: domain.TransactionTypeDebit, Amount: 5000000, Status: domain.TransactionStatusSuccess}},
+		{LineNumber: 2, Transaction: domain.Transaction{Timestamp: now.Add(48 * time.Hour).Unix(), Counterparty: "JOHN", Type: domain.TransactionTypeCredit, Amount: 100, Status: domain.TransactionStatusSuccess}},
+	}
+	// A burst of five rows, inspected last to first
+	for i := 0; i < 5; i++ {
+		rows = append(rows, domain.IssueTransaction{
+			LineNumber:  7 - i,
+			Transaction: domain.Transaction{Timestamp: base + 1000 + int64(6-i)*2, Counterparty: "JANE", Type: domain.TransactionTypeDebit, Amount: 1234, Status: domain.TransactionStatusSuccess},
+		})
+	}
+
+	// Rows are inspected once stored
+	for _, row := range rows {
+		err := store.AddTransaction(ctx, "upload-2", row.Transaction, row.LineNumber)
+		require.NoError(t, err)
+
+		_, err = store.DetectAnomalies(ctx, "upload-2", row, rules, now)
+		require.NoError(t, err)
+	}
+
+	// Inspecting a row again records nothing new
+	_, err := store.DetectAnomalies(ctx, "upload-2", rows[2], rules, now)
+	require.NoError(t, err)
+
+	anomalies, err := store.ListAnomalies(ctx, domain.AnomalyQuery{UploadID: "upload-2"})
+	require.NoError(t, err)
+	require.Len(t, anomalies, 7)
+	assert.Equal(t, domain.AnomalyKindAmountOutlier, anomalies[0].Kind)
+	assert.Greater(t, anomalies[0].Score, 0.5)
+	assert.Equal(t, domain.AnomalyKindFutureTimestamp, anomalies[1].Kind)
+	for _, anomaly := range anomalies[2:] {
+		assert.Equal(t, domain.AnomalyKindBurst, anomaly.Kind)
+	}
+
+	anomalies, err = store.ListAnomalies(ctx, domain.AnomalyQuery{UploadID: "upload-2", Kinds: []domain.AnomalyKind{domain.AnomalyKindBurst}})
+	require.NoError(t, err)
+	assert.Len(t, anomalies, 5)
+
+	// Flagged rows open an issue of the anomaly kind
+	flagged, total, err := store.QueryTransactions(ctx, domain.TransactionQuery{
+		UploadID:   "upload-2",
+		IssueKinds: []domain.IssueKind{domain.IssueKindAnomaly},
+		PerPage:    10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 7, total)
+	assert.Equal(t, []domain.AnomalyKind{domain.AnomalyKindAmountOutlier}, flagged[0].Anomalies)
+	assert.Equal(t, domain.IssueStateOpen, flagged[0].Issue.State)
+}
+
+func TestMemoryStore_DetectStructuring(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	rules := domain.DefaultAnomalyRules()
+	now := time.Now()
+	base := now.Add(-72 * time.Hour).Unix()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	amounts := []int64{49000000, 48000000, 1234567, 47000000}
+	for i, amount := range amounts {
+		_, err := store.DetectAnomalies(ctx, "upload-1", domain.IssueTransaction{
+			LineNumber:  i + 1,
+			Transaction: domain.Transaction{Timestamp: base + int64(i)*3600, Counterparty: "SHELL CO", Type: domain.TransactionTypeDebit, Amount: amount, Status: domain.TransactionStatusSuccess},
+		}, rules, now)
+		require.NoError(t, err)
+	}
+
+	anomalies, err := store.ListAnomalies(ctx, domain.AnomalyQuery{UploadID: "upload-1"})
+	require.NoError(t, err)
+	require.Len(t, anomalies, 3)
+	for _, anomaly := range anomalies {
+		assert.Equal(t, domain.AnomalyKindStructuring, anomaly.Kind)
+		assert.NotEqual(t, 3, anomaly.LineNumber)
+	}
+}
+
+func TestMemoryStore_DetectAnomalies_AliasedHistory(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	rules := domain.DefaultAnomalyRules()
+	now := time.Now()
+	base := now.Add(-24 * time.Hour).Unix()
+
+	for _, uploadID := range []string{"upload-1", "upload-2"} {
+		err := store.CreateUpload(ctx, uploadID)
+		require.NoError(t, err)
+	}
+
+	// History stored under another spelling before the alias existed
+	for i := 0; i < 5; i++ {
+		err := store.AddTransaction(ctx, "upload-1", domain.Transaction{
+			Timestamp: base - 86400*int64(i+1), Counterparty: "KOPI", Type: domain.TransactionTypeDebit,
+			Amount: 100000, Status: domain.TransactionStatusSuccess,
+		}, i+1)
+		require.NoError(t, err)
+	}
+
+	row := domain.IssueTransaction{
+		LineNumber:  1,
+		Transaction: domain.Transaction{Timestamp: base, Counterparty: "Coffee Shop", Type: domain.TransactionTypeDebit, Amount: 5000000, Status: domain.TransactionStatusSuccess},
+	}
+	err := store.AddTransaction(ctx, "upload-2", row.Transaction, 1)
+	require.NoError(t, err)
+
+	found, err := store.DetectAnomalies(ctx, "upload-2", row, rules, now)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	_, err = store.SetCounterpartyAlias(ctx, domain.CounterpartyAlias{Alias: "KOPI", Canonical: "COFFEE SHOP"})
+	require.NoError(t, err)
+
+	// The upload's own rows never count as history
+	found, err = store.DetectAnomalies(ctx, "upload-2", row, rules, now)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, domain.AnomalyKindAmountOutlier, found[0].Kind)
+	assert.Contains(t, found[0].Reason, "of 5 earlier transactions")
+}
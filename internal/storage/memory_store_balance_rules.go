@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+func (s *MemoryStore) GetBalanceRules(ctx context.Context) (*domain.BalanceRules, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.balanceRules, nil
+}
+
+func (s *MemoryStore) SetBalanceRules(ctx context.Context, rules *domain.BalanceRules) error {
+	if rules == nil {
+		return domain.ErrInvalidBalanceRule
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.balanceRules = rules
+	for uploadID := range s.uploads {
+		s.refreshBalanceCheck(uploadID)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_BalanceRules(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	rows := []domain.Transaction{
+		{Timestamp: 1000, Type: domain.TransactionTypeCredit, Amount: 1000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 1001, Type: domain.TransactionTypeFee, Amount: 25, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 1002, Type: domain.TransactionTypeInterest, Amount: 10, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 1003, Type: domain.TransactionTypeAdjustment, Amount: -5, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 1004, Type: domain.TransactionTypeDebit, Amount: 200, Status: domain.TransactionStatusRefunded},
+	}
+	for i, tx := range rows {
+		err = store.AddTransaction(ctx, "upload-1", tx, i+2)
+		require.NoError(t, err)
+	}
+
+	balance, err := store.GetBalance(ctx, "upload-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000-25+10-5), balance)
+
+	totals, err := store.SumByStatus(ctx, "upload-1", domain.TransactionStatusSuccess)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AmountTotals{Credit: 1010, Debit: 30}, totals)
+
+	// Refunded rows are out of the balance but still split by direction
+	totals, err = store.SumByStatus(ctx, "upload-1", domain.TransactionStatusRefunded)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AmountTotals{Debit: 200}, totals)
+
+	// Overrides replace their combination, the rest keep the default
+	rules, err := domain.NewBalanceRules([]domain.BalanceRule{
+		{Type: domain.TransactionTypeFee, Status: domain.TransactionStatusSuccess, Effect: domain.BalanceEffectSubtract, Include: false},
+		{Type: domain.TransactionTypeDebit, Status: domain.TransactionStatusRefunded, Effect: domain.BalanceEffectSubtract, Include: true},
+	})
+	require.NoError(t, err)
+	err = store.SetBalanceRules(ctx, rules)
+	require.NoError(t, err)
+
+	balance, err = store.GetBalance(ctx, "upload-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000+10-5-200), balance)
+}
+
+func TestMemoryStore_ReversalLinks(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	for _, uploadID := range []string{"upload-1", "upload-2"} {
+		err := store.CreateUpload(ctx, uploadID)
+		require.NoError(t, err)
+	}
+
+	// The reversal arrives before the row it reverses and waits for it
+	err := store.AddTransaction(ctx, "upload-2", domain.Transaction{
+		Timestamp: 2000, Type: domain.TransactionTypeReversal, Amount: 300, Status: domain.TransactionStatusSuccess,
+		Reference: "REV-1", ReversalOf: &domain.TransactionLink{Reference: "TRX-1"},
+	}, 2)
+	require.NoError(t, err)
+
+	balance, err := store.GetBalance(ctx, "upload-2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), balance)
+
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 1000, Type: domain.TransactionTypeDebit, Amount: 300, Status: domain.TransactionStatusSuccess,
+		Reference: "TRX-1",
+	}, 2)
+	require.NoError(t, err)
+
+	original, err := store.FindTransactionByReference(ctx, "TRX-1")
+	require.NoError(t, err)
+	require.NotNil(t, original.ReversedBy)
+	assert.Equal(t, domain.TransactionLink{Reference: "REV-1", UploadID: "upload-2", LineNumber: 2, Type: domain.TransactionTypeReversal}, *original.ReversedBy)
+
+	reversal, err := store.FindTransactionByReference(ctx, "REV-1")
+	require.NoError(t, err)
+	require.NotNil(t, reversal.ReversalOf)
+	assert.Equal(t, domain.TransactionLink{Reference: "TRX-1", UploadID: "upload-1", LineNumber: 2, Type: domain.TransactionTypeDebit}, *reversal.ReversalOf)
+
+	// Reversing a DEBIT puts the money back
+	balance, err = store.GetBalance(ctx, "upload-2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), balance)
+
+	// A row is only reversed once
+	err = store.AddTransaction(ctx, "upload-2", domain.Transaction{
+		Timestamp: 2001, Type: domain.TransactionTypeReversal, Amount: 300, Status: domain.TransactionStatusSuccess,
+		Reference: "REV-2", ReversalOf: &domain.TransactionLink{Reference: "TRX-1"},
+	}, 3)
+	require.NoError(t, err)
+
+	second, err := store.FindTransactionByReference(ctx, "REV-2")
+	require.NoError(t, err)
+	assert.False(t, second.ReversalOf.IsResolved())
+
+	balance, err = store.GetBalance(ctx, "upload-2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), balance)
+}
+
+func TestMemoryStore_ReversalLinks_SeveralWaiting(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	for _, uploadID := range []string{"upload-1", "upload-2"} {
+		err := store.CreateUpload(ctx, uploadID)
+		require.NoError(t, err)
+	}
+
+	// Overlapping statements both reverse TRX-1 before carrying it
+	for _, uploadID := range []string{"upload-1", "upload-2"} {
+		err := store.AddTransaction(ctx, uploadID, domain.Transaction{
+			Timestamp: 2000, Type: domain.TransactionTypeReversal, Amount: 300, Status: domain.TransactionStatusSuccess,
+			Reference: "REV-1", ReversalOf: &domain.TransactionLink{Reference: "TRX-1"},
+		}, 2)
+		require.NoError(t, err)
+	}
+
+	debit := domain.Transaction{
+		Timestamp: 1000, Type: domain.TransactionTypeDebit, Amount: 300, Status: domain.TransactionStatusSuccess,
+		Reference: "TRX-1",
+	}
+
+	// Each copy of the original links the reversal of its own upload
+	for _, uploadID := range []string{"upload-2", "upload-1"} {
+		err := store.AddTransaction(ctx, uploadID, debit, 1)
+		require.NoError(t, err)
+
+		balance, err := store.GetBalance(ctx, uploadID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), balance)
+
+		idx, found := store.findRowByReference(uploadID, "REV-1")
+		require.True(t, found)
+		reversal := store.transactions[uploadID][idx].Transaction
+		assert.Equal(t, domain.TransactionLink{Reference: "TRX-1", UploadID: uploadID, LineNumber: 1, Type: domain.TransactionTypeDebit}, *reversal.ReversalOf)
+	}
+
+	assert.Empty(t, store.pendingLinks)
+}
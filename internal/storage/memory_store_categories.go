@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+func (s *MemoryStore) CreateCategoryRule(ctx context.Context, rule domain.CategoryRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule.Tags = append([]string(nil), rule.Tags...)
+	s.categoryRules[rule.ID] = &rule
+
+	return s.rebuildRuleSet()
+}
+
+func (s *MemoryStore) GetCategoryRule(ctx context.Context, ruleID string) (*domain.CategoryRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rule, exists := s.categoryRules[ruleID]
+	if !exists {
+		return nil, domain.ErrCategoryRuleNotFound
+	}
+
+	result := *rule
+	result.Tags = append([]string(nil), rule.Tags...)
+
+	return &result, nil
+}
+
+func (s *MemoryStore) ListCategoryRules(ctx context.Context) ([]domain.CategoryRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sortedCategoryRules(), nil
+}
+
+func (s *MemoryStore) UpdateCategoryRule(ctx context.Context, rule domain.CategoryRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.categoryRules[rule.ID]; !exists {
+		return domain.ErrCategoryRuleNotFound
+	}
+
+	rule.Tags = append([]string(nil), rule.Tags...)
+	s.categoryRules[rule.ID] = &rule
+
+	return s.rebuildRuleSet()
+}
+
+func (s *MemoryStore) DeleteCategoryRule(ctx context.Context, ruleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.categoryRules[ruleID]; !exists {
+		return domain.ErrCategoryRuleNotFound
+	}
+
+	delete(s.categoryRules, ruleID)
+
+	return s.rebuildRuleSet()
+}
+
+func (s *MemoryStore) GetRuleSet(ctx context.Context) (*domain.RuleSet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.ruleSet, nil
+}
+
+func (s *MemoryStore) ApplyRuleSet(ctx context.Context, uploadID string, ruleSet *domain.RuleSet) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	transactions, exists := s.transactions[uploadID]
+	if !exists {
+		return 0, domain.ErrUploadNotFound
+	}
+
+	changed := 0
+	for i := range transactions {
+		before := transactions[i].Transaction
+		after := ruleSet.Categorize(before)
+		if after.Category != before.Category || !equalTags(after.Tags, before.Tags) {
+			changed++
+		}
+		transactions[i].Transaction = after
+	}
+
+	return changed, nil
+}
+
+func (s *MemoryStore) AggregateByCategory(ctx context.Context, uploadID string) ([]domain.CategorySummary, error) {
+	// Credit and debit totals only count SUCCESS rows, counts cover every row
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	transactions, exists := s.transactions[uploadID]
+	if !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	byCategory := make(map[string]*domain.CategorySummary)
+	for _, txWithLine := range transactions {
+		tx := txWithLine.Transaction
+
+		category := tx.Category
+		if category == "" {
+			category = domain.UncategorizedLabel
+		}
+
+		summary, ok := byCategory[category]
+		if !ok {
+			summary = &domain.CategorySummary{Category: category}
+			byCategory[category] = summary
+		}
+
+		summary.TransactionCount++
+		splitAmount(s.balanceRules.Settled(tx), &summary.TotalCredit, &summary.TotalDebit)
+	}
+
+	summaries := make([]domain.CategorySummary, 0, len(byCategory))
+	for _, summary := range byCategory {
+		summaries = append(summaries, *summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Category < summaries[j].Category
+	})
+
+	return summaries, nil
+}
+
+// rebuildRuleSet recompiles the rules after a change. Rules are validated
+// before they are stored so compiling cannot fail on stored data.
+// Callers must hold the write lock.
+func (s *MemoryStore) rebuildRuleSet() error {
+	ruleSet, err := domain.NewRuleSet(s.sortedCategoryRules())
+	if err != nil {
+		return err
+	}
+
+	s.ruleSet = ruleSet
+	return nil
+}
+
+// sortedCategoryRules copies the rules in evaluation order. Callers must hold
+// the lock.
+func (s *MemoryStore) sortedCategoryRules() []domain.CategoryRule {
+	rules := make([]domain.CategoryRule, 0, len(s.categoryRules))
+	for _, rule := range s.categoryRules {
+		copied := *rule
+		copied.Tags = append([]string(nil), rule.Tags...)
+		rules = append(rules, copied)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+		if !rules[i].CreatedAt.Equal(rules[j].CreatedAt) {
+			return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+		}
+		return rules[i].ID < rules[j].ID
+	})
+
+	return rules
+}
+
+func equalTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
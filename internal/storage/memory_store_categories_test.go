@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_CategoryRulePriority(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	err := store.CreateCategoryRule(ctx, domain.CategoryRule{
+		ID:                 "rule-broad",
+		Priority:           10,
+		DescriptionPattern: "coffee|lunch",
+		Category:           "food",
+		CreatedAt:          now,
+	})
+	require.NoError(t, err)
+
+	err = store.CreateCategoryRule(ctx, domain.CategoryRule{
+		ID:           "rule-payroll",
+		Priority:     1,
+		Counterparty: "ACME",
+		Type:         domain.TransactionTypeCredit,
+		Category:     "salary",
+		Tags:         []string{"income"},
+		CreatedAt:    now,
+	})
+	require.NoError(t, err)
+
+	rules, err := store.ListCategoryRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "rule-payroll", rules[0].ID)
+
+	ruleSet, err := store.GetRuleSet(ctx)
+	require.NoError(t, err)
+
+	tx := ruleSet.Categorize(domain.Transaction{
+		Counterparty: "PT Acme",
+		Type:         domain.TransactionTypeCredit,
+		Description:  "Lunch refund",
+	})
+	assert.Equal(t, "salary", tx.Category)
+	assert.Equal(t, []string{"income"}, tx.Tags)
+
+	err = store.DeleteCategoryRule(ctx, "rule-payroll")
+	require.NoError(t, err)
+
+	ruleSet, err = store.GetRuleSet(ctx)
+	require.NoError(t, err)
+
+	tx = ruleSet.Categorize(tx)
+	assert.Equal(t, "food", tx.Category)
+	assert.Empty(t, tx.Tags)
+
+	err = store.DeleteCategoryRule(ctx, "rule-payroll")
+	assert.ErrorIs(t, err, domain.ErrCategoryRuleNotFound)
+}
+
+func TestMemoryStore_ApplyRuleSetAndAggregate(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	rows := []domain.Transaction{
+		{Timestamp: 1000, Counterparty: "CAFE", Type: domain.TransactionTypeDebit, Amount: 50, Status: domain.TransactionStatusSuccess, Description: "coffee"},
+		{Timestamp: 1001, Counterparty: "CAFE", Type: domain.TransactionTypeDebit, Amount: 70, Status: domain.TransactionStatusFailed, Description: "coffee"},
+		{Timestamp: 1002, Counterparty: "ACME", Type: domain.TransactionTypeCredit, Amount: 900, Status: domain.TransactionStatusSuccess, Description: "salary"},
+	}
+	for i, tx := range rows {
+		err = store.AddTransaction(ctx, "upload-1", tx, i+1)
+		require.NoError(t, err)
+	}
+
+	err = store.CreateCategoryRule(ctx, domain.CategoryRule{
+		ID:                 "rule-1",
+		DescriptionPattern: "coffee",
+		Category:           "food",
+	})
+	require.NoError(t, err)
+
+	ruleSet, err := store.GetRuleSet(ctx)
+	require.NoError(t, err)
+
+	changed, err := store.ApplyRuleSet(ctx, "upload-1", ruleSet)
+	require.NoError(t, err)
+	assert.Equal(t, 2, changed)
+
+	// Re-applying the same rules changes nothing
+	changed, err = store.ApplyRuleSet(ctx, "upload-1", ruleSet)
+	require.NoError(t, err)
+	assert.Equal(t, 0, changed)
+
+	summaries, err := store.AggregateByCategory(ctx, "upload-1")
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+
+	assert.Equal(t, "food", summaries[0].Category)
+	assert.Equal(t, int64(50), summaries[0].TotalDebit)
+	assert.Equal(t, 2, summaries[0].TransactionCount)
+
+	assert.Equal(t, domain.UncategorizedLabel, summaries[1].Category)
+	assert.Equal(t, int64(900), summaries[1].TotalCredit)
+
+	_, err = store.ApplyRuleSet(ctx, "missing", ruleSet)
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+}
@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+type consolidatedRow struct {
+	uploadID    string
+	uploadOrder int
+	txWithLine  TransactionWithLine
+}
+
+func (s *MemoryStore) CreateCollection(ctx context.Context, collection domain.UploadCollection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, uploadID := range collection.UploadIDs {
+		if _, exists := s.uploads[uploadID]; !exists {
+			return domain.ErrUploadNotFound
+		}
+	}
+
+	collection.UploadIDs = append([]string(nil), collection.UploadIDs...)
+	s.collections[collection.ID] = &collection
+
+	return nil
+}
+
+func (s *MemoryStore) GetCollection(ctx context.Context, collectionID string) (*domain.UploadCollection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	collection, exists := s.collections[collectionID]
+	if !exists {
+		return nil, domain.ErrCollectionNotFound
+	}
+
+	result := *collection
+	result.UploadIDs = append([]string(nil), collection.UploadIDs...)
+
+	return &result, nil
+}
+
+func (s *MemoryStore) UpdateCollection(ctx context.Context, collection domain.UploadCollection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.collections[collection.ID]; !exists {
+		return domain.ErrCollectionNotFound
+	}
+
+	for _, uploadID := range collection.UploadIDs {
+		if _, exists := s.uploads[uploadID]; !exists {
+			return domain.ErrUploadNotFound
+		}
+	}
+
+	collection.UploadIDs = append([]string(nil), collection.UploadIDs...)
+	s.collections[collection.ID] = &collection
+
+	return nil
+}
+
+func (s *MemoryStore) DeleteCollection(ctx context.Context, collectionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.collections[collectionID]; !exists {
+		return domain.ErrCollectionNotFound
+	}
+
+	delete(s.collections, collectionID)
+
+	return nil
+}
+
+func (s *MemoryStore) GetConsolidatedBalance(ctx context.Context, uploadIDs []string) (*domain.ConsolidatedBalance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, perUpload, err := s.consolidate(uploadIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ConsolidatedBalance{
+		Uploads: make([]domain.UploadBalance, 0, len(uploadIDs)),
+	}
+	for _, row := range rows {
+		amount := s.balanceRules.Settled(row.txWithLine.Transaction)
+		perUpload[row.uploadID].Balance += amount
+		perUpload[row.uploadID].TransactionCount++
+	}
+
+	for _, uploadID := range uploadIDs {
+		uploadBalance := perUpload[uploadID]
+		result.Balance += uploadBalance.Balance
+		result.TransactionCount += uploadBalance.TransactionCount
+		result.DuplicatesSkipped += uploadBalance.DuplicatesSkipped
+		result.Uploads = append(result.Uploads, *uploadBalance)
+	}
+
+	return result, nil
+}
+
+func (s *MemoryStore) GetConsolidatedIssues(ctx context.Context, uploadIDs []string, page, perPage int, status *domain.TransactionStatus) ([]domain.ConsolidatedIssue, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, _, err := s.consolidate(uploadIDs, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	issues, total := paginateConsolidatedIssues(rows, page, perPage, status)
+	return issues, total, nil
+}
+
+// paginateConsolidatedIssues keeps the FAILED and PENDING rows, optionally of a
+// single status, and returns the requested page with the filtered total
+func paginateConsolidatedIssues(rows []consolidatedRow, page, perPage int, status *domain.TransactionStatus) ([]domain.ConsolidatedIssue, int) {
+	var filtered []domain.ConsolidatedIssue
+	for _, row := range rows {
+		tx := row.txWithLine.Transaction
+
+		if status != nil && tx.Status != *status {
+			continue
+		}
+
+		if tx.Status == domain.TransactionStatusFailed || tx.Status == domain.TransactionStatusPending {
+			filtered = append(filtered, domain.ConsolidatedIssue{
+				UploadID:         row.uploadID,
+				IssueTransaction: toIssueTransaction(row.txWithLine),
+			})
+		}
+	}
+
+	total := len(filtered)
+
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 10
+	}
+
+	start := (page - 1) * perPage
+	end := start + perPage
+
+	if start >= total {
+		return []domain.ConsolidatedIssue{}, total
+	}
+	if end > total {
+		end = total
+	}
+
+	return filtered[start:end], total
+}
+
+// consolidate merges the uploads' rows in timestamp order. Every fingerprint
+// is owned by one upload: the most recently created upload holding a SUCCESS
+// row for it, or failing that the most recently created upload holding it at
+// all. The owner keeps all of its rows with that fingerprint, so identical
+// rows within one statement and a FAILED attempt followed by its retry both
+// survive, while the other uploads' copies count as skipped duplicates. Rows
+// rejected by keep are ignored before deduplication, a nil keep takes all.
+// Callers must hold the read lock.
+func (s *MemoryStore) consolidate(uploadIDs []string, keep func(uploadID string, txWithLine TransactionWithLine) bool) ([]consolidatedRow, map[string]*domain.UploadBalance, error) {
+	perUpload := make(map[string]*domain.UploadBalance, len(uploadIDs))
+	for _, uploadID := range uploadIDs {
+		if _, exists := s.uploads[uploadID]; !exists {
+			return nil, nil, domain.ErrUploadNotFound
+		}
+		perUpload[uploadID] = &domain.UploadBalance{UploadID: uploadID}
+	}
+
+	newestFirst := make([]string, 0, len(perUpload))
+	for uploadID := range perUpload {
+		newestFirst = append(newestFirst, uploadID)
+	}
+	sort.Slice(newestFirst, func(i, j int) bool {
+		a, b := s.uploads[newestFirst[i]], s.uploads[newestFirst[j]]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return newestFirst[i] > newestFirst[j]
+	})
+
+	type candidate struct {
+		uploadID    string
+		txWithLine  TransactionWithLine
+		fingerprint string
+	}
+
+	aliases := s.aliasTable()
+	owners := make(map[string]string)
+	settledOwner := make(map[string]bool)
+	var candidates []candidate
+	for _, uploadID := range newestFirst {
+		for txWithLine := range s.rowsOf(uploadID) {
+			if keep != nil && !keep(uploadID, txWithLine) {
+				continue
+			}
+
+			fingerprint := txWithLine.Transaction.Fingerprint(aliases)
+			candidates = append(candidates, candidate{
+				uploadID:    uploadID,
+				txWithLine:  txWithLine,
+				fingerprint: fingerprint,
+			})
+
+			// Uploads are visited newest first, only a settled row from an
+			// older upload can take over an unsettled owner
+			settled := txWithLine.Transaction.Status == domain.TransactionStatusSuccess
+			if _, owned := owners[fingerprint]; !owned || (settled && !settledOwner[fingerprint]) {
+				owners[fingerprint] = uploadID
+				settledOwner[fingerprint] = settled
+			}
+		}
+	}
+
+	order := make(map[string]int, len(newestFirst))
+	for i, uploadID := range newestFirst {
+		order[uploadID] = i
+	}
+
+	var rows []consolidatedRow
+	for _, c := range candidates {
+		if owners[c.fingerprint] != c.uploadID {
+			perUpload[c.uploadID].DuplicatesSkipped++
+			continue
+		}
+
+		rows = append(rows, consolidatedRow{
+			uploadID:    c.uploadID,
+			uploadOrder: order[c.uploadID],
+			txWithLine:  c.txWithLine,
+		})
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.txWithLine.Transaction.Timestamp != b.txWithLine.Transaction.Timestamp {
+			return a.txWithLine.Transaction.Timestamp < b.txWithLine.Transaction.Timestamp
+		}
+		if a.uploadOrder != b.uploadOrder {
+			return a.uploadOrder > b.uploadOrder
+		}
+		return a.txWithLine.LineNumber < b.txWithLine.LineNumber
+	})
+
+	return rows, perUpload, nil
+}
@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_CollectionCRUD(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+	err = store.CreateUpload(ctx, "upload-2")
+	require.NoError(t, err)
+
+	collection := domain.UploadCollection{
+		ID:        "collection-1",
+		Name:      "January",
+		UploadIDs: []string{"upload-1"},
+	}
+	err = store.CreateCollection(ctx, collection)
+	require.NoError(t, err)
+
+	stored, err := store.GetCollection(ctx, "collection-1")
+	require.NoError(t, err)
+	assert.Equal(t, "January", stored.Name)
+	assert.Equal(t, []string{"upload-1"}, stored.UploadIDs)
+
+	collection.UploadIDs = []string{"upload-1", "upload-2"}
+	err = store.UpdateCollection(ctx, collection)
+	require.NoError(t, err)
+
+	stored, err = store.GetCollection(ctx, "collection-1")
+	require.NoError(t, err)
+	assert.Len(t, stored.UploadIDs, 2)
+
+	collection.UploadIDs = []string{"nonexistent"}
+	err = store.UpdateCollection(ctx, collection)
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+
+	err = store.DeleteCollection(ctx, "collection-1")
+	require.NoError(t, err)
+
+	_, err = store.GetCollection(ctx, "collection-1")
+	assert.ErrorIs(t, err, domain.ErrCollectionNotFound)
+}
+
+func TestMemoryStore_GetConsolidatedBalance(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "january")
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	err = store.CreateUpload(ctx, "february")
+	require.NoError(t, err)
+
+	overlap := domain.Transaction{
+		Timestamp:    2000,
+		Counterparty: "JANE DOE",
+		Type:         domain.TransactionTypeCredit,
+		Amount:       500000,
+		Status:       domain.TransactionStatusPending,
+	}
+
+	err = store.AddTransaction(ctx, "january", domain.Transaction{
+		Timestamp: 1000,
+		Type:      domain.TransactionTypeCredit,
+		Amount:    100000,
+		Status:    domain.TransactionStatusSuccess,
+	}, 1)
+	require.NoError(t, err)
+	err = store.AddTransaction(ctx, "january", overlap, 2)
+	require.NoError(t, err)
+
+	// The newer statement has the settled version of the overlapping row
+	overlap.Status = domain.TransactionStatusSuccess
+	overlap.Counterparty = "Jane  Doe"
+	err = store.AddTransaction(ctx, "february", overlap, 1)
+	require.NoError(t, err)
+	err = store.AddTransaction(ctx, "february", domain.Transaction{
+		Timestamp: 3000,
+		Type:      domain.TransactionTypeDebit,
+		Amount:    50000,
+		Status:    domain.TransactionStatusSuccess,
+	}, 2)
+	require.NoError(t, err)
+
+	balance, err := store.GetConsolidatedBalance(ctx, []string{"january", "february"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(550000), balance.Balance)
+	assert.Equal(t, 3, balance.TransactionCount)
+	assert.Equal(t, 1, balance.DuplicatesSkipped)
+
+	require.Len(t, balance.Uploads, 2)
+	assert.Equal(t, domain.UploadBalance{UploadID: "january", Balance: 100000, TransactionCount: 1, DuplicatesSkipped: 1}, balance.Uploads[0])
+	assert.Equal(t, domain.UploadBalance{UploadID: "february", Balance: 450000, TransactionCount: 2}, balance.Uploads[1])
+
+	issues, total, err := store.GetConsolidatedIssues(ctx, []string{"january", "february"}, 1, 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, issues)
+
+	_, err = store.GetConsolidatedBalance(ctx, []string{"january", "nonexistent"})
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+}
+
+func TestMemoryStore_GetConsolidatedBalance_DuplicatesWithinUpload(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "january")
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	err = store.CreateUpload(ctx, "february")
+	require.NoError(t, err)
+
+	coffee := domain.Transaction{
+		Timestamp:    1000,
+		Counterparty: "COFFEE SHOP",
+		Type:         domain.TransactionTypeDebit,
+		Amount:       20000,
+		Status:       domain.TransactionStatusSuccess,
+	}
+	transfer := domain.Transaction{
+		Timestamp:    2000,
+		Counterparty: "JOHN DOE",
+		Type:         domain.TransactionTypeCredit,
+		Amount:       100000,
+		Status:       domain.TransactionStatusFailed,
+	}
+
+	// Two identical purchases and a failed attempt retried in the same second
+	for line, tx := range []domain.Transaction{coffee, coffee, transfer} {
+		err = store.AddTransaction(ctx, "january", tx, line+1)
+		require.NoError(t, err)
+	}
+	transfer.Status = domain.TransactionStatusSuccess
+	err = store.AddTransaction(ctx, "january", transfer, 4)
+	require.NoError(t, err)
+
+	// The newer statement only caught the failed attempt
+	transfer.Status = domain.TransactionStatusFailed
+	err = store.AddTransaction(ctx, "february", transfer, 1)
+	require.NoError(t, err)
+
+	balance, err := store.GetConsolidatedBalance(ctx, []string{"january", "february"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(60000), balance.Balance)
+	assert.Equal(t, 4, balance.TransactionCount)
+	assert.Equal(t, 1, balance.DuplicatesSkipped)
+
+	require.Len(t, balance.Uploads, 2)
+	assert.Equal(t, domain.UploadBalance{UploadID: "january", Balance: 60000, TransactionCount: 4}, balance.Uploads[0])
+	assert.Equal(t, domain.UploadBalance{UploadID: "february", DuplicatesSkipped: 1}, balance.Uploads[1])
+}
+
+func TestMemoryStore_GetConsolidatedIssues(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+	err = store.CreateUpload(ctx, "upload-2")
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 2000,
+		Status:    domain.TransactionStatusFailed,
+	}, 1)
+	require.NoError(t, err)
+	err = store.AddTransaction(ctx, "upload-2", domain.Transaction{
+		Timestamp: 1000,
+		Status:    domain.TransactionStatusPending,
+	}, 1)
+	require.NoError(t, err)
+
+	issues, total, err := store.GetConsolidatedIssues(ctx, []string{"upload-1", "upload-2"}, 1, 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, issues, 2)
+	assert.Equal(t, "upload-2", issues[0].UploadID)
+	assert.Equal(t, "upload-1", issues[1].UploadID)
+
+	failed := domain.TransactionStatusFailed
+	issues, total, err = store.GetConsolidatedIssues(ctx, []string{"upload-1", "upload-2"}, 1, 10, &failed)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "upload-1", issues[0].UploadID)
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+func (s *MemoryStore) SetCounterpartyAlias(ctx context.Context, alias domain.CounterpartyAlias) (*domain.CounterpartyAlias, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Point at the end of an existing chain so lookups stay one level deep
+	if existing, ok := s.aliases[alias.Canonical]; ok {
+		alias.Canonical = existing.Canonical
+	}
+	if alias.Canonical == alias.Alias {
+		return nil, domain.ErrInvalidAlias
+	}
+
+	// Names that used the alias as their canonical follow it to the new one
+	for key, existing := range s.aliases {
+		if existing.Canonical == alias.Alias {
+			existing.Canonical = alias.Canonical
+			s.aliases[key] = existing
+		}
+	}
+
+	s.aliases[alias.Alias] = alias
+
+	return &alias, nil
+}
+
+func (s *MemoryStore) ListCounterpartyAliases(ctx context.Context) ([]domain.CounterpartyAlias, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	aliases := make([]domain.CounterpartyAlias, 0, len(s.aliases))
+	for _, alias := range s.aliases {
+		aliases = append(aliases, alias)
+	}
+
+	sort.Slice(aliases, func(i, j int) bool {
+		if aliases[i].Canonical != aliases[j].Canonical {
+			return aliases[i].Canonical < aliases[j].Canonical
+		}
+		return aliases[i].Alias < aliases[j].Alias
+	})
+
+	return aliases, nil
+}
+
+func (s *MemoryStore) DeleteCounterpartyAlias(ctx context.Context, alias string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.aliases[alias]; !exists {
+		return domain.ErrAliasNotFound
+	}
+
+	delete(s.aliases, alias)
+
+	return nil
+}
+
+func (s *MemoryStore) ListCounterpartyNames(ctx context.Context, uploadID string) ([]domain.CounterpartyName, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	uploadIDs := []string{uploadID}
+	if uploadID == "" {
+		uploadIDs = uploadIDs[:0]
+		for id := range s.uploads {
+			uploadIDs = append(uploadIDs, id)
+		}
+	} else if _, exists := s.uploads[uploadID]; !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	aliases := s.aliasTable()
+	counts := make(map[string]int)
+	variants := make(map[string]map[string]bool)
+	for _, id := range uploadIDs {
+		for _, txWithLine := range s.transactions[id] {
+			name := aliases.Canonical(txWithLine.Transaction.Counterparty)
+			if name == "" {
+				continue
+			}
+
+			if _, ok := variants[name]; !ok {
+				variants[name] = make(map[string]bool)
+			}
+			variants[name][strings.TrimSpace(txWithLine.Transaction.Counterparty)] = true
+			counts[name]++
+		}
+	}
+
+	names := make([]domain.CounterpartyName, 0, len(counts))
+	for name, count := range counts {
+		names = append(names, domain.CounterpartyName{
+			Name:             name,
+			Variants:         sortedKeys(variants[name]),
+			TransactionCount: count,
+		})
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].Name < names[j].Name
+	})
+
+	return names, nil
+}
+
+// aliasTable snapshots the alias lookup. Callers must hold the lock.
+func (s *MemoryStore) aliasTable() domain.CounterpartyAliases {
+	table := make(domain.CounterpartyAliases, len(s.aliases))
+	for key, alias := range s.aliases {
+		table[key] = alias.Canonical
+	}
+	return table
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_CounterpartyAliasChains(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	_, err := store.SetCounterpartyAlias(ctx, domain.CounterpartyAlias{Alias: "J DOE", Canonical: "JOHN DOE"})
+	require.NoError(t, err)
+
+	// Pointing at an alias resolves to its canonical name
+	stored, err := store.SetCounterpartyAlias(ctx, domain.CounterpartyAlias{Alias: "JD", Canonical: "J DOE"})
+	require.NoError(t, err)
+	assert.Equal(t, "JOHN DOE", stored.Canonical)
+
+	// Re-aliasing a canonical name moves its aliases along
+	_, err = store.SetCounterpartyAlias(ctx, domain.CounterpartyAlias{Alias: "JOHN DOE", Canonical: "JOHNATHAN DOE"})
+	require.NoError(t, err)
+
+	aliases, err := store.ListCounterpartyAliases(ctx)
+	require.NoError(t, err)
+	require.Len(t, aliases, 3)
+	for _, alias := range aliases {
+		assert.Equal(t, "JOHNATHAN DOE", alias.Canonical)
+	}
+
+	_, err = store.SetCounterpartyAlias(ctx, domain.CounterpartyAlias{Alias: "JOHNATHAN DOE", Canonical: "JD"})
+	assert.ErrorIs(t, err, domain.ErrInvalidAlias)
+
+	err = store.DeleteCounterpartyAlias(ctx, "JD")
+	require.NoError(t, err)
+	err = store.DeleteCounterpartyAlias(ctx, "JD")
+	assert.ErrorIs(t, err, domain.ErrAliasNotFound)
+}
+
+func TestMemoryStore_AliasesGroupCounterparties(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	for i, name := range []string{"JOHN DOE", "John  Doe", "J. DOE PT", "JANE DOE"} {
+		err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+			Timestamp:    int64(1000 + i),
+			Counterparty: name,
+			Type:         domain.TransactionTypeCredit,
+			Amount:       100,
+			Status:       domain.TransactionStatusSuccess,
+		}, i+1)
+		require.NoError(t, err)
+	}
+
+	names, err := store.ListCounterpartyNames(ctx, "upload-1")
+	require.NoError(t, err)
+	require.Len(t, names, 3)
+	assert.Equal(t, domain.CounterpartyName{Name: "J DOE", Variants: []string{"J. DOE PT"}, TransactionCount: 1}, names[0])
+
+	_, err = store.SetCounterpartyAlias(ctx, domain.CounterpartyAlias{Alias: "J DOE", Canonical: "JOHN DOE"})
+	require.NoError(t, err)
+
+	summaries, total, err := store.AggregateByCounterparty(ctx, domain.CounterpartyQuery{
+		UploadID: "upload-1",
+		SortBy:   domain.CounterpartySortByCount,
+		Page:     1,
+		PerPage:  10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, "JOHN DOE", summaries[1].Counterparty)
+	assert.Equal(t, 3, summaries[1].TransactionCount)
+	assert.Equal(t, []string{"J. DOE PT", "JOHN DOE", "John  Doe"}, summaries[1].Variants)
+
+	_, err = store.ListCounterpartyNames(ctx, "nonexistent")
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+}
@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"iter"
+	"slices"
+	"sort"
+)
+
+// rowIndexChunkSize bounds a chunk of a rowIndex, a full chunk is split in two
+const rowIndexChunkSize = 256
+
+// rowIndex keeps the positions of an upload's rows ordered by a key. The
+// positions sit in bounded chunks, so an insert shifts one chunk instead of
+// the whole upload and a seek is two binary searches.
+type rowIndex struct {
+	chunks [][]int
+}
+
+// rowIndexPos is a place in a rowIndex, one before the first entry or one
+// past the last entry when it is not valid
+type rowIndexPos struct {
+	chunk  int
+	offset int
+}
+
+// uploadIndex orders the rows of an upload, which are stored in arrival order
+type uploadIndex struct {
+	byTime rowIndex
+	byLine rowIndex
+}
+
+// insert places pos after every position less orders before it
+func (x *rowIndex) insert(pos int, less func(a, b int) bool) {
+	if len(x.chunks) == 0 {
+		x.chunks = [][]int{{pos}}
+		return
+	}
+
+	// The first chunk ending after pos takes it, rows arriving in order go to
+	// the end of the last chunk
+	c := sort.Search(len(x.chunks), func(i int) bool {
+		chunk := x.chunks[i]
+		return less(pos, chunk[len(chunk)-1])
+	})
+	if c == len(x.chunks) {
+		c--
+	}
+
+	chunk := x.chunks[c]
+	i := sort.Search(len(chunk), func(j int) bool {
+		return less(pos, chunk[j])
+	})
+	chunk = slices.Insert(chunk, i, pos)
+
+	if len(chunk) < rowIndexChunkSize {
+		x.chunks[c] = chunk
+		return
+	}
+
+	// The left half is capped so appending to it never overwrites the right
+	half := len(chunk) / 2
+	x.chunks[c] = chunk[:half:half]
+	x.chunks = slices.Insert(x.chunks, c+1, chunk[half:])
+}
+
+// search returns the first place whose position satisfies f, f must be false
+// and then true along the index
+func (x *rowIndex) search(f func(pos int) bool) rowIndexPos {
+	c := sort.Search(len(x.chunks), func(i int) bool {
+		chunk := x.chunks[i]
+		return f(chunk[len(chunk)-1])
+	})
+	if c == len(x.chunks) {
+		return rowIndexPos{chunk: c}
+	}
+
+	chunk := x.chunks[c]
+	return rowIndexPos{
+		chunk: c,
+		offset: sort.Search(len(chunk), func(j int) bool {
+			return f(chunk[j])
+		}),
+	}
+}
+
+func (x *rowIndex) first() rowIndexPos {
+	return rowIndexPos{}
+}
+
+func (x *rowIndex) last() rowIndexPos {
+	return x.prev(rowIndexPos{chunk: len(x.chunks)})
+}
+
+func (x *rowIndex) valid(p rowIndexPos) bool {
+	return p.chunk >= 0 && p.chunk < len(x.chunks) && p.offset >= 0 && p.offset < len(x.chunks[p.chunk])
+}
+
+func (x *rowIndex) at(p rowIndexPos) int {
+	return x.chunks[p.chunk][p.offset]
+}
+
+func (x *rowIndex) next(p rowIndexPos) rowIndexPos {
+	p.offset++
+	if p.offset >= len(x.chunks[p.chunk]) {
+		p.chunk, p.offset = p.chunk+1, 0
+	}
+	return p
+}
+
+func (x *rowIndex) prev(p rowIndexPos) rowIndexPos {
+	if p.offset > 0 {
+		p.offset--
+		return p
+	}
+
+	p.chunk--
+	if p.chunk >= 0 {
+		p.offset = len(x.chunks[p.chunk]) - 1
+	}
+	return p
+}
+
+// all yields every position in order
+func (x *rowIndex) all() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, chunk := range x.chunks {
+			for _, pos := range chunk {
+				if !yield(pos) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// indexRow adds the row stored at pos to the indexes of its upload. Callers
+// must hold the write lock.
+func (s *MemoryStore) indexRow(uploadID string, pos int) {
+	index, exists := s.indexes[uploadID]
+	if !exists {
+		index = &uploadIndex{}
+		s.indexes[uploadID] = index
+	}
+
+	rows := s.transactions[uploadID]
+	index.byTime.insert(pos, func(a, b int) bool {
+		return rowLess(rows[a], rows[b])
+	})
+	index.byLine.insert(pos, func(a, b int) bool {
+		return rows[a].LineNumber < rows[b].LineNumber
+	})
+}
+
+// rowsOf yields the rows of an upload in timestamp order, then line number.
+// Callers must hold the lock.
+func (s *MemoryStore) rowsOf(uploadID string) iter.Seq[TransactionWithLine] {
+	return func(yield func(TransactionWithLine) bool) {
+		index, exists := s.indexes[uploadID]
+		if !exists {
+			return
+		}
+
+		rows := s.transactions[uploadID]
+		for pos := range index.byTime.all() {
+			if !yield(rows[pos]) {
+				return
+			}
+		}
+	}
+}
+
+// findRow returns the index of the row stored for a line number. Callers must
+// hold the lock.
+func (s *MemoryStore) findRow(uploadID string, lineNumber int) (int, bool) {
+	index, exists := s.indexes[uploadID]
+	if !exists {
+		return 0, false
+	}
+
+	rows := s.transactions[uploadID]
+	p := index.byLine.search(func(pos int) bool {
+		return rows[pos].LineNumber >= lineNumber
+	})
+	if !index.byLine.valid(p) || rows[index.byLine.at(p)].LineNumber != lineNumber {
+		return 0, false
+	}
+
+	return index.byLine.at(p), true
+}
+
+func rowLess(a, b TransactionWithLine) bool {
+	if a.Transaction.Timestamp != b.Transaction.Timestamp {
+		return a.Transaction.Timestamp < b.Transaction.Timestamp
+	}
+	return a.LineNumber < b.LineNumber
+}
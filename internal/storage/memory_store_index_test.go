@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_SeekTransactions_ManyRows(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	// Enough rows to split index chunks, delivered out of order with
+	// timestamps running against line numbers
+	n := 5 * rowIndexChunkSize
+	for i := 0; i < n; i++ {
+		line := (i*7919)%n + 1
+		err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+			Timestamp: int64(100000 - line),
+			Status:    domain.TransactionStatusFailed,
+		}, line)
+		require.NoError(t, err)
+	}
+
+	idx, found := store.findRow(uploadID, 777)
+	require.True(t, found)
+	assert.Equal(t, 777, store.transactions[uploadID][idx].LineNumber)
+	_, found = store.findRow(uploadID, n+1)
+	assert.False(t, found)
+
+	walk := func(query domain.TransactionQuery) []int {
+		sortBy, err := query.CursorSortField()
+		require.NoError(t, err)
+
+		var lines []int
+		var cursor *domain.Cursor
+		for {
+			rows, hasMore, err := store.SeekTransactions(ctx, query, cursor)
+			require.NoError(t, err)
+			for _, row := range rows {
+				lines = append(lines, row.LineNumber)
+			}
+			if !hasMore {
+				return lines
+			}
+
+			next := domain.NewCursor(domain.CursorDirectionNext, sortBy, rows[len(rows)-1])
+			cursor = &next
+		}
+	}
+
+	// Line number order, ascending
+	lines := walk(domain.TransactionQuery{UploadID: uploadID, SortBy: domain.SortByLineNumber, PerPage: 100})
+	require.Len(t, lines, n)
+	for i, line := range lines {
+		assert.Equal(t, i+1, line)
+	}
+
+	// Timestamp order, descending, walks the lines up as well
+	lines = walk(domain.TransactionQuery{UploadID: uploadID, SortOrder: domain.SortOrderDesc, PerPage: 100})
+	require.Len(t, lines, n)
+	for i, line := range lines {
+		assert.Equal(t, i+1, line)
+	}
+
+	// Stepping back from the middle of an upload
+	rows, _, err := store.SeekTransactions(ctx, domain.TransactionQuery{UploadID: uploadID, SortBy: domain.SortByLineNumber, PerPage: 3},
+		&domain.Cursor{Direction: domain.CursorDirectionPrev, SortBy: domain.SortByLineNumber, LineNumber: 600})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, 597, rows[0].LineNumber)
+	assert.Equal(t, 599, rows[2].LineNumber)
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+func (s *MemoryStore) GetIssue(ctx context.Context, uploadID string, lineNumber int) (*domain.IssueDetail, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	idx, err := s.findIssue(uploadID, lineNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueDetail(uploadID, s.transactions[uploadID][idx]), nil
+}
+
+func (s *MemoryStore) UpdateIssue(ctx context.Context, uploadID string, lineNumber int, update domain.IssueUpdate) (*domain.IssueDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, err := s.findIssue(uploadID, lineNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	row := &s.transactions[uploadID][idx]
+
+	// The workflow is replaced rather than changed in place, rows handed out
+	// earlier keep pointing at the previous one
+	workflow, entry, err := row.Transaction.Issue.Apply(update, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	row.Transaction.Issue = workflow
+	s.recordIssueChange(uploadID, lineNumber, entry)
+
+	return s.issueDetail(uploadID, *row), nil
+}
+
+// openIssue starts the workflow of a FAILED or PENDING row. Callers must hold
+// the write lock.
+func (s *MemoryStore) openIssue(uploadID string, lineNumber int, tx *domain.Transaction) {
+	if tx.Issue != nil || !isIssueStatus(tx.Status) {
+		return
+	}
+
+	now := time.Now()
+	tx.Issue = domain.NewIssueWorkflow(now)
+	s.recordIssueChange(uploadID, lineNumber, domain.IssueHistoryEntry{
+		ToState:   domain.IssueStateOpen,
+		ChangedAt: now,
+	})
+}
+
+// findIssue returns the index of an issue row. Callers must hold the lock.
+func (s *MemoryStore) findIssue(uploadID string, lineNumber int) (int, error) {
+	if _, exists := s.uploads[uploadID]; !exists {
+		return 0, domain.ErrUploadNotFound
+	}
+
+	idx, found := s.findRow(uploadID, lineNumber)
+	if !found || s.transactions[uploadID][idx].Transaction.Issue == nil {
+		return 0, domain.ErrIssueNotFound
+	}
+
+	return idx, nil
+}
+
+// recordIssueChange appends to an issue's history. Callers must hold the
+// write lock.
+func (s *MemoryStore) recordIssueChange(uploadID string, lineNumber int, entry domain.IssueHistoryEntry) {
+	byLine, exists := s.issueHistory[uploadID]
+	if !exists {
+		byLine = make(map[int][]domain.IssueHistoryEntry)
+		s.issueHistory[uploadID] = byLine
+	}
+
+	byLine[lineNumber] = append(byLine[lineNumber], entry)
+}
+
+// issueDetail copies a row and its history. Callers must hold the lock.
+func (s *MemoryStore) issueDetail(uploadID string, txWithLine TransactionWithLine) *domain.IssueDetail {
+	history := append([]domain.IssueHistoryEntry{}, s.issueHistory[uploadID][txWithLine.LineNumber]...)
+
+	return &domain.IssueDetail{
+		IssueTransaction: toIssueTransaction(txWithLine),
+		History:          history,
+	}
+}
+
+func isIssueStatus(status domain.TransactionStatus) bool {
+	return status == domain.TransactionStatusFailed || status == domain.TransactionStatusPending
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_IssueWorkflow(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 1000, Counterparty: "JOHN DOE", Type: domain.TransactionTypeDebit, Amount: 100, Status: domain.TransactionStatusSuccess,
+	}, 1)
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 1001, Counterparty: "JANE DOE", Type: domain.TransactionTypeDebit, Amount: 200, Status: domain.TransactionStatusFailed,
+	}, 2)
+	require.NoError(t, err)
+
+	// Only FAILED and PENDING rows are issues
+	_, err = store.GetIssue(ctx, "upload-1", 1)
+	assert.ErrorIs(t, err, domain.ErrIssueNotFound)
+
+	issue, err := store.GetIssue(ctx, "upload-1", 2)
+	require.NoError(t, err)
+	require.NotNil(t, issue.Transaction.Issue)
+	assert.Equal(t, domain.IssueStateOpen, issue.Transaction.Issue.State)
+	require.Len(t, issue.History, 1)
+
+	investigating := domain.IssueStateInvestigating
+	assignee := "alice"
+	issue, err = store.UpdateIssue(ctx, "upload-1", 2, domain.IssueUpdate{
+		State:    &investigating,
+		Assignee: &assignee,
+		Note:     "asked the bank",
+		Actor:    "bob",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.IssueStateInvestigating, issue.Transaction.Issue.State)
+	assert.Equal(t, "alice", issue.Transaction.Issue.Assignee)
+	require.Len(t, issue.Transaction.Issue.Notes, 1)
+	assert.Equal(t, "bob", issue.Transaction.Issue.Notes[0].Author)
+
+	resolved := domain.IssueStateResolved
+	issue, err = store.UpdateIssue(ctx, "upload-1", 2, domain.IssueUpdate{State: &resolved, Actor: "alice"})
+	require.NoError(t, err)
+	assert.NotNil(t, issue.Transaction.Issue.ClosedAt)
+
+	// Closed issues can only be reopened
+	_, err = store.UpdateIssue(ctx, "upload-1", 2, domain.IssueUpdate{State: &investigating})
+	assert.ErrorIs(t, err, domain.ErrInvalidIssueTransition)
+
+	issue, err = store.GetIssue(ctx, "upload-1", 2)
+	require.NoError(t, err)
+	require.Len(t, issue.History, 3)
+	assert.Equal(t, domain.IssueStateInvestigating, issue.History[2].FromState)
+	assert.Equal(t, domain.IssueStateResolved, issue.History[2].ToState)
+
+	rows, total, err := store.QueryTransactions(ctx, domain.TransactionQuery{
+		UploadID:    "upload-1",
+		IssueStates: []domain.IssueState{domain.IssueStateResolved},
+		Assignee:    "ALICE",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, 2, rows[0].LineNumber)
+
+	_, err = store.GetIssue(ctx, "missing", 2)
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+// ProjectBalance projects an upload's balance from its last row, or from now
+// for an empty upload, using its PENDING rows and recurring series
+func (s *MemoryStore) ProjectBalance(ctx context.Context, query domain.ProjectionQuery, recurring domain.RecurringRules, now time.Time) (*domain.Projection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.uploads[query.UploadID]; !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	input := domain.ProjectionInput{
+		Start: now.Unix(),
+		Rules: s.balanceRules,
+	}
+
+	rows := make([]domain.ConsolidatedIssue, 0, len(s.transactions[query.UploadID]))
+	for txWithLine := range s.rowsOf(query.UploadID) {
+		input.StartBalance += s.balanceRules.Settled(txWithLine.Transaction)
+		if txWithLine.Transaction.Status == domain.TransactionStatusPending {
+			input.Pending = append(input.Pending, toIssueTransaction(txWithLine))
+		}
+
+		rows = append(rows, domain.ConsolidatedIssue{
+			UploadID:         query.UploadID,
+			IssueTransaction: toIssueTransaction(txWithLine),
+		})
+	}
+	if len(rows) > 0 {
+		input.Start = rows[len(rows)-1].Timestamp
+	}
+
+	input.Recurring = recurring.DetectRecurring(rows, s.aliasTable(), input.Start)
+
+	projection := query.Project(input)
+	return &projection, nil
+}
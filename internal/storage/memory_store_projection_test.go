@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_ProjectBalance(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	rules := domain.DefaultRecurringRules()
+	now := time.Now()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	day := func(month time.Month, d int) int64 {
+		return time.Date(2024, month, d, 9, 0, 0, 0, time.UTC).Unix()
+	}
+
+	rows := []domain.Transaction{
+		{Timestamp: day(time.January, 25), Counterparty: "ACME", Type: domain.TransactionTypeCredit, Amount: 4000000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.February, 1), Counterparty: "LANDLORD", Type: domain.TransactionTypeDebit, Amount: 3000000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.February, 25), Counterparty: "ACME", Type: domain.TransactionTypeCredit, Amount: 4000000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.March, 1), Counterparty: "LANDLORD", Type: domain.TransactionTypeDebit, Amount: 3000000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.March, 25), Counterparty: "ACME", Type: domain.TransactionTypeCredit, Amount: 4000000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.April, 1), Counterparty: "LANDLORD", Type: domain.TransactionTypeDebit, Amount: 3000000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.April, 3), Counterparty: "CAR DEALER", Type: domain.TransactionTypeDebit, Amount: 4000000, Status: domain.TransactionStatusPending},
+	}
+	for i, tx := range rows {
+		err = store.AddTransaction(ctx, "upload-1", tx, i+1)
+		require.NoError(t, err)
+	}
+
+	full := 1.0
+	projection, err := store.ProjectBalance(ctx, domain.ProjectionQuery{
+		UploadID:          "upload-1",
+		Horizon:           30 * 24 * time.Hour,
+		SettleProbability: &full,
+	}, rules, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3000000), projection.StartBalance)
+	assert.Equal(t, day(time.April, 3), projection.Start)
+	require.Len(t, projection.Points, 31)
+	assert.Equal(t, "2024-04-03", projection.Points[0].Date)
+	assert.Equal(t, int64(3000000), projection.Points[0].Balance)
+
+	// The PENDING row settles the next day and takes the balance below zero
+	// until the salary arrives
+	assert.Equal(t, int64(-1000000), projection.Points[1].Balance)
+	assert.Equal(t, domain.ProjectionSourcePending, projection.Points[1].Events[0].Source)
+	assert.Len(t, projection.NegativeDates, 21)
+	assert.Equal(t, "2024-04-04", projection.NegativeDates[0])
+	assert.Equal(t, "2024-04-24", projection.NegativeDates[20])
+	assert.Equal(t, int64(-1000000), projection.LowestBalance)
+
+	assert.Equal(t, int64(3000000), projection.Points[22].Balance)
+	assert.Equal(t, domain.RecurrenceMonthly, projection.Points[22].Events[0].Interval)
+	assert.Equal(t, int64(3000000), projection.Points[28].Outflow)
+	assert.Equal(t, int64(0), projection.Points[30].Balance)
+
+	// Weighting the PENDING row keeps the balance positive
+	half := 0.5
+	projection, err = store.ProjectBalance(ctx, domain.ProjectionQuery{
+		UploadID:          "upload-1",
+		Horizon:           30 * 24 * time.Hour,
+		SettleProbability: &half,
+	}, rules, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000000), projection.Points[1].Balance)
+	assert.Empty(t, projection.NegativeDates)
+
+	_, err = store.ProjectBalance(ctx, domain.ProjectionQuery{UploadID: "missing", Horizon: time.Hour}, rules, now)
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+func (s *MemoryStore) CreateReconciliationRun(ctx context.Context, run domain.ReconciliationRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.uploads[run.UploadID]; !exists {
+		return domain.ErrUploadNotFound
+	}
+
+	s.runs[run.ID] = &run
+
+	return nil
+}
+
+func (s *MemoryStore) UpdateReconciliationRun(ctx context.Context, run domain.ReconciliationRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.runs[run.ID]; !exists {
+		return domain.ErrReconciliationRunNotFound
+	}
+
+	s.runs[run.ID] = &run
+
+	return nil
+}
+
+func (s *MemoryStore) GetReconciliationRun(ctx context.Context, runID string) (*domain.ReconciliationRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	run, exists := s.runs[runID]
+	if !exists {
+		return nil, domain.ErrReconciliationRunNotFound
+	}
+
+	result := *run
+	return &result, nil
+}
+
+func (s *MemoryStore) SaveReconciliationResults(ctx context.Context, runID string, results []domain.ReconciliationResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.runs[runID]; !exists {
+		return domain.ErrReconciliationRunNotFound
+	}
+
+	s.runResults[runID] = append([]domain.ReconciliationResult(nil), results...)
+
+	return nil
+}
+
+func (s *MemoryStore) GetReconciliationResults(ctx context.Context, query domain.ReconciliationResultQuery) ([]domain.ReconciliationResult, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.runs[query.RunID]; !exists {
+		return nil, 0, domain.ErrReconciliationRunNotFound
+	}
+
+	var filtered []domain.ReconciliationResult
+	for _, result := range s.runResults[query.RunID] {
+		if query.Classification != nil && result.Classification != *query.Classification {
+			continue
+		}
+		filtered = append(filtered, result)
+	}
+
+	total := len(filtered)
+
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	perPage := query.PerPage
+	if perPage < 1 {
+		perPage = 10
+	}
+
+	start := (page - 1) * perPage
+	end := start + perPage
+
+	if start >= total {
+		return []domain.ReconciliationResult{}, total, nil
+	}
+	if end > total {
+		end = total
+	}
+
+	return filtered[start:end], total, nil
+}
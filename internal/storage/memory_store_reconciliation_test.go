@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_ReconciliationRun(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateReconciliationRun(ctx, domain.ReconciliationRun{ID: "run-1", UploadID: "nonexistent"})
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+
+	err = store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	run := domain.ReconciliationRun{
+		ID:       "run-1",
+		UploadID: "upload-1",
+		Status:   domain.ReconciliationRunStatusProcessing,
+	}
+	err = store.CreateReconciliationRun(ctx, run)
+	require.NoError(t, err)
+
+	run.Status = domain.ReconciliationRunStatusCompleted
+	err = store.UpdateReconciliationRun(ctx, run)
+	require.NoError(t, err)
+
+	stored, err := store.GetReconciliationRun(ctx, "run-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReconciliationRunStatusCompleted, stored.Status)
+
+	_, err = store.GetReconciliationRun(ctx, "nonexistent")
+	assert.ErrorIs(t, err, domain.ErrReconciliationRunNotFound)
+}
+
+func TestMemoryStore_GetReconciliationResults(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+	err = store.CreateReconciliationRun(ctx, domain.ReconciliationRun{ID: "run-1", UploadID: "upload-1"})
+	require.NoError(t, err)
+
+	results := []domain.ReconciliationResult{
+		{Classification: domain.MatchClassificationMatched},
+		{Classification: domain.MatchClassificationUnmatchedBank},
+		{Classification: domain.MatchClassificationMatched},
+	}
+	err = store.SaveReconciliationResults(ctx, "run-1", results)
+	require.NoError(t, err)
+
+	page, total, err := store.GetReconciliationResults(ctx, domain.ReconciliationResultQuery{RunID: "run-1", Page: 2, PerPage: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Len(t, page, 1)
+
+	matched := domain.MatchClassificationMatched
+	page, total, err = store.GetReconciliationResults(ctx, domain.ReconciliationResultQuery{RunID: "run-1", Classification: &matched, Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, page, 2)
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+// DetectRecurring analyses the SUCCESS rows of one upload, or the deduplicated
+// rows of one account across its uploads. Rows after the as-of time are left
+// out, without one the period ends at the latest row of any status.
+func (s *MemoryStore) DetectRecurring(ctx context.Context, query domain.RecurringQuery, rules domain.RecurringRules) ([]domain.RecurringSeries, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	inPeriod := func(txWithLine TransactionWithLine) bool {
+		return query.AsOf == nil || txWithLine.Transaction.Timestamp <= *query.AsOf
+	}
+
+	var rows []domain.ConsolidatedIssue
+	if query.AccountID != "" {
+		if _, exists := s.accounts[query.AccountID]; !exists {
+			return nil, domain.ErrAccountNotFound
+		}
+
+		consolidated, _, err := s.consolidate(s.accountUploadIDs(query.AccountID), func(uploadID string, txWithLine TransactionWithLine) bool {
+			return s.rowAccountID(uploadID, txWithLine) == query.AccountID && inPeriod(txWithLine)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range consolidated {
+			rows = append(rows, domain.ConsolidatedIssue{
+				UploadID:         row.uploadID,
+				IssueTransaction: toIssueTransaction(row.txWithLine),
+			})
+		}
+	} else {
+		if _, exists := s.uploads[query.UploadID]; !exists {
+			return nil, domain.ErrUploadNotFound
+		}
+
+		for txWithLine := range s.rowsOf(query.UploadID) {
+			if !inPeriod(txWithLine) {
+				continue
+			}
+			rows = append(rows, domain.ConsolidatedIssue{
+				UploadID:         query.UploadID,
+				IssueTransaction: toIssueTransaction(txWithLine),
+			})
+		}
+	}
+
+	var asOf int64
+	if query.AsOf != nil {
+		asOf = *query.AsOf
+	} else if len(rows) > 0 {
+		asOf = rows[len(rows)-1].Timestamp
+	}
+
+	return rules.DetectRecurring(rows, s.aliasTable(), asOf), nil
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_DetectRecurring(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	day := func(month time.Month, d int) int64 {
+		return time.Date(2024, month, d, 9, 0, 0, 0, time.UTC).Unix()
+	}
+
+	rows := []domain.Transaction{
+		// Monthly salary, March is missing and June is unusually high
+		{Timestamp: day(time.January, 25), Counterparty: "ACME CORP", Type: domain.TransactionTypeCredit, Amount: 10000000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.February, 25), Counterparty: "Acme Corp", Type: domain.TransactionTypeCredit, Amount: 10000000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.April, 25), Counterparty: "ACME CORP", Type: domain.TransactionTypeCredit, Amount: 10000000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.May, 25), Counterparty: "ACME CORP", Type: domain.TransactionTypeCredit, Amount: 10000000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.June, 25), Counterparty: "ACME CORP", Type: domain.TransactionTypeCredit, Amount: 15000000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.June, 26), Counterparty: "ACME CORP", Type: domain.TransactionTypeCredit, Amount: 10000000, Status: domain.TransactionStatusFailed},
+		// Monthly gym fee that stopped after May
+		{Timestamp: day(time.March, 1), Counterparty: "GYM", Type: domain.TransactionTypeDebit, Amount: 300000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.April, 1), Counterparty: "GYM", Type: domain.TransactionTypeDebit, Amount: 300000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.May, 1), Counterparty: "GYM", Type: domain.TransactionTypeDebit, Amount: 300000, Status: domain.TransactionStatusSuccess},
+		// Weekly subscription with one extra charge between two due dates
+		{Timestamp: day(time.June, 5), Counterparty: "STREAMING", Type: domain.TransactionTypeDebit, Amount: 50000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.June, 8), Counterparty: "STREAMING", Type: domain.TransactionTypeDebit, Amount: 50000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.June, 12), Counterparty: "STREAMING", Type: domain.TransactionTypeDebit, Amount: 50000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.June, 19), Counterparty: "STREAMING", Type: domain.TransactionTypeDebit, Amount: 50000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.June, 26), Counterparty: "STREAMING", Type: domain.TransactionTypeDebit, Amount: 50000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.July, 3), Counterparty: "STREAMING", Type: domain.TransactionTypeDebit, Amount: 50000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.July, 10), Counterparty: "STREAMING", Type: domain.TransactionTypeDebit, Amount: 50000, Status: domain.TransactionStatusSuccess},
+		// Too few occurrences to be a series
+		{Timestamp: day(time.February, 3), Counterparty: "HARDWARE STORE", Type: domain.TransactionTypeDebit, Amount: 750000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: day(time.March, 3), Counterparty: "HARDWARE STORE", Type: domain.TransactionTypeDebit, Amount: 750000, Status: domain.TransactionStatusSuccess},
+	}
+	for i, tx := range rows {
+		err = store.AddTransaction(ctx, "upload-1", tx, i+1)
+		require.NoError(t, err)
+	}
+
+	series, err := store.DetectRecurring(ctx, domain.RecurringQuery{UploadID: "upload-1"}, domain.DefaultRecurringRules())
+	require.NoError(t, err)
+	require.Len(t, series, 3)
+
+	salary := series[0]
+	assert.Equal(t, "ACME", salary.Counterparty)
+	assert.Equal(t, domain.RecurrenceMonthly, salary.Interval)
+	assert.Equal(t, int64(10000000), salary.TypicalAmount)
+	assert.Equal(t, 5, salary.OccurrenceCount)
+	assert.Equal(t, day(time.July, 25), salary.NextExpected)
+	assert.False(t, salary.Overdue)
+	require.Len(t, salary.Exceptions, 2)
+	assert.Equal(t, domain.RecurringExceptionMissed, salary.Exceptions[0].Kind)
+	assert.Equal(t, day(time.March, 25), salary.Exceptions[0].Expected)
+	assert.Equal(t, domain.RecurringExceptionUnusualAmount, salary.Exceptions[1].Kind)
+	assert.Equal(t, 5, salary.Exceptions[1].Occurrence.LineNumber)
+
+	// The period ends at the last row, July 10, so June and July are missed
+	gym := series[1]
+	assert.Equal(t, "GYM", gym.Counterparty)
+	assert.True(t, gym.Overdue)
+	assert.Equal(t, day(time.August, 1), gym.NextExpected)
+	require.Len(t, gym.Exceptions, 2)
+	assert.Equal(t, day(time.June, 1), gym.Exceptions[0].Expected)
+	assert.Equal(t, day(time.July, 1), gym.Exceptions[1].Expected)
+
+	streaming := series[2]
+	assert.Equal(t, domain.RecurrenceWeekly, streaming.Interval)
+	assert.Equal(t, day(time.July, 17), streaming.NextExpected)
+	assert.Equal(t, 0.83, streaming.Regularity)
+	require.Len(t, streaming.Exceptions, 1)
+	assert.Equal(t, domain.RecurringExceptionOffSchedule, streaming.Exceptions[0].Kind)
+	assert.Equal(t, day(time.June, 12), streaming.Exceptions[0].Expected)
+
+	// An earlier as-of time leaves later rows out of the analysis
+	asOf := day(time.May, 28)
+	series, err = store.DetectRecurring(ctx, domain.RecurringQuery{UploadID: "upload-1", AsOf: &asOf}, domain.DefaultRecurringRules())
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+	assert.Equal(t, 4, series[0].OccurrenceCount)
+	assert.False(t, series[1].Overdue)
+
+	_, err = store.DetectRecurring(ctx, domain.RecurringQuery{UploadID: "missing"}, domain.DefaultRecurringRules())
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+
+	_, err = store.DetectRecurring(ctx, domain.RecurringQuery{AccountID: "missing"}, domain.DefaultRecurringRules())
+	assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+}
+
+func TestMemoryStore_DetectRecurring_AmongAdHocRows(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	day := func(month time.Month, d int) int64 {
+		return time.Date(2024, month, d, 9, 0, 0, 0, time.UTC).Unix()
+	}
+
+	// A monthly subscription and ad-hoc purchases with the same shop
+	rows := []domain.Transaction{
+		{Timestamp: day(time.February, 10), Amount: 99000},
+		{Timestamp: day(time.February, 20), Amount: 250000},
+		{Timestamp: day(time.March, 10), Amount: 99000},
+		{Timestamp: day(time.March, 14), Amount: 37000},
+		{Timestamp: day(time.April, 2), Amount: 512000},
+		{Timestamp: day(time.April, 10), Amount: 99000},
+		{Timestamp: day(time.April, 27), Amount: 80000},
+		{Timestamp: day(time.May, 10), Amount: 105000},
+	}
+	for i, tx := range rows {
+		tx.Counterparty = "ONLINE SHOP"
+		tx.Type = domain.TransactionTypeDebit
+		tx.Status = domain.TransactionStatusSuccess
+		err = store.AddTransaction(ctx, "upload-1", tx, i+1)
+		require.NoError(t, err)
+	}
+
+	series, err := store.DetectRecurring(ctx, domain.RecurringQuery{UploadID: "upload-1"}, domain.DefaultRecurringRules())
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+
+	subscription := series[0]
+	assert.Equal(t, domain.RecurrenceMonthly, subscription.Interval)
+	assert.Equal(t, int64(99000), subscription.TypicalAmount)
+	assert.Equal(t, 4, subscription.OccurrenceCount)
+	assert.Equal(t, 1.0, subscription.Regularity)
+	assert.Equal(t, day(time.June, 10), subscription.NextExpected)
+	assert.Empty(t, subscription.Exceptions)
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+// rowLocation points at a stored row
+type rowLocation struct {
+	uploadID   string
+	lineNumber int
+}
+
+func (s *MemoryStore) FindTransactionByReference(ctx context.Context, reference string) (*domain.ConsolidatedIssue, error) {
+	// Overlapping statements may each carry the reference, the copy from the
+	// most recently created upload is returned as consolidation does
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var newest *rowLocation
+	for i, loc := range s.references[reference] {
+		if newest == nil || s.createdAfter(loc.uploadID, newest.uploadID) {
+			newest = &s.references[reference][i]
+		}
+	}
+	if newest == nil {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	idx, found := s.findRow(newest.uploadID, newest.lineNumber)
+	if !found {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	return &domain.ConsolidatedIssue{
+		UploadID:         newest.uploadID,
+		IssueTransaction: toIssueTransaction(s.transactions[newest.uploadID][idx]),
+	}, nil
+}
+
+// createdAfter reports whether upload a was created after upload b, ties are
+// broken by ID. Callers must hold the lock.
+func (s *MemoryStore) createdAfter(a, b string) bool {
+	ua, ub := s.uploads[a], s.uploads[b]
+	if !ua.CreatedAt.Equal(ub.CreatedAt) {
+		return ua.CreatedAt.After(ub.CreatedAt)
+	}
+	return a > b
+}
+
+// referenceIn returns where an upload stores a reference. Callers must hold
+// the lock.
+func (s *MemoryStore) referenceIn(uploadID, reference string) (rowLocation, bool) {
+	for _, loc := range s.references[reference] {
+		if loc.uploadID == uploadID {
+			return loc, true
+		}
+	}
+	return rowLocation{}, false
+}
+
+// findRowByReference returns the index of the row of an upload carrying a
+// reference. Callers must hold the lock.
+func (s *MemoryStore) findRowByReference(uploadID, reference string) (int, bool) {
+	loc, exists := s.referenceIn(uploadID, reference)
+	if !exists {
+		return 0, false
+	}
+
+	return s.findRow(uploadID, loc.lineNumber)
+}
+
+// linkReversals links a REVERSAL row to the row it reverses, or a stored row
+// to a reversal that arrived before it. A row is reversed at most once and a
+// reversal is never reversed. Callers must hold the lock.
+func (s *MemoryStore) linkReversals(uploadID string, lineNumber int, tx *domain.Transaction) {
+	here := rowLocation{uploadID: uploadID, lineNumber: lineNumber}
+
+	if tx.Type == domain.TransactionTypeReversal {
+		if tx.ReversalOf == nil || tx.ReversalOf.IsResolved() {
+			return
+		}
+
+		original, loc, found := s.reversibleRow(uploadID, tx.ReversalOf.Reference)
+		if found {
+			linkReversal(tx, here, original, loc)
+			return
+		}
+
+		// Wait for the row unless this upload already stored it reversed
+		if _, stored := s.referenceIn(uploadID, tx.ReversalOf.Reference); !stored {
+			s.pendingLinks[tx.ReversalOf.Reference] = append(s.pendingLinks[tx.ReversalOf.Reference], here)
+		}
+		return
+	}
+
+	if tx.Reference == "" {
+		return
+	}
+
+	// A reversal waiting in the same upload goes first
+	waiting := s.pendingLinks[tx.Reference]
+	delete(s.pendingLinks, tx.Reference)
+	sort.SliceStable(waiting, func(i, j int) bool {
+		return waiting[i].uploadID == uploadID && waiting[j].uploadID != uploadID
+	})
+	linked := false
+	var still []rowLocation
+	for _, loc := range waiting {
+		idx, found := s.findRow(loc.uploadID, loc.lineNumber)
+		if !found {
+			continue
+		}
+
+		reversal := &s.transactions[loc.uploadID][idx].Transaction
+		if reversal.ReversalOf == nil || reversal.ReversalOf.IsResolved() {
+			continue
+		}
+
+		if !linked {
+			linkReversal(reversal, loc, tx, here)
+			linked = true
+			continue
+		}
+
+		// The row is reversed once. Reversals from other uploads keep waiting
+		// for their own copy of it, another one in this upload stays unlinked
+		// like a reversal arriving after the row was reversed
+		if loc.uploadID != uploadID {
+			still = append(still, loc)
+		}
+	}
+
+	if len(still) > 0 {
+		s.pendingLinks[tx.Reference] = still
+	}
+}
+
+// reversibleRow finds the row a reversal in an upload reverses. The copy in
+// the same upload is preferred, overlapping statements may carry the row
+// more than once. Callers must hold the lock.
+func (s *MemoryStore) reversibleRow(uploadID, reference string) (*domain.Transaction, rowLocation, bool) {
+	reversible := func(loc rowLocation) (*domain.Transaction, bool) {
+		idx, found := s.findRow(loc.uploadID, loc.lineNumber)
+		if !found {
+			return nil, false
+		}
+
+		original := &s.transactions[loc.uploadID][idx].Transaction
+		return original, original.Type != domain.TransactionTypeReversal && original.ReversedBy == nil
+	}
+
+	if loc, exists := s.referenceIn(uploadID, reference); exists {
+		original, ok := reversible(loc)
+		return original, loc, ok
+	}
+
+	// Only copies stored by other uploads remain
+
+	for _, loc := range s.references[reference] {
+		if original, ok := reversible(loc); ok {
+			return original, loc, true
+		}
+	}
+	return nil, rowLocation{}, false
+}
+
+// linkReversal points both rows at each other, stored links are replaced and
+// never modified since rows handed out share them
+func linkReversal(reversal *domain.Transaction, reversalLoc rowLocation, original *domain.Transaction, originalLoc rowLocation) {
+	reversal.ReversalOf = &domain.TransactionLink{
+		Reference:  original.Reference,
+		UploadID:   originalLoc.uploadID,
+		LineNumber: originalLoc.lineNumber,
+		Type:       original.Type,
+	}
+	original.ReversedBy = &domain.TransactionLink{
+		Reference:  reversal.Reference,
+		UploadID:   reversalLoc.uploadID,
+		LineNumber: reversalLoc.lineNumber,
+		Type:       reversal.Type,
+	}
+}
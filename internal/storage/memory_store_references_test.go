@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_TransactionReferences(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	for _, uploadID := range []string{"upload-1", "upload-2"} {
+		err := store.CreateUpload(ctx, uploadID)
+		require.NoError(t, err)
+	}
+
+	err := store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 1000, Counterparty: "JOHN DOE", Type: domain.TransactionTypeDebit, Amount: 100,
+		Status: domain.TransactionStatusPending, Reference: "TRX-1",
+	}, 3)
+	require.NoError(t, err)
+
+	// A bank reference is stored once per upload
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 1000, Counterparty: "JOHN DOE", Type: domain.TransactionTypeDebit, Amount: 100,
+		Status: domain.TransactionStatusPending, Reference: "TRX-1",
+	}, 4)
+	assert.ErrorIs(t, err, domain.ErrDuplicateReference)
+
+	// An overlapping statement may carry it again, the newest copy is found
+	err = store.AddTransaction(ctx, "upload-2", domain.Transaction{
+		Timestamp: 1000, Counterparty: "JOHN DOE", Type: domain.TransactionTypeDebit, Amount: 100,
+		Status: domain.TransactionStatusFailed, Reference: "TRX-1",
+	}, 1)
+	require.NoError(t, err)
+
+	found, err := store.FindTransactionByReference(ctx, "TRX-1")
+	require.NoError(t, err)
+	assert.Equal(t, "upload-2", found.UploadID)
+	assert.Equal(t, 1, found.LineNumber)
+
+	_, err = store.FindTransactionByReference(ctx, "TRX-2")
+	assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+
+	// Status updates can name the row by reference within its upload
+	_, err = store.UpdateTransactionStatus(ctx, "upload-1", domain.StatusUpdate{Reference: "TRX-3", Status: domain.TransactionStatusSuccess})
+	assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+
+	tx, err := store.UpdateTransactionStatus(ctx, "upload-1", domain.StatusUpdate{Reference: "TRX-1", Status: domain.TransactionStatusSuccess})
+	require.NoError(t, err)
+	assert.Equal(t, domain.TransactionStatusSuccess, tx.Status)
+	assert.Equal(t, 3, tx.LineNumber)
+
+	history, err := store.GetStatusHistory(ctx, "upload-1", 3)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func TestMemoryStore_RejectedRowsStayInLineOrder(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	for _, line := range []int{2, 7, 4} {
+		err = store.AddRejectedRow(ctx, "upload-1", domain.RejectedRow{LineNumber: line})
+		require.NoError(t, err)
+	}
+
+	rows, _, err := store.GetRejectedRows(ctx, "upload-1", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, 4, rows[1].LineNumber)
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+func (s *MemoryStore) UpdateTransactionStatus(ctx context.Context, uploadID string, update domain.StatusUpdate) (*domain.IssueTransaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.uploads[uploadID]; !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	idx, found := s.findRow(uploadID, update.LineNumber)
+	if update.Reference != "" {
+		idx, found = s.findRowByReference(uploadID, update.Reference)
+	}
+	if !found {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	row := &s.transactions[uploadID][idx]
+	lineNumber := row.LineNumber
+	if !row.Transaction.Status.CanTransitionTo(update.Status) {
+		return nil, domain.ErrInvalidStatusTransition
+	}
+
+	now := time.Now()
+	change := domain.StatusChange{
+		FromStatus: row.Transaction.Status,
+		ToStatus:   update.Status,
+		Reason:     update.Reason,
+		Source:     update.Source,
+		ChangedAt:  now,
+	}
+
+	row.Transaction.Status = update.Status
+	s.refreshBalanceCheck(uploadID)
+
+	byLine, exists := s.statusHistory[uploadID]
+	if !exists {
+		byLine = make(map[int][]domain.StatusChange)
+		s.statusHistory[uploadID] = byLine
+	}
+	byLine[lineNumber] = append(byLine[lineNumber], change)
+
+	// A row that settled successfully needs no follow-up, FAILED and flagged
+	// rows stay open for the ops team
+	if update.Status == domain.TransactionStatusSuccess && len(row.Transaction.Anomalies) == 0 &&
+		row.Transaction.Issue != nil && !row.Transaction.Issue.State.IsClosed() {
+		resolved := domain.IssueStateResolved
+		workflow, entry, err := row.Transaction.Issue.Apply(domain.IssueUpdate{
+			State: &resolved,
+			Note:  "settled as SUCCESS",
+			Actor: string(update.Source),
+		}, now)
+		if err == nil {
+			row.Transaction.Issue = workflow
+			s.recordIssueChange(uploadID, lineNumber, entry)
+		}
+	}
+
+	result := toIssueTransaction(*row)
+	return &result, nil
+}
+
+func (s *MemoryStore) GetStatusHistory(ctx context.Context, uploadID string, lineNumber int) ([]domain.StatusChange, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.uploads[uploadID]; !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	if _, found := s.findRow(uploadID, lineNumber); !found {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	return append([]domain.StatusChange{}, s.statusHistory[uploadID][lineNumber]...), nil
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_UpdateTransactionStatus(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 1000, Counterparty: "JOHN DOE", Type: domain.TransactionTypeCredit, Amount: 500, Status: domain.TransactionStatusSuccess,
+	}, 1)
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 1001, Counterparty: "JANE DOE", Type: domain.TransactionTypeDebit, Amount: 200, Status: domain.TransactionStatusPending,
+	}, 2)
+	require.NoError(t, err)
+
+	tx, err := store.UpdateTransactionStatus(ctx, "upload-1", domain.StatusUpdate{
+		LineNumber: 2,
+		Status:     domain.TransactionStatusSuccess,
+		Reason:     "cleared",
+		Source:     domain.StatusUpdateSourceAPI,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.TransactionStatusSuccess, tx.Status)
+	assert.Equal(t, domain.IssueStateResolved, tx.Issue.State)
+
+	// Balance and issues see the settled row straight away
+	balance, err := store.GetBalance(ctx, "upload-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), balance)
+
+	_, total, err := store.GetIssues(ctx, "upload-1", 1, 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	// Settled rows are final
+	_, err = store.UpdateTransactionStatus(ctx, "upload-1", domain.StatusUpdate{LineNumber: 2, Status: domain.TransactionStatusFailed})
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+
+	_, err = store.UpdateTransactionStatus(ctx, "upload-1", domain.StatusUpdate{LineNumber: 9, Status: domain.TransactionStatusFailed})
+	assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+
+	history, err := store.GetStatusHistory(ctx, "upload-1", 2)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, domain.TransactionStatusPending, history[0].FromStatus)
+	assert.Equal(t, domain.TransactionStatusSuccess, history[0].ToStatus)
+	assert.Equal(t, "cleared", history[0].Reason)
+
+	history, err = store.GetStatusHistory(ctx, "upload-1", 1)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestMemoryStore_UpdateTransactionStatus_RefreshesBalanceCheck(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 1000, Type: domain.TransactionTypeCredit, Amount: 500, Status: domain.TransactionStatusSuccess,
+	}, 1)
+	require.NoError(t, err)
+	err = store.AddTransaction(ctx, "upload-1", domain.Transaction{
+		Timestamp: 1001, Type: domain.TransactionTypeDebit, Amount: 200, Status: domain.TransactionStatusPending,
+	}, 2)
+	require.NoError(t, err)
+
+	expected := int64(375)
+	declared := domain.BalanceCheck{OpeningBalance: 100, ExpectedClosingBalance: &expected}
+	err = store.SetUploadBalanceCheck(ctx, "upload-1", declared.Evaluate(500, time.Now()))
+	require.NoError(t, err)
+
+	upload, err := store.GetUpload(ctx, "upload-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), *upload.BalanceCheck.ComputedClosingBalance)
+	assert.False(t, *upload.BalanceCheck.Matched)
+
+	// Settling the PENDING debit moves the closing balance
+	_, err = store.UpdateTransactionStatus(ctx, "upload-1", domain.StatusUpdate{LineNumber: 2, Status: domain.TransactionStatusSuccess})
+	require.NoError(t, err)
+
+	upload, err = store.GetUpload(ctx, "upload-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(400), *upload.BalanceCheck.ComputedClosingBalance)
+	assert.Equal(t, int64(25), *upload.BalanceCheck.Difference)
+
+	// So does an approved adjustment
+	err = store.CreateAdjustment(ctx, domain.Adjustment{
+		ID: "adj-1", UploadID: "upload-1", Timestamp: 1002, Type: domain.TransactionTypeFee, Amount: 25,
+		State: domain.AdjustmentStateProposed, ProposedBy: "alice", ProposedAt: time.Now(),
+	})
+	require.NoError(t, err)
+	_, err = store.ReviewAdjustment(ctx, "upload-1", "adj-1", domain.AdjustmentReview{Approve: true, Actor: "bob"})
+	require.NoError(t, err)
+
+	upload, err = store.GetUpload(ctx, "upload-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(375), *upload.BalanceCheck.ComputedClosingBalance)
+	assert.True(t, *upload.BalanceCheck.Matched)
+	assert.Zero(t, *upload.BalanceCheck.Difference)
+
+	// A check that has not run yet stays empty
+	err = store.CreateUpload(ctx, "upload-2")
+	require.NoError(t, err)
+	err = store.SetUploadBalanceCheck(ctx, "upload-2", domain.BalanceCheck{OpeningBalance: 100})
+	require.NoError(t, err)
+	err = store.AddTransaction(ctx, "upload-2", domain.Transaction{
+		Timestamp: 1000, Type: domain.TransactionTypeCredit, Amount: 500, Status: domain.TransactionStatusPending,
+	}, 1)
+	require.NoError(t, err)
+	_, err = store.UpdateTransactionStatus(ctx, "upload-2", domain.StatusUpdate{LineNumber: 1, Status: domain.TransactionStatusSuccess})
+	require.NoError(t, err)
+
+	upload, err = store.GetUpload(ctx, "upload-2")
+	require.NoError(t, err)
+	assert.Nil(t, upload.BalanceCheck.ComputedClosingBalance)
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/grachmannico95/flip-test-be/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -67,6 +68,75 @@ func TestMemoryStore_IncrementProcessedRows(t *testing.T) {
 	assert.Equal(t, 5, upload.ProcessedRows)
 }
 
+func TestMemoryStore_MarkUploadReconciled(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	err = store.SetUploadTotalRows(ctx, uploadID, 2)
+	require.NoError(t, err)
+
+	err = store.IncrementProcessedRows(ctx, uploadID)
+	require.NoError(t, err)
+
+	// Still processing
+	reconciled, err := store.MarkUploadReconciled(ctx, uploadID)
+	require.NoError(t, err)
+	assert.False(t, reconciled)
+
+	err = store.UpdateUploadStatus(ctx, uploadID, domain.UploadStatusCompleted)
+	require.NoError(t, err)
+
+	// Rows still in flight
+	reconciled, err = store.MarkUploadReconciled(ctx, uploadID)
+	require.NoError(t, err)
+	assert.False(t, reconciled)
+
+	err = store.IncrementProcessedRows(ctx, uploadID)
+	require.NoError(t, err)
+
+	reconciled, err = store.MarkUploadReconciled(ctx, uploadID)
+	require.NoError(t, err)
+	assert.True(t, reconciled)
+
+	// Only reported once
+	reconciled, err = store.MarkUploadReconciled(ctx, uploadID)
+	require.NoError(t, err)
+	assert.False(t, reconciled)
+
+	upload, err := store.GetUpload(ctx, uploadID)
+	require.NoError(t, err)
+	assert.NotNil(t, upload.ReconciledAt)
+}
+
+func TestMemoryStore_SetUploadBalanceCheck(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	expected := int64(750000)
+	err = store.SetUploadBalanceCheck(ctx, uploadID, domain.BalanceCheck{
+		OpeningBalance:         500000,
+		ExpectedClosingBalance: &expected,
+	})
+	require.NoError(t, err)
+
+	upload, err := store.GetUpload(ctx, uploadID)
+	require.NoError(t, err)
+	require.NotNil(t, upload.BalanceCheck)
+	assert.Equal(t, int64(500000), upload.BalanceCheck.OpeningBalance)
+	assert.Equal(t, &expected, upload.BalanceCheck.ExpectedClosingBalance)
+
+	err = store.SetUploadBalanceCheck(ctx, "nonexistent", domain.BalanceCheck{})
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+}
+
 func TestMemoryStore_AddTransaction(t *testing.T) {
 	store := NewMemoryStore()
 	ctx := context.Background()
@@ -156,6 +226,150 @@ func TestMemoryStore_GetBalance_OnlySuccessTransactions(t *testing.T) {
 	assert.Equal(t, int64(1000), balance)
 }
 
+func TestMemoryStore_AddTransaction_OrderedByTimestamp(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	// Workers deliver rows out of order
+	for _, line := range []int{3, 1, 2} {
+		err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+			Timestamp: int64(1674507880 + line),
+			Status:    domain.TransactionStatusFailed,
+		}, line)
+		require.NoError(t, err)
+	}
+
+	issues, _, err := store.GetIssues(ctx, uploadID, 1, 10, nil)
+	require.NoError(t, err)
+	require.Len(t, issues, 3)
+	assert.Equal(t, 1, issues[0].LineNumber)
+	assert.Equal(t, 2, issues[1].LineNumber)
+	assert.Equal(t, 3, issues[2].LineNumber)
+
+	// Rows stay in arrival order, the indexes order them
+	for i, line := range []int{3, 1, 2} {
+		assert.Equal(t, line, store.transactions[uploadID][i].LineNumber)
+	}
+}
+
+func TestMemoryStore_GetBalanceAsOf(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+		Timestamp: 1000,
+		Type:      domain.TransactionTypeCredit,
+		Amount:    500000,
+		Status:    domain.TransactionStatusSuccess,
+	}, 1)
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+		Timestamp: 2000,
+		Type:      domain.TransactionTypeDebit,
+		Amount:    200000,
+		Status:    domain.TransactionStatusSuccess,
+	}, 2)
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+		Timestamp: 1500,
+		Type:      domain.TransactionTypeDebit,
+		Amount:    100000,
+		Status:    domain.TransactionStatusPending,
+	}, 3)
+	require.NoError(t, err)
+
+	balance, err := store.GetBalanceAsOf(ctx, uploadID, 999)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), balance)
+
+	balance, err = store.GetBalanceAsOf(ctx, uploadID, 1999)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500000), balance)
+
+	balance, err = store.GetBalanceAsOf(ctx, uploadID, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300000), balance)
+}
+
+func TestMemoryStore_GetBalanceAsOf_OutOfOrderRows(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	// The upload is still being ingested, the later row arrived first
+	err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+		Timestamp: 2000,
+		Type:      domain.TransactionTypeCredit,
+		Amount:    100,
+		Status:    domain.TransactionStatusSuccess,
+	}, 2)
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+		Timestamp: 1000,
+		Type:      domain.TransactionTypeCredit,
+		Amount:    50,
+		Status:    domain.TransactionStatusSuccess,
+	}, 1)
+	require.NoError(t, err)
+
+	balance, err := store.GetBalanceAsOf(ctx, uploadID, 1500)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), balance)
+
+	balance, err = store.GetBalanceAsOf(ctx, uploadID, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), balance)
+}
+
+func TestMemoryStore_GetBalanceSeries(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	hour := int64(3600)
+	transactions := []domain.Transaction{
+		{Timestamp: 10, Type: domain.TransactionTypeCredit, Amount: 1000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 20, Type: domain.TransactionTypeDebit, Amount: 300, Status: domain.TransactionStatusSuccess},
+		{Timestamp: hour + 5, Type: domain.TransactionTypeCredit, Amount: 9999, Status: domain.TransactionStatusFailed},
+		{Timestamp: 3*hour + 1, Type: domain.TransactionTypeCredit, Amount: 500, Status: domain.TransactionStatusSuccess},
+	}
+	for i, tx := range transactions {
+		err = store.AddTransaction(ctx, uploadID, tx, i+1)
+		require.NoError(t, err)
+	}
+
+	series, err := store.GetBalanceSeries(ctx, uploadID, domain.SeriesIntervalHour)
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+	assert.Equal(t, domain.BalancePoint{BucketStart: 0, Credits: 1000, Debits: 300, Balance: 700}, series[0])
+	assert.Equal(t, domain.BalancePoint{BucketStart: 3 * hour, Credits: 500, Balance: 1200}, series[1])
+
+	series, err = store.GetBalanceSeries(ctx, uploadID, domain.SeriesIntervalDay)
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	assert.Equal(t, int64(1200), series[0].Balance)
+
+	_, err = store.GetBalanceSeries(ctx, uploadID, domain.SeriesInterval("minute"))
+	assert.ErrorIs(t, err, domain.ErrInvalidInterval)
+}
+
 func TestMemoryStore_GetIssues(t *testing.T) {
 	store := NewMemoryStore()
 	ctx := context.Background()
@@ -244,6 +458,358 @@ func TestMemoryStore_GetIssues_Pagination(t *testing.T) {
 	assert.Len(t, issues, 1)
 }
 
+func TestMemoryStore_QueryTransactions(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	transactions := []domain.Transaction{
+		{Timestamp: 1674507883, Counterparty: "JOHN DOE", Type: domain.TransactionTypeDebit, Amount: 250000, Status: domain.TransactionStatusSuccess, Description: "restaurant"},
+		{Timestamp: 1674507884, Counterparty: "JANE DOE", Type: domain.TransactionTypeCredit, Amount: 500000, Status: domain.TransactionStatusSuccess, Description: "salary"},
+		{Timestamp: 1674507885, Counterparty: "BOB SMITH", Type: domain.TransactionTypeDebit, Amount: 100000, Status: domain.TransactionStatusFailed, Description: "invalid transaction"},
+		{Timestamp: 1674507886, Counterparty: "John Doe", Type: domain.TransactionTypeDebit, Amount: 75000, Status: domain.TransactionStatusSuccess, Description: "Restaurant tip"},
+	}
+	for i, tx := range transactions {
+		err = store.AddTransaction(ctx, uploadID, tx, i+1)
+		require.NoError(t, err)
+	}
+
+	// No filters returns every row, SUCCESS included
+	rows, total, err := store.QueryTransactions(ctx, domain.TransactionQuery{UploadID: uploadID})
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+	assert.Len(t, rows, 4)
+
+	// Counterparty is case-insensitive, description is a substring match
+	rows, total, err = store.QueryTransactions(ctx, domain.TransactionQuery{
+		UploadID:     uploadID,
+		Counterparty: "john doe",
+		Description:  "restaurant",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, 1, rows[0].LineNumber)
+	assert.Equal(t, 4, rows[1].LineNumber)
+
+	minAmount := int64(80000)
+	to := int64(1674507885)
+	rows, total, err = store.QueryTransactions(ctx, domain.TransactionQuery{
+		UploadID:  uploadID,
+		Types:     []domain.TransactionType{domain.TransactionTypeDebit},
+		MinAmount: &minAmount,
+		To:        &to,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, "JOHN DOE", rows[0].Counterparty)
+	assert.Equal(t, "BOB SMITH", rows[1].Counterparty)
+
+	// Sort by amount descending, paginated
+	rows, total, err = store.QueryTransactions(ctx, domain.TransactionQuery{
+		UploadID:  uploadID,
+		SortBy:    domain.SortByAmount,
+		SortOrder: domain.SortOrderDesc,
+		Page:      1,
+		PerPage:   2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+	require.Len(t, rows, 2)
+	assert.Equal(t, int64(500000), rows[0].Amount)
+	assert.Equal(t, int64(250000), rows[1].Amount)
+
+	_, _, err = store.QueryTransactions(ctx, domain.TransactionQuery{UploadID: "nonexistent"})
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+}
+
+func TestMemoryStore_SeekTransactions(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	for i := 1; i <= 5; i++ {
+		status := domain.TransactionStatusFailed
+		if i == 3 {
+			status = domain.TransactionStatusSuccess
+		}
+		err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+			Timestamp: int64(1000 + i),
+			Status:    status,
+		}, i)
+		require.NoError(t, err)
+	}
+
+	query := domain.TransactionQuery{
+		UploadID: uploadID,
+		Statuses: []domain.TransactionStatus{domain.TransactionStatusFailed},
+		PerPage:  2,
+	}
+
+	// First page
+	rows, hasMore, err := store.SeekTransactions(ctx, query, nil)
+	require.NoError(t, err)
+	assert.True(t, hasMore)
+	require.Len(t, rows, 2)
+	assert.Equal(t, 1, rows[0].LineNumber)
+	assert.Equal(t, 2, rows[1].LineNumber)
+
+	// Seek past line 2, skipping the SUCCESS row
+	next := domain.NewCursor(domain.CursorDirectionNext, domain.SortByTimestamp, rows[1])
+	rows, hasMore, err = store.SeekTransactions(ctx, query, &next)
+	require.NoError(t, err)
+	assert.False(t, hasMore)
+	require.Len(t, rows, 2)
+	assert.Equal(t, 4, rows[0].LineNumber)
+	assert.Equal(t, 5, rows[1].LineNumber)
+
+	// Back again, rows keep ascending order
+	prev := domain.NewCursor(domain.CursorDirectionPrev, domain.SortByTimestamp, rows[0])
+	rows, hasMore, err = store.SeekTransactions(ctx, query, &prev)
+	require.NoError(t, err)
+	assert.False(t, hasMore)
+	require.Len(t, rows, 2)
+	assert.Equal(t, 1, rows[0].LineNumber)
+	assert.Equal(t, 2, rows[1].LineNumber)
+
+	// Descending order walks from the end
+	query.SortOrder = domain.SortOrderDesc
+	rows, hasMore, err = store.SeekTransactions(ctx, query, nil)
+	require.NoError(t, err)
+	assert.True(t, hasMore)
+	assert.Equal(t, 5, rows[0].LineNumber)
+	assert.Equal(t, 4, rows[1].LineNumber)
+
+	next = domain.NewCursor(domain.CursorDirectionNext, domain.SortByTimestamp, rows[1])
+	rows, hasMore, err = store.SeekTransactions(ctx, query, &next)
+	require.NoError(t, err)
+	assert.False(t, hasMore)
+	assert.Equal(t, 2, rows[0].LineNumber)
+	assert.Equal(t, 1, rows[1].LineNumber)
+
+	query.SortBy = domain.SortByAmount
+	_, _, err = store.SeekTransactions(ctx, query, nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+}
+
+func TestMemoryStore_RejectedRows(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	for _, line := range []int{2, 5, 7} {
+		err = store.AddRejectedRow(ctx, uploadID, domain.RejectedRow{
+			LineNumber: line,
+			Raw:        "bad,row",
+			Reason:     "invalid record format",
+		})
+		require.NoError(t, err)
+	}
+
+	rows, hasMore, err := store.GetRejectedRows(ctx, uploadID, 0, 2)
+	require.NoError(t, err)
+	assert.True(t, hasMore)
+	require.Len(t, rows, 2)
+	assert.Equal(t, 2, rows[0].LineNumber)
+	assert.Equal(t, 5, rows[1].LineNumber)
+
+	rows, hasMore, err = store.GetRejectedRows(ctx, uploadID, 5, 2)
+	require.NoError(t, err)
+	assert.False(t, hasMore)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 7, rows[0].LineNumber)
+
+	err = store.AddRejectedRow(ctx, "nonexistent", domain.RejectedRow{})
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+}
+
+func TestMemoryStore_SumByStatus(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+		Type:   domain.TransactionTypeCredit,
+		Amount: 500000,
+		Status: domain.TransactionStatusSuccess,
+	}, 1)
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+		Type:   domain.TransactionTypeDebit,
+		Amount: 250000,
+		Status: domain.TransactionStatusPending,
+	}, 2)
+	require.NoError(t, err)
+
+	err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+		Type:   domain.TransactionTypeCredit,
+		Amount: 300000,
+		Status: domain.TransactionStatusPending,
+	}, 3)
+	require.NoError(t, err)
+
+	totals, err := store.SumByStatus(ctx, uploadID, domain.TransactionStatusPending)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300000), totals.Credit)
+	assert.Equal(t, int64(250000), totals.Debit)
+
+	totals, err = store.SumByStatus(ctx, uploadID, domain.TransactionStatusFailed)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AmountTotals{}, totals)
+
+	_, err = store.SumByStatus(ctx, "nonexistent", domain.TransactionStatusSuccess)
+	assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+}
+
+func TestMemoryStore_CountByStatus(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	statuses := []domain.TransactionStatus{
+		domain.TransactionStatusSuccess,
+		domain.TransactionStatusSuccess,
+		domain.TransactionStatusPending,
+	}
+	for i, status := range statuses {
+		err = store.AddTransaction(ctx, uploadID, domain.Transaction{
+			Status: status,
+		}, i+1)
+		require.NoError(t, err)
+	}
+
+	counts, err := store.CountByStatus(ctx, uploadID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, counts[domain.TransactionStatusSuccess])
+	assert.Equal(t, 1, counts[domain.TransactionStatusPending])
+	assert.Equal(t, 0, counts[domain.TransactionStatusFailed])
+}
+
+func TestMemoryStore_AggregateByCounterparty(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	transactions := []domain.Transaction{
+		{Timestamp: 1000, Counterparty: "JOHN DOE", Type: domain.TransactionTypeDebit, Amount: 250000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 2000, Counterparty: " john  doe ", Type: domain.TransactionTypeDebit, Amount: 100000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 3000, Counterparty: "John Doe", Type: domain.TransactionTypeDebit, Amount: 50000, Status: domain.TransactionStatusFailed},
+		{Timestamp: 1500, Counterparty: "JANE DOE", Type: domain.TransactionTypeCredit, Amount: 500000, Status: domain.TransactionStatusSuccess},
+	}
+	for i, tx := range transactions {
+		err = store.AddTransaction(ctx, uploadID, tx, i+1)
+		require.NoError(t, err)
+	}
+
+	summaries, total, err := store.AggregateByCounterparty(ctx, domain.CounterpartyQuery{
+		UploadID:  uploadID,
+		SortBy:    domain.CounterpartySortByTotalDebit,
+		SortOrder: domain.SortOrderDesc,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, summaries, 2)
+
+	john := summaries[0]
+	assert.Equal(t, "JOHN DOE", john.Counterparty)
+	assert.Equal(t, int64(350000), john.TotalDebit)
+	assert.Equal(t, int64(0), john.TotalCredit)
+	assert.Equal(t, 3, john.TransactionCount)
+	assert.Equal(t, 2, john.StatusCounts[domain.TransactionStatusSuccess])
+	assert.Equal(t, 1, john.StatusCounts[domain.TransactionStatusFailed])
+	assert.Equal(t, int64(1000), john.FirstTransactionAt)
+	assert.Equal(t, int64(3000), john.LastTransactionAt)
+
+	assert.Equal(t, "JANE DOE", summaries[1].Counterparty)
+	assert.Equal(t, int64(500000), summaries[1].TotalCredit)
+
+	// Paginated, default sort by name
+	summaries, total, err = store.AggregateByCounterparty(ctx, domain.CounterpartyQuery{
+		UploadID: uploadID,
+		Page:     2,
+		PerPage:  1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "JOHN DOE", summaries[0].Counterparty)
+}
+
+func TestMemoryStore_SummarizeByPeriod(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	uploadID := "test-upload-1"
+	err := store.CreateUpload(ctx, uploadID)
+	require.NoError(t, err)
+
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	// 2023-01-23 16:30 UTC is still the 23rd in Jakarta, 17:30 UTC is the 24th
+	transactions := []domain.Transaction{
+		{Timestamp: time.Date(2023, 1, 23, 16, 30, 0, 0, time.UTC).Unix(), Type: domain.TransactionTypeCredit, Amount: 500000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: time.Date(2023, 1, 23, 17, 30, 0, 0, time.UTC).Unix(), Type: domain.TransactionTypeDebit, Amount: 200000, Status: domain.TransactionStatusSuccess},
+		{Timestamp: time.Date(2023, 1, 23, 18, 0, 0, 0, time.UTC).Unix(), Type: domain.TransactionTypeDebit, Amount: 100000, Status: domain.TransactionStatusFailed},
+		{Timestamp: time.Date(2023, 2, 1, 3, 0, 0, 0, time.UTC).Unix(), Type: domain.TransactionTypeCredit, Amount: 50000, Status: domain.TransactionStatusPending},
+	}
+	for i, tx := range transactions {
+		err = store.AddTransaction(ctx, uploadID, tx, i+1)
+		require.NoError(t, err)
+	}
+
+	summaries, err := store.SummarizeByPeriod(ctx, uploadID, domain.SummaryPeriodDay, jakarta)
+	require.NoError(t, err)
+	require.Len(t, summaries, 3)
+
+	assert.True(t, summaries[0].PeriodStart.Equal(time.Date(2023, 1, 23, 0, 0, 0, 0, jakarta)))
+	assert.Equal(t, int64(500000), summaries[0].Credits)
+	assert.Equal(t, int64(500000), summaries[0].ClosingBalance)
+
+	assert.True(t, summaries[1].PeriodStart.Equal(time.Date(2023, 1, 24, 0, 0, 0, 0, jakarta)))
+	assert.Equal(t, int64(200000), summaries[1].Debits)
+	assert.Equal(t, int64(-200000), summaries[1].Net)
+	assert.Equal(t, 1, summaries[1].FailedCount)
+	assert.Equal(t, int64(300000), summaries[1].ClosingBalance)
+
+	assert.Equal(t, 1, summaries[2].PendingCount)
+	assert.Equal(t, int64(300000), summaries[2].ClosingBalance)
+
+	// 2023-01-23 is a Monday, both January days share a week
+	summaries, err = store.SummarizeByPeriod(ctx, uploadID, domain.SummaryPeriodWeek, jakarta)
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	assert.True(t, summaries[0].PeriodStart.Equal(time.Date(2023, 1, 23, 0, 0, 0, 0, jakarta)))
+	assert.True(t, summaries[0].PeriodEnd.Equal(time.Date(2023, 1, 30, 0, 0, 0, 0, jakarta)))
+
+	summaries, err = store.SummarizeByPeriod(ctx, uploadID, domain.SummaryPeriodMonth, jakarta)
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, int64(300000), summaries[0].Net)
+	assert.True(t, summaries[1].PeriodStart.Equal(time.Date(2023, 2, 1, 0, 0, 0, 0, jakarta)))
+
+	_, err = store.SummarizeByPeriod(ctx, uploadID, domain.SummaryPeriod("year"), jakarta)
+	assert.ErrorIs(t, err, domain.ErrInvalidPeriod)
+}
+
 func TestMemoryStore_IsEventProcessed(t *testing.T) {
 	store := NewMemoryStore()
 	ctx := context.Background()
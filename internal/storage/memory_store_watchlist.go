@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+func (s *MemoryStore) CreateWatchlistEntry(ctx context.Context, entry domain.WatchlistEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.watchlist {
+		if existing.Name == entry.Name {
+			return domain.ErrDuplicateWatchlistEntry
+		}
+	}
+
+	s.watchlist[entry.ID] = &entry
+
+	return nil
+}
+
+func (s *MemoryStore) ListWatchlistEntries(ctx context.Context) ([]domain.WatchlistEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sortedWatchlist(), nil
+}
+
+func (s *MemoryStore) DeleteWatchlistEntry(ctx context.Context, entryID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.watchlist[entryID]; !exists {
+		return domain.ErrWatchlistEntryNotFound
+	}
+
+	delete(s.watchlist, entryID)
+
+	return nil
+}
+
+// GetWatchlist snapshots the entries and aliases for screening a file
+func (s *MemoryStore) GetWatchlist(ctx context.Context) (*domain.Watchlist, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return domain.NewWatchlist(s.sortedWatchlist(), s.aliasTable()), nil
+}
+
+func (s *MemoryStore) SetUploadHoldForReview(ctx context.Context, uploadID string, hold bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, exists := s.uploads[uploadID]
+	if !exists {
+		return domain.ErrUploadNotFound
+	}
+
+	upload.HoldForReview = hold
+
+	return nil
+}
+
+// ListScreeningResults returns an upload's watchlist hits by line number
+func (s *MemoryStore) ListScreeningResults(ctx context.Context, uploadID string, state *domain.ScreeningState) ([]domain.ScreeningResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.uploads[uploadID]; !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	results := []domain.ScreeningResult{}
+	for _, result := range s.screening[uploadID] {
+		if state == nil || result.State == *state {
+			results = append(results, copyScreeningResult(result))
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].LineNumber < results[j].LineNumber
+	})
+
+	return results, nil
+}
+
+// ClearScreeningResults clears the reviewed rows, all or none. A held upload
+// is released once no open hit is left.
+func (s *MemoryStore) ClearScreeningResults(ctx context.Context, uploadID string, review domain.ScreeningReview) ([]domain.ScreeningResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, exists := s.uploads[uploadID]
+	if !exists {
+		return nil, domain.ErrUploadNotFound
+	}
+
+	byLine := s.screening[uploadID]
+	var targets []*domain.ScreeningResult
+	if len(review.LineNumbers) == 0 {
+		for _, result := range byLine {
+			if result.State == domain.ScreeningStateOpen {
+				targets = append(targets, result)
+			}
+		}
+	} else {
+		for _, lineNumber := range review.LineNumbers {
+			result, exists := byLine[lineNumber]
+			if !exists {
+				return nil, domain.ErrScreeningNotFound
+			}
+			if result.State == domain.ScreeningStateOpen {
+				targets = append(targets, result)
+			}
+		}
+	}
+
+	now := time.Now()
+	cleared := make([]domain.ScreeningResult, 0, len(targets))
+	for _, result := range targets {
+		result.State = domain.ScreeningStateCleared
+		result.ClearedBy = review.Reviewer
+		result.Note = review.Note
+		result.ClearedAt = &now
+		cleared = append(cleared, copyScreeningResult(result))
+	}
+
+	sort.Slice(cleared, func(i, j int) bool {
+		return cleared[i].LineNumber < cleared[j].LineNumber
+	})
+
+	if upload.Status == domain.UploadStatusNeedsReview && s.openScreeningCount(uploadID) == 0 {
+		upload.Status = domain.UploadStatusCompleted
+	}
+
+	return cleared, nil
+}
+
+// recordScreening opens a screening result for a stored row that hit the
+// watchlist. Callers must hold the lock.
+func (s *MemoryStore) recordScreening(uploadID string, lineNumber int, tx domain.Transaction) {
+	if len(tx.WatchlistHits) == 0 {
+		return
+	}
+
+	byLine := s.screening[uploadID]
+	if byLine == nil {
+		byLine = make(map[int]*domain.ScreeningResult)
+		s.screening[uploadID] = byLine
+	}
+
+	byLine[lineNumber] = &domain.ScreeningResult{
+		UploadID:     uploadID,
+		LineNumber:   lineNumber,
+		Counterparty: tx.Counterparty,
+		Hits:         append([]domain.WatchlistHit(nil), tx.WatchlistHits...),
+		State:        domain.ScreeningStateOpen,
+	}
+}
+
+// openScreeningCount counts the upload's uncleared hits. Callers must hold
+// the read lock.
+func (s *MemoryStore) openScreeningCount(uploadID string) int {
+	count := 0
+	for _, result := range s.screening[uploadID] {
+		if result.State == domain.ScreeningStateOpen {
+			count++
+		}
+	}
+	return count
+}
+
+// sortedWatchlist returns the entries by name. Callers must hold the read lock.
+func (s *MemoryStore) sortedWatchlist() []domain.WatchlistEntry {
+	entries := make([]domain.WatchlistEntry, 0, len(s.watchlist))
+	for _, entry := range s.watchlist {
+		entries = append(entries, *entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries
+}
+
+func copyScreeningResult(result *domain.ScreeningResult) domain.ScreeningResult {
+	copied := *result
+	copied.Hits = append([]domain.WatchlistHit(nil), result.Hits...)
+	return copied
+}
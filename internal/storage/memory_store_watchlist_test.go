@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_WatchlistScreening(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	err := store.CreateWatchlistEntry(ctx, domain.WatchlistEntry{ID: "entry-1", Name: "ACME HOLDINGS"})
+	require.NoError(t, err)
+
+	err = store.CreateWatchlistEntry(ctx, domain.WatchlistEntry{ID: "entry-2", Name: "ACME HOLDINGS"})
+	assert.ErrorIs(t, err, domain.ErrDuplicateWatchlistEntry)
+
+	watchlist, err := store.GetWatchlist(ctx)
+	require.NoError(t, err)
+
+	hits := watchlist.Screen("PT Acme Holdings")
+	require.Len(t, hits, 1)
+	assert.Equal(t, domain.WatchlistMatchExact, hits[0].Match)
+
+	hits = watchlist.Screen("ACME HOLDING")
+	require.Len(t, hits, 1)
+	assert.Equal(t, domain.WatchlistMatchFuzzy, hits[0].Match)
+
+	assert.Empty(t, watchlist.Screen("JOHN DOE"))
+
+	// A held upload waits for review once reconciled with open hits
+	err = store.CreateUpload(ctx, "upload-1")
+	require.NoError(t, err)
+	err = store.SetUploadHoldForReview(ctx, "upload-1", true)
+	require.NoError(t, err)
+
+	rows := []domain.Transaction{
+		{Timestamp: 1000, Counterparty: "PT Acme Holdings", Type: domain.TransactionTypeDebit, Amount: 100, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 2000, Counterparty: "JOHN DOE", Type: domain.TransactionTypeCredit, Amount: 100, Status: domain.TransactionStatusSuccess},
+		{Timestamp: 3000, Counterparty: "ACME HOLDING", Type: domain.TransactionTypeDebit, Amount: 100, Status: domain.TransactionStatusSuccess},
+	}
+	for i, tx := range rows {
+		tx.WatchlistHits = watchlist.Screen(tx.Counterparty)
+		err = store.AddTransaction(ctx, "upload-1", tx, i+1)
+		require.NoError(t, err)
+		err = store.IncrementProcessedRows(ctx, "upload-1")
+		require.NoError(t, err)
+	}
+	err = store.SetUploadTotalRows(ctx, "upload-1", len(rows))
+	require.NoError(t, err)
+	err = store.UpdateUploadStatus(ctx, "upload-1", domain.UploadStatusCompleted)
+	require.NoError(t, err)
+
+	reconciled, err := store.MarkUploadReconciled(ctx, "upload-1")
+	require.NoError(t, err)
+	assert.True(t, reconciled)
+
+	upload, err := store.GetUpload(ctx, "upload-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.UploadStatusNeedsReview, upload.Status)
+
+	open := domain.ScreeningStateOpen
+	results, err := store.ListScreeningResults(ctx, "upload-1", &open)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, []int{1, 3}, []int{results[0].LineNumber, results[1].LineNumber})
+
+	_, err = store.ClearScreeningResults(ctx, "upload-1", domain.ScreeningReview{Reviewer: "alice", LineNumbers: []int{2}})
+	assert.ErrorIs(t, err, domain.ErrScreeningNotFound)
+
+	cleared, err := store.ClearScreeningResults(ctx, "upload-1", domain.ScreeningReview{Reviewer: "alice", LineNumbers: []int{1}})
+	require.NoError(t, err)
+	require.Len(t, cleared, 1)
+	assert.Equal(t, "alice", cleared[0].ClearedBy)
+	assert.Equal(t, domain.UploadStatusNeedsReview, upload.Status)
+
+	// Clearing the rest releases the upload
+	cleared, err = store.ClearScreeningResults(ctx, "upload-1", domain.ScreeningReview{Reviewer: "bob", Note: "different entity"})
+	require.NoError(t, err)
+	require.Len(t, cleared, 1)
+	assert.Equal(t, 3, cleared[0].LineNumber)
+
+	upload, err = store.GetUpload(ctx, "upload-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.UploadStatusCompleted, upload.Status)
+
+	err = store.DeleteWatchlistEntry(ctx, "entry-1")
+	require.NoError(t, err)
+	err = store.DeleteWatchlistEntry(ctx, "entry-1")
+	assert.ErrorIs(t, err, domain.ErrWatchlistEntryNotFound)
+}
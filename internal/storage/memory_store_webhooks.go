@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+)
+
+func (s *MemoryStore) CreateWebhook(ctx context.Context, webhook domain.WebhookSubscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook.EventTypes = append([]domain.WebhookEventType(nil), webhook.EventTypes...)
+	s.webhooks[webhook.ID] = &webhook
+
+	return nil
+}
+
+func (s *MemoryStore) GetWebhook(ctx context.Context, webhookID string) (*domain.WebhookSubscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhook, exists := s.webhooks[webhookID]
+	if !exists {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	result := *webhook
+	result.EventTypes = append([]domain.WebhookEventType(nil), webhook.EventTypes...)
+	return &result, nil
+}
+
+// ListWebhooks returns the subscriptions in creation order
+func (s *MemoryStore) ListWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhooks := make([]domain.WebhookSubscription, 0, len(s.webhooks))
+	for _, webhook := range s.webhooks {
+		result := *webhook
+		result.EventTypes = append([]domain.WebhookEventType(nil), webhook.EventTypes...)
+		webhooks = append(webhooks, result)
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+		}
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	return webhooks, nil
+}
+
+// DeleteWebhook stops future deliveries, the log of past ones is kept
+func (s *MemoryStore) DeleteWebhook(ctx context.Context, webhookID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.webhooks[webhookID]; !exists {
+		return domain.ErrWebhookNotFound
+	}
+
+	delete(s.webhooks, webhookID)
+
+	return nil
+}
+
+// SaveWebhookDelivery inserts a delivery or replaces it after another attempt
+func (s *MemoryStore) SaveWebhookDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deliveries[delivery.ID] = delivery
+
+	return nil
+}
+
+// ListWebhookDeliveries returns the matching deliveries, oldest first
+func (s *MemoryStore) ListWebhookDeliveries(ctx context.Context, query domain.WebhookDeliveryQuery) ([]domain.WebhookDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if query.SubscriptionID != "" {
+		if _, exists := s.webhooks[query.SubscriptionID]; !exists && !s.hasDeliveries(query.SubscriptionID) {
+			return nil, domain.ErrWebhookNotFound
+		}
+	}
+
+	deliveries := []domain.WebhookDelivery{}
+	for _, delivery := range s.deliveries {
+		if query.Matches(delivery) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+		}
+		return deliveries[i].ID < deliveries[j].ID
+	})
+
+	return deliveries, nil
+}
+
+// hasDeliveries reports whether a subscription, possibly deleted, was ever
+// delivered to. Callers must hold the read lock.
+func (s *MemoryStore) hasDeliveries(webhookID string) bool {
+	for _, delivery := range s.deliveries {
+		if delivery.SubscriptionID == webhookID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grachmannico95/flip-test-be/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_WebhookDeliveries(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	for i, id := range []string{"webhook-1", "webhook-2"} {
+		err := store.CreateWebhook(ctx, domain.WebhookSubscription{
+			ID:         id,
+			URL:        "http://localhost/hook",
+			Secret:     "secret",
+			EventTypes: []domain.WebhookEventType{domain.WebhookEventUploadCompleted},
+			CreatedAt:  now.Add(time.Duration(i) * time.Second),
+		})
+		require.NoError(t, err)
+	}
+
+	webhooks, err := store.ListWebhooks(ctx)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 2)
+	assert.Equal(t, "webhook-1", webhooks[0].ID)
+	assert.Equal(t, "secret", webhooks[0].Secret)
+
+	deliveries := []domain.WebhookDelivery{
+		{ID: "delivery-1", SubscriptionID: "webhook-1", EventType: domain.WebhookEventUploadCompleted, Status: domain.WebhookDeliveryPending, CreatedAt: now},
+		{ID: "delivery-2", SubscriptionID: "webhook-1", EventType: domain.WebhookEventUploadFailed, Status: domain.WebhookDeliveryFailed, CreatedAt: now.Add(time.Second)},
+		{ID: "delivery-3", SubscriptionID: "webhook-2", EventType: domain.WebhookEventUploadCompleted, Status: domain.WebhookDeliveryDelivered, CreatedAt: now},
+	}
+	for _, delivery := range deliveries {
+		err = store.SaveWebhookDelivery(ctx, delivery)
+		require.NoError(t, err)
+	}
+
+	// A later attempt replaces the stored delivery
+	deliveries[0].Status = domain.WebhookDeliveryDelivered
+	deliveries[0].Attempts = 2
+	err = store.SaveWebhookDelivery(ctx, deliveries[0])
+	require.NoError(t, err)
+
+	result, err := store.ListWebhookDeliveries(ctx, domain.WebhookDeliveryQuery{SubscriptionID: "webhook-1"})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "delivery-1", result[0].ID)
+	assert.Equal(t, 2, result[0].Attempts)
+
+	result, err = store.ListWebhookDeliveries(ctx, domain.WebhookDeliveryQuery{Status: domain.WebhookDeliveryDelivered})
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	// The log of a deleted subscription stays queryable
+	err = store.DeleteWebhook(ctx, "webhook-1")
+	require.NoError(t, err)
+	err = store.DeleteWebhook(ctx, "webhook-1")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+
+	result, err = store.ListWebhookDeliveries(ctx, domain.WebhookDeliveryQuery{SubscriptionID: "webhook-1", EventType: domain.WebhookEventUploadFailed})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "delivery-2", result[0].ID)
+
+	_, err = store.ListWebhookDeliveries(ctx, domain.WebhookDeliveryQuery{SubscriptionID: "nonexistent"})
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/grachmannico95/flip-test-be/internal/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockRepository is an autogenerated mock type for the Repository type
@@ -22,17 +24,17 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
-// AddTransaction provides a mock function with given fields: ctx, uploadID, tx, lineNumber
-func (_m *MockRepository) AddTransaction(ctx context.Context, uploadID string, tx domain.Transaction, lineNumber int) error {
-	ret := _m.Called(ctx, uploadID, tx, lineNumber)
+// AddRejectedRow provides a mock function with given fields: ctx, uploadID, row
+func (_m *MockRepository) AddRejectedRow(ctx context.Context, uploadID string, row domain.RejectedRow) error {
+	ret := _m.Called(ctx, uploadID, row)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddTransaction")
+		panic("no return value specified for AddRejectedRow")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Transaction, int) error); ok {
-		r0 = rf(ctx, uploadID, tx, lineNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.RejectedRow) error); ok {
+		r0 = rf(ctx, uploadID, row)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -40,48 +42,47 @@ func (_m *MockRepository) AddTransaction(ctx context.Context, uploadID string, t
 	return r0
 }
 
-// MockRepository_AddTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTransaction'
-type MockRepository_AddTransaction_Call struct {
+// MockRepository_AddRejectedRow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRejectedRow'
+type MockRepository_AddRejectedRow_Call struct {
 	*mock.Call
 }
 
-// AddTransaction is a helper method to define mock.On call
+// AddRejectedRow is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uploadID string
-//   - tx domain.Transaction
-//   - lineNumber int
-func (_e *MockRepository_Expecter) AddTransaction(ctx interface{}, uploadID interface{}, tx interface{}, lineNumber interface{}) *MockRepository_AddTransaction_Call {
-	return &MockRepository_AddTransaction_Call{Call: _e.mock.On("AddTransaction", ctx, uploadID, tx, lineNumber)}
+//   - row domain.RejectedRow
+func (_e *MockRepository_Expecter) AddRejectedRow(ctx interface{}, uploadID interface{}, row interface{}) *MockRepository_AddRejectedRow_Call {
+	return &MockRepository_AddRejectedRow_Call{Call: _e.mock.On("AddRejectedRow", ctx, uploadID, row)}
 }
 
-func (_c *MockRepository_AddTransaction_Call) Run(run func(ctx context.Context, uploadID string, tx domain.Transaction, lineNumber int)) *MockRepository_AddTransaction_Call {
+func (_c *MockRepository_AddRejectedRow_Call) Run(run func(ctx context.Context, uploadID string, row domain.RejectedRow)) *MockRepository_AddRejectedRow_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(domain.Transaction), args[3].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.RejectedRow))
 	})
 	return _c
 }
 
-func (_c *MockRepository_AddTransaction_Call) Return(_a0 error) *MockRepository_AddTransaction_Call {
+func (_c *MockRepository_AddRejectedRow_Call) Return(_a0 error) *MockRepository_AddRejectedRow_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockRepository_AddTransaction_Call) RunAndReturn(run func(context.Context, string, domain.Transaction, int) error) *MockRepository_AddTransaction_Call {
+func (_c *MockRepository_AddRejectedRow_Call) RunAndReturn(run func(context.Context, string, domain.RejectedRow) error) *MockRepository_AddRejectedRow_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUpload provides a mock function with given fields: ctx, uploadID
-func (_m *MockRepository) CreateUpload(ctx context.Context, uploadID string) error {
-	ret := _m.Called(ctx, uploadID)
+// AddTransaction provides a mock function with given fields: ctx, uploadID, tx, lineNumber
+func (_m *MockRepository) AddTransaction(ctx context.Context, uploadID string, tx domain.Transaction, lineNumber int) error {
+	ret := _m.Called(ctx, uploadID, tx, lineNumber)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUpload")
+		panic("no return value specified for AddTransaction")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, uploadID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Transaction, int) error); ok {
+		r0 = rf(ctx, uploadID, tx, lineNumber)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -89,52 +90,56 @@ func (_m *MockRepository) CreateUpload(ctx context.Context, uploadID string) err
 	return r0
 }
 
-// MockRepository_CreateUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUpload'
-type MockRepository_CreateUpload_Call struct {
+// MockRepository_AddTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTransaction'
+type MockRepository_AddTransaction_Call struct {
 	*mock.Call
 }
 
-// CreateUpload is a helper method to define mock.On call
+// AddTransaction is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uploadID string
-func (_e *MockRepository_Expecter) CreateUpload(ctx interface{}, uploadID interface{}) *MockRepository_CreateUpload_Call {
-	return &MockRepository_CreateUpload_Call{Call: _e.mock.On("CreateUpload", ctx, uploadID)}
+//   - tx domain.Transaction
+//   - lineNumber int
+func (_e *MockRepository_Expecter) AddTransaction(ctx interface{}, uploadID interface{}, tx interface{}, lineNumber interface{}) *MockRepository_AddTransaction_Call {
+	return &MockRepository_AddTransaction_Call{Call: _e.mock.On("AddTransaction", ctx, uploadID, tx, lineNumber)}
 }
 
-func (_c *MockRepository_CreateUpload_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_CreateUpload_Call {
+func (_c *MockRepository_AddTransaction_Call) Run(run func(ctx context.Context, uploadID string, tx domain.Transaction, lineNumber int)) *MockRepository_AddTransaction_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.Transaction), args[3].(int))
 	})
 	return _c
 }
 
-func (_c *MockRepository_CreateUpload_Call) Return(_a0 error) *MockRepository_CreateUpload_Call {
+func (_c *MockRepository_AddTransaction_Call) Return(_a0 error) *MockRepository_AddTransaction_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockRepository_CreateUpload_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_CreateUpload_Call {
+func (_c *MockRepository_AddTransaction_Call) RunAndReturn(run func(context.Context, string, domain.Transaction, int) error) *MockRepository_AddTransaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBalance provides a mock function with given fields: ctx, uploadID
-func (_m *MockRepository) GetBalance(ctx context.Context, uploadID string) (int64, error) {
+// AggregateByCategory provides a mock function with given fields: ctx, uploadID
+func (_m *MockRepository) AggregateByCategory(ctx context.Context, uploadID string) ([]domain.CategorySummary, error) {
 	ret := _m.Called(ctx, uploadID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBalance")
+		panic("no return value specified for AggregateByCategory")
 	}
 
-	var r0 int64
+	var r0 []domain.CategorySummary
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.CategorySummary, error)); ok {
 		return rf(ctx, uploadID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.CategorySummary); ok {
 		r0 = rf(ctx, uploadID)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CategorySummary)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -146,65 +151,65 @@ func (_m *MockRepository) GetBalance(ctx context.Context, uploadID string) (int6
 	return r0, r1
 }
 
-// MockRepository_GetBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalance'
-type MockRepository_GetBalance_Call struct {
+// MockRepository_AggregateByCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AggregateByCategory'
+type MockRepository_AggregateByCategory_Call struct {
 	*mock.Call
 }
 
-// GetBalance is a helper method to define mock.On call
+// AggregateByCategory is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uploadID string
-func (_e *MockRepository_Expecter) GetBalance(ctx interface{}, uploadID interface{}) *MockRepository_GetBalance_Call {
-	return &MockRepository_GetBalance_Call{Call: _e.mock.On("GetBalance", ctx, uploadID)}
+func (_e *MockRepository_Expecter) AggregateByCategory(ctx interface{}, uploadID interface{}) *MockRepository_AggregateByCategory_Call {
+	return &MockRepository_AggregateByCategory_Call{Call: _e.mock.On("AggregateByCategory", ctx, uploadID)}
 }
 
-func (_c *MockRepository_GetBalance_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_GetBalance_Call {
+func (_c *MockRepository_AggregateByCategory_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_AggregateByCategory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockRepository_GetBalance_Call) Return(_a0 int64, _a1 error) *MockRepository_GetBalance_Call {
+func (_c *MockRepository_AggregateByCategory_Call) Return(_a0 []domain.CategorySummary, _a1 error) *MockRepository_AggregateByCategory_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepository_GetBalance_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockRepository_GetBalance_Call {
+func (_c *MockRepository_AggregateByCategory_Call) RunAndReturn(run func(context.Context, string) ([]domain.CategorySummary, error)) *MockRepository_AggregateByCategory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetIssues provides a mock function with given fields: ctx, uploadID, page, perPage, status
-func (_m *MockRepository) GetIssues(ctx context.Context, uploadID string, page int, perPage int, status *domain.TransactionStatus) ([]domain.IssueTransaction, int, error) {
-	ret := _m.Called(ctx, uploadID, page, perPage, status)
+// AggregateByCounterparty provides a mock function with given fields: ctx, query
+func (_m *MockRepository) AggregateByCounterparty(ctx context.Context, query domain.CounterpartyQuery) ([]domain.CounterpartySummary, int, error) {
+	ret := _m.Called(ctx, query)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetIssues")
+		panic("no return value specified for AggregateByCounterparty")
 	}
 
-	var r0 []domain.IssueTransaction
+	var r0 []domain.CounterpartySummary
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, *domain.TransactionStatus) ([]domain.IssueTransaction, int, error)); ok {
-		return rf(ctx, uploadID, page, perPage, status)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CounterpartyQuery) ([]domain.CounterpartySummary, int, error)); ok {
+		return rf(ctx, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, *domain.TransactionStatus) []domain.IssueTransaction); ok {
-		r0 = rf(ctx, uploadID, page, perPage, status)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CounterpartyQuery) []domain.CounterpartySummary); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.IssueTransaction)
+			r0 = ret.Get(0).([]domain.CounterpartySummary)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, int, *domain.TransactionStatus) int); ok {
-		r1 = rf(ctx, uploadID, page, perPage, status)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CounterpartyQuery) int); ok {
+		r1 = rf(ctx, query)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, int, int, *domain.TransactionStatus) error); ok {
-		r2 = rf(ctx, uploadID, page, perPage, status)
+	if rf, ok := ret.Get(2).(func(context.Context, domain.CounterpartyQuery) error); ok {
+		r2 = rf(ctx, query)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -212,61 +217,56 @@ func (_m *MockRepository) GetIssues(ctx context.Context, uploadID string, page i
 	return r0, r1, r2
 }
 
-// MockRepository_GetIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIssues'
-type MockRepository_GetIssues_Call struct {
+// MockRepository_AggregateByCounterparty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AggregateByCounterparty'
+type MockRepository_AggregateByCounterparty_Call struct {
 	*mock.Call
 }
 
-// GetIssues is a helper method to define mock.On call
+// AggregateByCounterparty is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uploadID string
-//   - page int
-//   - perPage int
-//   - status *domain.TransactionStatus
-func (_e *MockRepository_Expecter) GetIssues(ctx interface{}, uploadID interface{}, page interface{}, perPage interface{}, status interface{}) *MockRepository_GetIssues_Call {
-	return &MockRepository_GetIssues_Call{Call: _e.mock.On("GetIssues", ctx, uploadID, page, perPage, status)}
+//   - query domain.CounterpartyQuery
+func (_e *MockRepository_Expecter) AggregateByCounterparty(ctx interface{}, query interface{}) *MockRepository_AggregateByCounterparty_Call {
+	return &MockRepository_AggregateByCounterparty_Call{Call: _e.mock.On("AggregateByCounterparty", ctx, query)}
 }
 
-func (_c *MockRepository_GetIssues_Call) Run(run func(ctx context.Context, uploadID string, page int, perPage int, status *domain.TransactionStatus)) *MockRepository_GetIssues_Call {
+func (_c *MockRepository_AggregateByCounterparty_Call) Run(run func(ctx context.Context, query domain.CounterpartyQuery)) *MockRepository_AggregateByCounterparty_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int), args[4].(*domain.TransactionStatus))
+		run(args[0].(context.Context), args[1].(domain.CounterpartyQuery))
 	})
 	return _c
 }
 
-func (_c *MockRepository_GetIssues_Call) Return(_a0 []domain.IssueTransaction, _a1 int, _a2 error) *MockRepository_GetIssues_Call {
+func (_c *MockRepository_AggregateByCounterparty_Call) Return(_a0 []domain.CounterpartySummary, _a1 int, _a2 error) *MockRepository_AggregateByCounterparty_Call {
 	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockRepository_GetIssues_Call) RunAndReturn(run func(context.Context, string, int, int, *domain.TransactionStatus) ([]domain.IssueTransaction, int, error)) *MockRepository_GetIssues_Call {
+func (_c *MockRepository_AggregateByCounterparty_Call) RunAndReturn(run func(context.Context, domain.CounterpartyQuery) ([]domain.CounterpartySummary, int, error)) *MockRepository_AggregateByCounterparty_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUpload provides a mock function with given fields: ctx, uploadID
-func (_m *MockRepository) GetUpload(ctx context.Context, uploadID string) (*domain.Upload, error) {
-	ret := _m.Called(ctx, uploadID)
+// ApplyRuleSet provides a mock function with given fields: ctx, uploadID, ruleSet
+func (_m *MockRepository) ApplyRuleSet(ctx context.Context, uploadID string, ruleSet *domain.RuleSet) (int, error) {
+	ret := _m.Called(ctx, uploadID, ruleSet)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUpload")
+		panic("no return value specified for ApplyRuleSet")
 	}
 
-	var r0 *domain.Upload
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Upload, error)); ok {
-		return rf(ctx, uploadID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.RuleSet) (int, error)); ok {
+		return rf(ctx, uploadID, ruleSet)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Upload); ok {
-		r0 = rf(ctx, uploadID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.RuleSet) int); ok {
+		r0 = rf(ctx, uploadID, ruleSet)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Upload)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, uploadID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.RuleSet) error); ok {
+		r1 = rf(ctx, uploadID, ruleSet)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -274,46 +274,47 @@ func (_m *MockRepository) GetUpload(ctx context.Context, uploadID string) (*doma
 	return r0, r1
 }
 
-// MockRepository_GetUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUpload'
-type MockRepository_GetUpload_Call struct {
+// MockRepository_ApplyRuleSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyRuleSet'
+type MockRepository_ApplyRuleSet_Call struct {
 	*mock.Call
 }
 
-// GetUpload is a helper method to define mock.On call
+// ApplyRuleSet is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uploadID string
-func (_e *MockRepository_Expecter) GetUpload(ctx interface{}, uploadID interface{}) *MockRepository_GetUpload_Call {
-	return &MockRepository_GetUpload_Call{Call: _e.mock.On("GetUpload", ctx, uploadID)}
+//   - ruleSet *domain.RuleSet
+func (_e *MockRepository_Expecter) ApplyRuleSet(ctx interface{}, uploadID interface{}, ruleSet interface{}) *MockRepository_ApplyRuleSet_Call {
+	return &MockRepository_ApplyRuleSet_Call{Call: _e.mock.On("ApplyRuleSet", ctx, uploadID, ruleSet)}
 }
 
-func (_c *MockRepository_GetUpload_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_GetUpload_Call {
+func (_c *MockRepository_ApplyRuleSet_Call) Run(run func(ctx context.Context, uploadID string, ruleSet *domain.RuleSet)) *MockRepository_ApplyRuleSet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(*domain.RuleSet))
 	})
 	return _c
 }
 
-func (_c *MockRepository_GetUpload_Call) Return(_a0 *domain.Upload, _a1 error) *MockRepository_GetUpload_Call {
+func (_c *MockRepository_ApplyRuleSet_Call) Return(_a0 int, _a1 error) *MockRepository_ApplyRuleSet_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepository_GetUpload_Call) RunAndReturn(run func(context.Context, string) (*domain.Upload, error)) *MockRepository_GetUpload_Call {
+func (_c *MockRepository_ApplyRuleSet_Call) RunAndReturn(run func(context.Context, string, *domain.RuleSet) (int, error)) *MockRepository_ApplyRuleSet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IncrementProcessedRows provides a mock function with given fields: ctx, uploadID
-func (_m *MockRepository) IncrementProcessedRows(ctx context.Context, uploadID string) error {
-	ret := _m.Called(ctx, uploadID)
+// AttachUpload provides a mock function with given fields: ctx, uploadID, accountID
+func (_m *MockRepository) AttachUpload(ctx context.Context, uploadID string, accountID string) error {
+	ret := _m.Called(ctx, uploadID, accountID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IncrementProcessedRows")
+		panic("no return value specified for AttachUpload")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, uploadID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, uploadID, accountID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -321,56 +322,119 @@ func (_m *MockRepository) IncrementProcessedRows(ctx context.Context, uploadID s
 	return r0
 }
 
-// MockRepository_IncrementProcessedRows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementProcessedRows'
-type MockRepository_IncrementProcessedRows_Call struct {
+// MockRepository_AttachUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AttachUpload'
+type MockRepository_AttachUpload_Call struct {
 	*mock.Call
 }
 
-// IncrementProcessedRows is a helper method to define mock.On call
+// AttachUpload is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uploadID string
-func (_e *MockRepository_Expecter) IncrementProcessedRows(ctx interface{}, uploadID interface{}) *MockRepository_IncrementProcessedRows_Call {
-	return &MockRepository_IncrementProcessedRows_Call{Call: _e.mock.On("IncrementProcessedRows", ctx, uploadID)}
+//   - accountID string
+func (_e *MockRepository_Expecter) AttachUpload(ctx interface{}, uploadID interface{}, accountID interface{}) *MockRepository_AttachUpload_Call {
+	return &MockRepository_AttachUpload_Call{Call: _e.mock.On("AttachUpload", ctx, uploadID, accountID)}
 }
 
-func (_c *MockRepository_IncrementProcessedRows_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_IncrementProcessedRows_Call {
+func (_c *MockRepository_AttachUpload_Call) Run(run func(ctx context.Context, uploadID string, accountID string)) *MockRepository_AttachUpload_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockRepository_IncrementProcessedRows_Call) Return(_a0 error) *MockRepository_IncrementProcessedRows_Call {
+func (_c *MockRepository_AttachUpload_Call) Return(_a0 error) *MockRepository_AttachUpload_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockRepository_IncrementProcessedRows_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_IncrementProcessedRows_Call {
+func (_c *MockRepository_AttachUpload_Call) RunAndReturn(run func(context.Context, string, string) error) *MockRepository_AttachUpload_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsEventProcessed provides a mock function with given fields: ctx, eventID
-func (_m *MockRepository) IsEventProcessed(ctx context.Context, eventID string) (bool, error) {
-	ret := _m.Called(ctx, eventID)
+// ClearScreeningResults provides a mock function with given fields: ctx, uploadID, review
+func (_m *MockRepository) ClearScreeningResults(ctx context.Context, uploadID string, review domain.ScreeningReview) ([]domain.ScreeningResult, error) {
+	ret := _m.Called(ctx, uploadID, review)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsEventProcessed")
+		panic("no return value specified for ClearScreeningResults")
 	}
 
-	var r0 bool
+	var r0 []domain.ScreeningResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
-		return rf(ctx, eventID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.ScreeningReview) ([]domain.ScreeningResult, error)); ok {
+		return rf(ctx, uploadID, review)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = rf(ctx, eventID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.ScreeningReview) []domain.ScreeningResult); ok {
+		r0 = rf(ctx, uploadID, review)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ScreeningResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.ScreeningReview) error); ok {
+		r1 = rf(ctx, uploadID, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ClearScreeningResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearScreeningResults'
+type MockRepository_ClearScreeningResults_Call struct {
+	*mock.Call
+}
+
+// ClearScreeningResults is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - review domain.ScreeningReview
+func (_e *MockRepository_Expecter) ClearScreeningResults(ctx interface{}, uploadID interface{}, review interface{}) *MockRepository_ClearScreeningResults_Call {
+	return &MockRepository_ClearScreeningResults_Call{Call: _e.mock.On("ClearScreeningResults", ctx, uploadID, review)}
+}
+
+func (_c *MockRepository_ClearScreeningResults_Call) Run(run func(ctx context.Context, uploadID string, review domain.ScreeningReview)) *MockRepository_ClearScreeningResults_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.ScreeningReview))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ClearScreeningResults_Call) Return(_a0 []domain.ScreeningResult, _a1 error) *MockRepository_ClearScreeningResults_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ClearScreeningResults_Call) RunAndReturn(run func(context.Context, string, domain.ScreeningReview) ([]domain.ScreeningResult, error)) *MockRepository_ClearScreeningResults_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByStatus provides a mock function with given fields: ctx, uploadID
+func (_m *MockRepository) CountByStatus(ctx context.Context, uploadID string) (map[domain.TransactionStatus]int, error) {
+	ret := _m.Called(ctx, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByStatus")
+	}
+
+	var r0 map[domain.TransactionStatus]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[domain.TransactionStatus]int, error)); ok {
+		return rf(ctx, uploadID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[domain.TransactionStatus]int); ok {
+		r0 = rf(ctx, uploadID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[domain.TransactionStatus]int)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, eventID)
+		r1 = rf(ctx, uploadID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -378,46 +442,46 @@ func (_m *MockRepository) IsEventProcessed(ctx context.Context, eventID string)
 	return r0, r1
 }
 
-// MockRepository_IsEventProcessed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsEventProcessed'
-type MockRepository_IsEventProcessed_Call struct {
+// MockRepository_CountByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByStatus'
+type MockRepository_CountByStatus_Call struct {
 	*mock.Call
 }
 
-// IsEventProcessed is a helper method to define mock.On call
+// CountByStatus is a helper method to define mock.On call
 //   - ctx context.Context
-//   - eventID string
-func (_e *MockRepository_Expecter) IsEventProcessed(ctx interface{}, eventID interface{}) *MockRepository_IsEventProcessed_Call {
-	return &MockRepository_IsEventProcessed_Call{Call: _e.mock.On("IsEventProcessed", ctx, eventID)}
+//   - uploadID string
+func (_e *MockRepository_Expecter) CountByStatus(ctx interface{}, uploadID interface{}) *MockRepository_CountByStatus_Call {
+	return &MockRepository_CountByStatus_Call{Call: _e.mock.On("CountByStatus", ctx, uploadID)}
 }
 
-func (_c *MockRepository_IsEventProcessed_Call) Run(run func(ctx context.Context, eventID string)) *MockRepository_IsEventProcessed_Call {
+func (_c *MockRepository_CountByStatus_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_CountByStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockRepository_IsEventProcessed_Call) Return(_a0 bool, _a1 error) *MockRepository_IsEventProcessed_Call {
+func (_c *MockRepository_CountByStatus_Call) Return(_a0 map[domain.TransactionStatus]int, _a1 error) *MockRepository_CountByStatus_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepository_IsEventProcessed_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockRepository_IsEventProcessed_Call {
+func (_c *MockRepository_CountByStatus_Call) RunAndReturn(run func(context.Context, string) (map[domain.TransactionStatus]int, error)) *MockRepository_CountByStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MarkEventProcessed provides a mock function with given fields: ctx, eventID
-func (_m *MockRepository) MarkEventProcessed(ctx context.Context, eventID string) error {
-	ret := _m.Called(ctx, eventID)
+// CreateAccount provides a mock function with given fields: ctx, account
+func (_m *MockRepository) CreateAccount(ctx context.Context, account domain.Account) error {
+	ret := _m.Called(ctx, account)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MarkEventProcessed")
+		panic("no return value specified for CreateAccount")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, eventID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Account) error); ok {
+		r0 = rf(ctx, account)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -425,31 +489,4543 @@ func (_m *MockRepository) MarkEventProcessed(ctx context.Context, eventID string
 	return r0
 }
 
-// MockRepository_MarkEventProcessed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkEventProcessed'
-type MockRepository_MarkEventProcessed_Call struct {
+// MockRepository_CreateAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccount'
+type MockRepository_CreateAccount_Call struct {
 	*mock.Call
 }
 
-// MarkEventProcessed is a helper method to define mock.On call
+// CreateAccount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - eventID string
-func (_e *MockRepository_Expecter) MarkEventProcessed(ctx interface{}, eventID interface{}) *MockRepository_MarkEventProcessed_Call {
-	return &MockRepository_MarkEventProcessed_Call{Call: _e.mock.On("MarkEventProcessed", ctx, eventID)}
+//   - account domain.Account
+func (_e *MockRepository_Expecter) CreateAccount(ctx interface{}, account interface{}) *MockRepository_CreateAccount_Call {
+	return &MockRepository_CreateAccount_Call{Call: _e.mock.On("CreateAccount", ctx, account)}
 }
 
-func (_c *MockRepository_MarkEventProcessed_Call) Run(run func(ctx context.Context, eventID string)) *MockRepository_MarkEventProcessed_Call {
+func (_c *MockRepository_CreateAccount_Call) Run(run func(ctx context.Context, account domain.Account)) *MockRepository_CreateAccount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(domain.Account))
 	})
 	return _c
 }
 
-func (_c *MockRepository_MarkEventProcessed_Call) Return(_a0 error) *MockRepository_MarkEventProcessed_Call {
+func (_c *MockRepository_CreateAccount_Call) Return(_a0 error) *MockRepository_CreateAccount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockRepository_MarkEventProcessed_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_MarkEventProcessed_Call {
+func (_c *MockRepository_CreateAccount_Call) RunAndReturn(run func(context.Context, domain.Account) error) *MockRepository_CreateAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAdjustment provides a mock function with given fields: ctx, adjustment
+func (_m *MockRepository) CreateAdjustment(ctx context.Context, adjustment domain.Adjustment) error {
+	ret := _m.Called(ctx, adjustment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAdjustment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Adjustment) error); ok {
+		r0 = rf(ctx, adjustment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAdjustment'
+type MockRepository_CreateAdjustment_Call struct {
+	*mock.Call
+}
+
+// CreateAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - adjustment domain.Adjustment
+func (_e *MockRepository_Expecter) CreateAdjustment(ctx interface{}, adjustment interface{}) *MockRepository_CreateAdjustment_Call {
+	return &MockRepository_CreateAdjustment_Call{Call: _e.mock.On("CreateAdjustment", ctx, adjustment)}
+}
+
+func (_c *MockRepository_CreateAdjustment_Call) Run(run func(ctx context.Context, adjustment domain.Adjustment)) *MockRepository_CreateAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Adjustment))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateAdjustment_Call) Return(_a0 error) *MockRepository_CreateAdjustment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateAdjustment_Call) RunAndReturn(run func(context.Context, domain.Adjustment) error) *MockRepository_CreateAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAlertRule provides a mock function with given fields: ctx, rule
+func (_m *MockRepository) CreateAlertRule(ctx context.Context, rule domain.AlertRule) error {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAlertRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AlertRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateAlertRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAlertRule'
+type MockRepository_CreateAlertRule_Call struct {
+	*mock.Call
+}
+
+// CreateAlertRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rule domain.AlertRule
+func (_e *MockRepository_Expecter) CreateAlertRule(ctx interface{}, rule interface{}) *MockRepository_CreateAlertRule_Call {
+	return &MockRepository_CreateAlertRule_Call{Call: _e.mock.On("CreateAlertRule", ctx, rule)}
+}
+
+func (_c *MockRepository_CreateAlertRule_Call) Run(run func(ctx context.Context, rule domain.AlertRule)) *MockRepository_CreateAlertRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AlertRule))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateAlertRule_Call) Return(_a0 error) *MockRepository_CreateAlertRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateAlertRule_Call) RunAndReturn(run func(context.Context, domain.AlertRule) error) *MockRepository_CreateAlertRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCategoryRule provides a mock function with given fields: ctx, rule
+func (_m *MockRepository) CreateCategoryRule(ctx context.Context, rule domain.CategoryRule) error {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCategoryRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CategoryRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateCategoryRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCategoryRule'
+type MockRepository_CreateCategoryRule_Call struct {
+	*mock.Call
+}
+
+// CreateCategoryRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rule domain.CategoryRule
+func (_e *MockRepository_Expecter) CreateCategoryRule(ctx interface{}, rule interface{}) *MockRepository_CreateCategoryRule_Call {
+	return &MockRepository_CreateCategoryRule_Call{Call: _e.mock.On("CreateCategoryRule", ctx, rule)}
+}
+
+func (_c *MockRepository_CreateCategoryRule_Call) Run(run func(ctx context.Context, rule domain.CategoryRule)) *MockRepository_CreateCategoryRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CategoryRule))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateCategoryRule_Call) Return(_a0 error) *MockRepository_CreateCategoryRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateCategoryRule_Call) RunAndReturn(run func(context.Context, domain.CategoryRule) error) *MockRepository_CreateCategoryRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCollection provides a mock function with given fields: ctx, collection
+func (_m *MockRepository) CreateCollection(ctx context.Context, collection domain.UploadCollection) error {
+	ret := _m.Called(ctx, collection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UploadCollection) error); ok {
+		r0 = rf(ctx, collection)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCollection'
+type MockRepository_CreateCollection_Call struct {
+	*mock.Call
+}
+
+// CreateCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - collection domain.UploadCollection
+func (_e *MockRepository_Expecter) CreateCollection(ctx interface{}, collection interface{}) *MockRepository_CreateCollection_Call {
+	return &MockRepository_CreateCollection_Call{Call: _e.mock.On("CreateCollection", ctx, collection)}
+}
+
+func (_c *MockRepository_CreateCollection_Call) Run(run func(ctx context.Context, collection domain.UploadCollection)) *MockRepository_CreateCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UploadCollection))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateCollection_Call) Return(_a0 error) *MockRepository_CreateCollection_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateCollection_Call) RunAndReturn(run func(context.Context, domain.UploadCollection) error) *MockRepository_CreateCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReconciliationRun provides a mock function with given fields: ctx, run
+func (_m *MockRepository) CreateReconciliationRun(ctx context.Context, run domain.ReconciliationRun) error {
+	ret := _m.Called(ctx, run)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReconciliationRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReconciliationRun) error); ok {
+		r0 = rf(ctx, run)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateReconciliationRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReconciliationRun'
+type MockRepository_CreateReconciliationRun_Call struct {
+	*mock.Call
+}
+
+// CreateReconciliationRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - run domain.ReconciliationRun
+func (_e *MockRepository_Expecter) CreateReconciliationRun(ctx interface{}, run interface{}) *MockRepository_CreateReconciliationRun_Call {
+	return &MockRepository_CreateReconciliationRun_Call{Call: _e.mock.On("CreateReconciliationRun", ctx, run)}
+}
+
+func (_c *MockRepository_CreateReconciliationRun_Call) Run(run func(ctx context.Context, run domain.ReconciliationRun)) *MockRepository_CreateReconciliationRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ReconciliationRun))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateReconciliationRun_Call) Return(_a0 error) *MockRepository_CreateReconciliationRun_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateReconciliationRun_Call) RunAndReturn(run func(context.Context, domain.ReconciliationRun) error) *MockRepository_CreateReconciliationRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUpload provides a mock function with given fields: ctx, uploadID
+func (_m *MockRepository) CreateUpload(ctx context.Context, uploadID string) error {
+	ret := _m.Called(ctx, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUpload")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, uploadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUpload'
+type MockRepository_CreateUpload_Call struct {
+	*mock.Call
+}
+
+// CreateUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+func (_e *MockRepository_Expecter) CreateUpload(ctx interface{}, uploadID interface{}) *MockRepository_CreateUpload_Call {
+	return &MockRepository_CreateUpload_Call{Call: _e.mock.On("CreateUpload", ctx, uploadID)}
+}
+
+func (_c *MockRepository_CreateUpload_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_CreateUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateUpload_Call) Return(_a0 error) *MockRepository_CreateUpload_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateUpload_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_CreateUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWatchlistEntry provides a mock function with given fields: ctx, entry
+func (_m *MockRepository) CreateWatchlistEntry(ctx context.Context, entry domain.WatchlistEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWatchlistEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WatchlistEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateWatchlistEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWatchlistEntry'
+type MockRepository_CreateWatchlistEntry_Call struct {
+	*mock.Call
+}
+
+// CreateWatchlistEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry domain.WatchlistEntry
+func (_e *MockRepository_Expecter) CreateWatchlistEntry(ctx interface{}, entry interface{}) *MockRepository_CreateWatchlistEntry_Call {
+	return &MockRepository_CreateWatchlistEntry_Call{Call: _e.mock.On("CreateWatchlistEntry", ctx, entry)}
+}
+
+func (_c *MockRepository_CreateWatchlistEntry_Call) Run(run func(ctx context.Context, entry domain.WatchlistEntry)) *MockRepository_CreateWatchlistEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.WatchlistEntry))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateWatchlistEntry_Call) Return(_a0 error) *MockRepository_CreateWatchlistEntry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateWatchlistEntry_Call) RunAndReturn(run func(context.Context, domain.WatchlistEntry) error) *MockRepository_CreateWatchlistEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, webhook
+func (_m *MockRepository) CreateWebhook(ctx context.Context, webhook domain.WebhookSubscription) error {
+	ret := _m.Called(ctx, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookSubscription) error); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type MockRepository_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhook domain.WebhookSubscription
+func (_e *MockRepository_Expecter) CreateWebhook(ctx interface{}, webhook interface{}) *MockRepository_CreateWebhook_Call {
+	return &MockRepository_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, webhook)}
+}
+
+func (_c *MockRepository_CreateWebhook_Call) Run(run func(ctx context.Context, webhook domain.WebhookSubscription)) *MockRepository_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.WebhookSubscription))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateWebhook_Call) Return(_a0 error) *MockRepository_CreateWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateWebhook_Call) RunAndReturn(run func(context.Context, domain.WebhookSubscription) error) *MockRepository_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockRepository) DeleteAccount(ctx context.Context, accountID string) error {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAccount'
+type MockRepository_DeleteAccount_Call struct {
+	*mock.Call
+}
+
+// DeleteAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+func (_e *MockRepository_Expecter) DeleteAccount(ctx interface{}, accountID interface{}) *MockRepository_DeleteAccount_Call {
+	return &MockRepository_DeleteAccount_Call{Call: _e.mock.On("DeleteAccount", ctx, accountID)}
+}
+
+func (_c *MockRepository_DeleteAccount_Call) Run(run func(ctx context.Context, accountID string)) *MockRepository_DeleteAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteAccount_Call) Return(_a0 error) *MockRepository_DeleteAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteAccount_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_DeleteAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAlertRule provides a mock function with given fields: ctx, ruleID
+func (_m *MockRepository) DeleteAlertRule(ctx context.Context, ruleID string) error {
+	ret := _m.Called(ctx, ruleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlertRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, ruleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteAlertRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlertRule'
+type MockRepository_DeleteAlertRule_Call struct {
+	*mock.Call
+}
+
+// DeleteAlertRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ruleID string
+func (_e *MockRepository_Expecter) DeleteAlertRule(ctx interface{}, ruleID interface{}) *MockRepository_DeleteAlertRule_Call {
+	return &MockRepository_DeleteAlertRule_Call{Call: _e.mock.On("DeleteAlertRule", ctx, ruleID)}
+}
+
+func (_c *MockRepository_DeleteAlertRule_Call) Run(run func(ctx context.Context, ruleID string)) *MockRepository_DeleteAlertRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteAlertRule_Call) Return(_a0 error) *MockRepository_DeleteAlertRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteAlertRule_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_DeleteAlertRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCategoryRule provides a mock function with given fields: ctx, ruleID
+func (_m *MockRepository) DeleteCategoryRule(ctx context.Context, ruleID string) error {
+	ret := _m.Called(ctx, ruleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategoryRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, ruleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteCategoryRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCategoryRule'
+type MockRepository_DeleteCategoryRule_Call struct {
+	*mock.Call
+}
+
+// DeleteCategoryRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ruleID string
+func (_e *MockRepository_Expecter) DeleteCategoryRule(ctx interface{}, ruleID interface{}) *MockRepository_DeleteCategoryRule_Call {
+	return &MockRepository_DeleteCategoryRule_Call{Call: _e.mock.On("DeleteCategoryRule", ctx, ruleID)}
+}
+
+func (_c *MockRepository_DeleteCategoryRule_Call) Run(run func(ctx context.Context, ruleID string)) *MockRepository_DeleteCategoryRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteCategoryRule_Call) Return(_a0 error) *MockRepository_DeleteCategoryRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteCategoryRule_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_DeleteCategoryRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCollection provides a mock function with given fields: ctx, collectionID
+func (_m *MockRepository) DeleteCollection(ctx context.Context, collectionID string) error {
+	ret := _m.Called(ctx, collectionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, collectionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCollection'
+type MockRepository_DeleteCollection_Call struct {
+	*mock.Call
+}
+
+// DeleteCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - collectionID string
+func (_e *MockRepository_Expecter) DeleteCollection(ctx interface{}, collectionID interface{}) *MockRepository_DeleteCollection_Call {
+	return &MockRepository_DeleteCollection_Call{Call: _e.mock.On("DeleteCollection", ctx, collectionID)}
+}
+
+func (_c *MockRepository_DeleteCollection_Call) Run(run func(ctx context.Context, collectionID string)) *MockRepository_DeleteCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteCollection_Call) Return(_a0 error) *MockRepository_DeleteCollection_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteCollection_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_DeleteCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCounterpartyAlias provides a mock function with given fields: ctx, alias
+func (_m *MockRepository) DeleteCounterpartyAlias(ctx context.Context, alias string) error {
+	ret := _m.Called(ctx, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCounterpartyAlias")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, alias)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteCounterpartyAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCounterpartyAlias'
+type MockRepository_DeleteCounterpartyAlias_Call struct {
+	*mock.Call
+}
+
+// DeleteCounterpartyAlias is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alias string
+func (_e *MockRepository_Expecter) DeleteCounterpartyAlias(ctx interface{}, alias interface{}) *MockRepository_DeleteCounterpartyAlias_Call {
+	return &MockRepository_DeleteCounterpartyAlias_Call{Call: _e.mock.On("DeleteCounterpartyAlias", ctx, alias)}
+}
+
+func (_c *MockRepository_DeleteCounterpartyAlias_Call) Run(run func(ctx context.Context, alias string)) *MockRepository_DeleteCounterpartyAlias_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteCounterpartyAlias_Call) Return(_a0 error) *MockRepository_DeleteCounterpartyAlias_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteCounterpartyAlias_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_DeleteCounterpartyAlias_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWatchlistEntry provides a mock function with given fields: ctx, entryID
+func (_m *MockRepository) DeleteWatchlistEntry(ctx context.Context, entryID string) error {
+	ret := _m.Called(ctx, entryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWatchlistEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, entryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteWatchlistEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWatchlistEntry'
+type MockRepository_DeleteWatchlistEntry_Call struct {
+	*mock.Call
+}
+
+// DeleteWatchlistEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entryID string
+func (_e *MockRepository_Expecter) DeleteWatchlistEntry(ctx interface{}, entryID interface{}) *MockRepository_DeleteWatchlistEntry_Call {
+	return &MockRepository_DeleteWatchlistEntry_Call{Call: _e.mock.On("DeleteWatchlistEntry", ctx, entryID)}
+}
+
+func (_c *MockRepository_DeleteWatchlistEntry_Call) Run(run func(ctx context.Context, entryID string)) *MockRepository_DeleteWatchlistEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteWatchlistEntry_Call) Return(_a0 error) *MockRepository_DeleteWatchlistEntry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteWatchlistEntry_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_DeleteWatchlistEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, webhookID
+func (_m *MockRepository) DeleteWebhook(ctx context.Context, webhookID string) error {
+	ret := _m.Called(ctx, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, webhookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type MockRepository_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+func (_e *MockRepository_Expecter) DeleteWebhook(ctx interface{}, webhookID interface{}) *MockRepository_DeleteWebhook_Call {
+	return &MockRepository_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, webhookID)}
+}
+
+func (_c *MockRepository_DeleteWebhook_Call) Run(run func(ctx context.Context, webhookID string)) *MockRepository_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteWebhook_Call) Return(_a0 error) *MockRepository_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteWebhook_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DetectAnomalies provides a mock function with given fields: ctx, uploadID, row, rules, now
+func (_m *MockRepository) DetectAnomalies(ctx context.Context, uploadID string, row domain.IssueTransaction, rules domain.AnomalyRules, now time.Time) ([]domain.Anomaly, error) {
+	ret := _m.Called(ctx, uploadID, row, rules, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DetectAnomalies")
+	}
+
+	var r0 []domain.Anomaly
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.IssueTransaction, domain.AnomalyRules, time.Time) ([]domain.Anomaly, error)); ok {
+		return rf(ctx, uploadID, row, rules, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.IssueTransaction, domain.AnomalyRules, time.Time) []domain.Anomaly); ok {
+		r0 = rf(ctx, uploadID, row, rules, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Anomaly)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.IssueTransaction, domain.AnomalyRules, time.Time) error); ok {
+		r1 = rf(ctx, uploadID, row, rules, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_DetectAnomalies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DetectAnomalies'
+type MockRepository_DetectAnomalies_Call struct {
+	*mock.Call
+}
+
+// DetectAnomalies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - row domain.IssueTransaction
+//   - rules domain.AnomalyRules
+//   - now time.Time
+func (_e *MockRepository_Expecter) DetectAnomalies(ctx interface{}, uploadID interface{}, row interface{}, rules interface{}, now interface{}) *MockRepository_DetectAnomalies_Call {
+	return &MockRepository_DetectAnomalies_Call{Call: _e.mock.On("DetectAnomalies", ctx, uploadID, row, rules, now)}
+}
+
+func (_c *MockRepository_DetectAnomalies_Call) Run(run func(ctx context.Context, uploadID string, row domain.IssueTransaction, rules domain.AnomalyRules, now time.Time)) *MockRepository_DetectAnomalies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.IssueTransaction), args[3].(domain.AnomalyRules), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DetectAnomalies_Call) Return(_a0 []domain.Anomaly, _a1 error) *MockRepository_DetectAnomalies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_DetectAnomalies_Call) RunAndReturn(run func(context.Context, string, domain.IssueTransaction, domain.AnomalyRules, time.Time) ([]domain.Anomaly, error)) *MockRepository_DetectAnomalies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DetectRecurring provides a mock function with given fields: ctx, query, rules
+func (_m *MockRepository) DetectRecurring(ctx context.Context, query domain.RecurringQuery, rules domain.RecurringRules) ([]domain.RecurringSeries, error) {
+	ret := _m.Called(ctx, query, rules)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DetectRecurring")
+	}
+
+	var r0 []domain.RecurringSeries
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RecurringQuery, domain.RecurringRules) ([]domain.RecurringSeries, error)); ok {
+		return rf(ctx, query, rules)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RecurringQuery, domain.RecurringRules) []domain.RecurringSeries); ok {
+		r0 = rf(ctx, query, rules)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RecurringSeries)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.RecurringQuery, domain.RecurringRules) error); ok {
+		r1 = rf(ctx, query, rules)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_DetectRecurring_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DetectRecurring'
+type MockRepository_DetectRecurring_Call struct {
+	*mock.Call
+}
+
+// DetectRecurring is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.RecurringQuery
+//   - rules domain.RecurringRules
+func (_e *MockRepository_Expecter) DetectRecurring(ctx interface{}, query interface{}, rules interface{}) *MockRepository_DetectRecurring_Call {
+	return &MockRepository_DetectRecurring_Call{Call: _e.mock.On("DetectRecurring", ctx, query, rules)}
+}
+
+func (_c *MockRepository_DetectRecurring_Call) Run(run func(ctx context.Context, query domain.RecurringQuery, rules domain.RecurringRules)) *MockRepository_DetectRecurring_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.RecurringQuery), args[2].(domain.RecurringRules))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DetectRecurring_Call) Return(_a0 []domain.RecurringSeries, _a1 error) *MockRepository_DetectRecurring_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_DetectRecurring_Call) RunAndReturn(run func(context.Context, domain.RecurringQuery, domain.RecurringRules) ([]domain.RecurringSeries, error)) *MockRepository_DetectRecurring_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EvaluateRowAlerts provides a mock function with given fields: ctx, uploadID, row, now
+func (_m *MockRepository) EvaluateRowAlerts(ctx context.Context, uploadID string, row domain.IssueTransaction, now time.Time) ([]domain.Alert, error) {
+	ret := _m.Called(ctx, uploadID, row, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateRowAlerts")
+	}
+
+	var r0 []domain.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.IssueTransaction, time.Time) ([]domain.Alert, error)); ok {
+		return rf(ctx, uploadID, row, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.IssueTransaction, time.Time) []domain.Alert); ok {
+		r0 = rf(ctx, uploadID, row, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.IssueTransaction, time.Time) error); ok {
+		r1 = rf(ctx, uploadID, row, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_EvaluateRowAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EvaluateRowAlerts'
+type MockRepository_EvaluateRowAlerts_Call struct {
+	*mock.Call
+}
+
+// EvaluateRowAlerts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - row domain.IssueTransaction
+//   - now time.Time
+func (_e *MockRepository_Expecter) EvaluateRowAlerts(ctx interface{}, uploadID interface{}, row interface{}, now interface{}) *MockRepository_EvaluateRowAlerts_Call {
+	return &MockRepository_EvaluateRowAlerts_Call{Call: _e.mock.On("EvaluateRowAlerts", ctx, uploadID, row, now)}
+}
+
+func (_c *MockRepository_EvaluateRowAlerts_Call) Run(run func(ctx context.Context, uploadID string, row domain.IssueTransaction, now time.Time)) *MockRepository_EvaluateRowAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.IssueTransaction), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_EvaluateRowAlerts_Call) Return(_a0 []domain.Alert, _a1 error) *MockRepository_EvaluateRowAlerts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_EvaluateRowAlerts_Call) RunAndReturn(run func(context.Context, string, domain.IssueTransaction, time.Time) ([]domain.Alert, error)) *MockRepository_EvaluateRowAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EvaluateUploadAlerts provides a mock function with given fields: ctx, uploadID, now
+func (_m *MockRepository) EvaluateUploadAlerts(ctx context.Context, uploadID string, now time.Time) ([]domain.Alert, error) {
+	ret := _m.Called(ctx, uploadID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateUploadAlerts")
+	}
+
+	var r0 []domain.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]domain.Alert, error)); ok {
+		return rf(ctx, uploadID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []domain.Alert); ok {
+		r0 = rf(ctx, uploadID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, uploadID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_EvaluateUploadAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EvaluateUploadAlerts'
+type MockRepository_EvaluateUploadAlerts_Call struct {
+	*mock.Call
+}
+
+// EvaluateUploadAlerts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - now time.Time
+func (_e *MockRepository_Expecter) EvaluateUploadAlerts(ctx interface{}, uploadID interface{}, now interface{}) *MockRepository_EvaluateUploadAlerts_Call {
+	return &MockRepository_EvaluateUploadAlerts_Call{Call: _e.mock.On("EvaluateUploadAlerts", ctx, uploadID, now)}
+}
+
+func (_c *MockRepository_EvaluateUploadAlerts_Call) Run(run func(ctx context.Context, uploadID string, now time.Time)) *MockRepository_EvaluateUploadAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_EvaluateUploadAlerts_Call) Return(_a0 []domain.Alert, _a1 error) *MockRepository_EvaluateUploadAlerts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_EvaluateUploadAlerts_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]domain.Alert, error)) *MockRepository_EvaluateUploadAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAccountByNumber provides a mock function with given fields: ctx, accountNumber
+func (_m *MockRepository) FindAccountByNumber(ctx context.Context, accountNumber string) (*domain.Account, error) {
+	ret := _m.Called(ctx, accountNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAccountByNumber")
+	}
+
+	var r0 *domain.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Account, error)); ok {
+		return rf(ctx, accountNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Account); ok {
+		r0 = rf(ctx, accountNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_FindAccountByNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAccountByNumber'
+type MockRepository_FindAccountByNumber_Call struct {
+	*mock.Call
+}
+
+// FindAccountByNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+func (_e *MockRepository_Expecter) FindAccountByNumber(ctx interface{}, accountNumber interface{}) *MockRepository_FindAccountByNumber_Call {
+	return &MockRepository_FindAccountByNumber_Call{Call: _e.mock.On("FindAccountByNumber", ctx, accountNumber)}
+}
+
+func (_c *MockRepository_FindAccountByNumber_Call) Run(run func(ctx context.Context, accountNumber string)) *MockRepository_FindAccountByNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_FindAccountByNumber_Call) Return(_a0 *domain.Account, _a1 error) *MockRepository_FindAccountByNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_FindAccountByNumber_Call) RunAndReturn(run func(context.Context, string) (*domain.Account, error)) *MockRepository_FindAccountByNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindTransactionByReference provides a mock function with given fields: ctx, reference
+func (_m *MockRepository) FindTransactionByReference(ctx context.Context, reference string) (*domain.ConsolidatedIssue, error) {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTransactionByReference")
+	}
+
+	var r0 *domain.ConsolidatedIssue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.ConsolidatedIssue, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.ConsolidatedIssue); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ConsolidatedIssue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_FindTransactionByReference_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTransactionByReference'
+type MockRepository_FindTransactionByReference_Call struct {
+	*mock.Call
+}
+
+// FindTransactionByReference is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockRepository_Expecter) FindTransactionByReference(ctx interface{}, reference interface{}) *MockRepository_FindTransactionByReference_Call {
+	return &MockRepository_FindTransactionByReference_Call{Call: _e.mock.On("FindTransactionByReference", ctx, reference)}
+}
+
+func (_c *MockRepository_FindTransactionByReference_Call) Run(run func(ctx context.Context, reference string)) *MockRepository_FindTransactionByReference_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_FindTransactionByReference_Call) Return(_a0 *domain.ConsolidatedIssue, _a1 error) *MockRepository_FindTransactionByReference_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_FindTransactionByReference_Call) RunAndReturn(run func(context.Context, string) (*domain.ConsolidatedIssue, error)) *MockRepository_FindTransactionByReference_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockRepository) GetAccount(ctx context.Context, accountID string) (*domain.Account, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 *domain.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Account, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Account); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockRepository_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+func (_e *MockRepository_Expecter) GetAccount(ctx interface{}, accountID interface{}) *MockRepository_GetAccount_Call {
+	return &MockRepository_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, accountID)}
+}
+
+func (_c *MockRepository_GetAccount_Call) Run(run func(ctx context.Context, accountID string)) *MockRepository_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetAccount_Call) Return(_a0 *domain.Account, _a1 error) *MockRepository_GetAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetAccount_Call) RunAndReturn(run func(context.Context, string) (*domain.Account, error)) *MockRepository_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountBalance provides a mock function with given fields: ctx, accountID, asOf
+func (_m *MockRepository) GetAccountBalance(ctx context.Context, accountID string, asOf *int64) (*domain.AccountBalance, error) {
+	ret := _m.Called(ctx, accountID, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountBalance")
+	}
+
+	var r0 *domain.AccountBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int64) (*domain.AccountBalance, error)); ok {
+		return rf(ctx, accountID, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int64) *domain.AccountBalance); ok {
+		r0 = rf(ctx, accountID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AccountBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int64) error); ok {
+		r1 = rf(ctx, accountID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetAccountBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountBalance'
+type MockRepository_GetAccountBalance_Call struct {
+	*mock.Call
+}
+
+// GetAccountBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+//   - asOf *int64
+func (_e *MockRepository_Expecter) GetAccountBalance(ctx interface{}, accountID interface{}, asOf interface{}) *MockRepository_GetAccountBalance_Call {
+	return &MockRepository_GetAccountBalance_Call{Call: _e.mock.On("GetAccountBalance", ctx, accountID, asOf)}
+}
+
+func (_c *MockRepository_GetAccountBalance_Call) Run(run func(ctx context.Context, accountID string, asOf *int64)) *MockRepository_GetAccountBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetAccountBalance_Call) Return(_a0 *domain.AccountBalance, _a1 error) *MockRepository_GetAccountBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetAccountBalance_Call) RunAndReturn(run func(context.Context, string, *int64) (*domain.AccountBalance, error)) *MockRepository_GetAccountBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountIssues provides a mock function with given fields: ctx, query
+func (_m *MockRepository) GetAccountIssues(ctx context.Context, query domain.AccountIssueQuery) ([]domain.ConsolidatedIssue, int, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountIssues")
+	}
+
+	var r0 []domain.ConsolidatedIssue
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AccountIssueQuery) ([]domain.ConsolidatedIssue, int, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AccountIssueQuery) []domain.ConsolidatedIssue); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ConsolidatedIssue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AccountIssueQuery) int); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.AccountIssueQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRepository_GetAccountIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountIssues'
+type MockRepository_GetAccountIssues_Call struct {
+	*mock.Call
+}
+
+// GetAccountIssues is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.AccountIssueQuery
+func (_e *MockRepository_Expecter) GetAccountIssues(ctx interface{}, query interface{}) *MockRepository_GetAccountIssues_Call {
+	return &MockRepository_GetAccountIssues_Call{Call: _e.mock.On("GetAccountIssues", ctx, query)}
+}
+
+func (_c *MockRepository_GetAccountIssues_Call) Run(run func(ctx context.Context, query domain.AccountIssueQuery)) *MockRepository_GetAccountIssues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AccountIssueQuery))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetAccountIssues_Call) Return(_a0 []domain.ConsolidatedIssue, _a1 int, _a2 error) *MockRepository_GetAccountIssues_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRepository_GetAccountIssues_Call) RunAndReturn(run func(context.Context, domain.AccountIssueQuery) ([]domain.ConsolidatedIssue, int, error)) *MockRepository_GetAccountIssues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountUploadPeriods provides a mock function with given fields: ctx, accountID
+func (_m *MockRepository) GetAccountUploadPeriods(ctx context.Context, accountID string) ([]domain.UploadCoverage, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountUploadPeriods")
+	}
+
+	var r0 []domain.UploadCoverage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.UploadCoverage, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.UploadCoverage); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UploadCoverage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetAccountUploadPeriods_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountUploadPeriods'
+type MockRepository_GetAccountUploadPeriods_Call struct {
+	*mock.Call
+}
+
+// GetAccountUploadPeriods is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+func (_e *MockRepository_Expecter) GetAccountUploadPeriods(ctx interface{}, accountID interface{}) *MockRepository_GetAccountUploadPeriods_Call {
+	return &MockRepository_GetAccountUploadPeriods_Call{Call: _e.mock.On("GetAccountUploadPeriods", ctx, accountID)}
+}
+
+func (_c *MockRepository_GetAccountUploadPeriods_Call) Run(run func(ctx context.Context, accountID string)) *MockRepository_GetAccountUploadPeriods_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetAccountUploadPeriods_Call) Return(_a0 []domain.UploadCoverage, _a1 error) *MockRepository_GetAccountUploadPeriods_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetAccountUploadPeriods_Call) RunAndReturn(run func(context.Context, string) ([]domain.UploadCoverage, error)) *MockRepository_GetAccountUploadPeriods_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAdjustment provides a mock function with given fields: ctx, uploadID, adjustmentID
+func (_m *MockRepository) GetAdjustment(ctx context.Context, uploadID string, adjustmentID string) (*domain.Adjustment, error) {
+	ret := _m.Called(ctx, uploadID, adjustmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdjustment")
+	}
+
+	var r0 *domain.Adjustment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Adjustment, error)); ok {
+		return rf(ctx, uploadID, adjustmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Adjustment); ok {
+		r0 = rf(ctx, uploadID, adjustmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Adjustment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, uploadID, adjustmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdjustment'
+type MockRepository_GetAdjustment_Call struct {
+	*mock.Call
+}
+
+// GetAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - adjustmentID string
+func (_e *MockRepository_Expecter) GetAdjustment(ctx interface{}, uploadID interface{}, adjustmentID interface{}) *MockRepository_GetAdjustment_Call {
+	return &MockRepository_GetAdjustment_Call{Call: _e.mock.On("GetAdjustment", ctx, uploadID, adjustmentID)}
+}
+
+func (_c *MockRepository_GetAdjustment_Call) Run(run func(ctx context.Context, uploadID string, adjustmentID string)) *MockRepository_GetAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetAdjustment_Call) Return(_a0 *domain.Adjustment, _a1 error) *MockRepository_GetAdjustment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetAdjustment_Call) RunAndReturn(run func(context.Context, string, string) (*domain.Adjustment, error)) *MockRepository_GetAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAlertRule provides a mock function with given fields: ctx, ruleID
+func (_m *MockRepository) GetAlertRule(ctx context.Context, ruleID string) (*domain.AlertRule, error) {
+	ret := _m.Called(ctx, ruleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlertRule")
+	}
+
+	var r0 *domain.AlertRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.AlertRule, error)); ok {
+		return rf(ctx, ruleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.AlertRule); ok {
+		r0 = rf(ctx, ruleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AlertRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ruleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetAlertRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlertRule'
+type MockRepository_GetAlertRule_Call struct {
+	*mock.Call
+}
+
+// GetAlertRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ruleID string
+func (_e *MockRepository_Expecter) GetAlertRule(ctx interface{}, ruleID interface{}) *MockRepository_GetAlertRule_Call {
+	return &MockRepository_GetAlertRule_Call{Call: _e.mock.On("GetAlertRule", ctx, ruleID)}
+}
+
+func (_c *MockRepository_GetAlertRule_Call) Run(run func(ctx context.Context, ruleID string)) *MockRepository_GetAlertRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetAlertRule_Call) Return(_a0 *domain.AlertRule, _a1 error) *MockRepository_GetAlertRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetAlertRule_Call) RunAndReturn(run func(context.Context, string) (*domain.AlertRule, error)) *MockRepository_GetAlertRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalance provides a mock function with given fields: ctx, uploadID
+func (_m *MockRepository) GetBalance(ctx context.Context, uploadID string) (int64, error) {
+	ret := _m.Called(ctx, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalance")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, uploadID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, uploadID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uploadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalance'
+type MockRepository_GetBalance_Call struct {
+	*mock.Call
+}
+
+// GetBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+func (_e *MockRepository_Expecter) GetBalance(ctx interface{}, uploadID interface{}) *MockRepository_GetBalance_Call {
+	return &MockRepository_GetBalance_Call{Call: _e.mock.On("GetBalance", ctx, uploadID)}
+}
+
+func (_c *MockRepository_GetBalance_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_GetBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetBalance_Call) Return(_a0 int64, _a1 error) *MockRepository_GetBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetBalance_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockRepository_GetBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalanceAsOf provides a mock function with given fields: ctx, uploadID, asOf
+func (_m *MockRepository) GetBalanceAsOf(ctx context.Context, uploadID string, asOf int64) (int64, error) {
+	ret := _m.Called(ctx, uploadID, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceAsOf")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return rf(ctx, uploadID, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = rf(ctx, uploadID, asOf)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, uploadID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetBalanceAsOf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceAsOf'
+type MockRepository_GetBalanceAsOf_Call struct {
+	*mock.Call
+}
+
+// GetBalanceAsOf is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - asOf int64
+func (_e *MockRepository_Expecter) GetBalanceAsOf(ctx interface{}, uploadID interface{}, asOf interface{}) *MockRepository_GetBalanceAsOf_Call {
+	return &MockRepository_GetBalanceAsOf_Call{Call: _e.mock.On("GetBalanceAsOf", ctx, uploadID, asOf)}
+}
+
+func (_c *MockRepository_GetBalanceAsOf_Call) Run(run func(ctx context.Context, uploadID string, asOf int64)) *MockRepository_GetBalanceAsOf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetBalanceAsOf_Call) Return(_a0 int64, _a1 error) *MockRepository_GetBalanceAsOf_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetBalanceAsOf_Call) RunAndReturn(run func(context.Context, string, int64) (int64, error)) *MockRepository_GetBalanceAsOf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalanceRules provides a mock function with given fields: ctx
+func (_m *MockRepository) GetBalanceRules(ctx context.Context) (*domain.BalanceRules, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceRules")
+	}
+
+	var r0 *domain.BalanceRules
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.BalanceRules, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.BalanceRules); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BalanceRules)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetBalanceRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceRules'
+type MockRepository_GetBalanceRules_Call struct {
+	*mock.Call
+}
+
+// GetBalanceRules is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) GetBalanceRules(ctx interface{}) *MockRepository_GetBalanceRules_Call {
+	return &MockRepository_GetBalanceRules_Call{Call: _e.mock.On("GetBalanceRules", ctx)}
+}
+
+func (_c *MockRepository_GetBalanceRules_Call) Run(run func(ctx context.Context)) *MockRepository_GetBalanceRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetBalanceRules_Call) Return(_a0 *domain.BalanceRules, _a1 error) *MockRepository_GetBalanceRules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetBalanceRules_Call) RunAndReturn(run func(context.Context) (*domain.BalanceRules, error)) *MockRepository_GetBalanceRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalanceSeries provides a mock function with given fields: ctx, uploadID, interval
+func (_m *MockRepository) GetBalanceSeries(ctx context.Context, uploadID string, interval domain.SeriesInterval) ([]domain.BalancePoint, error) {
+	ret := _m.Called(ctx, uploadID, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceSeries")
+	}
+
+	var r0 []domain.BalancePoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SeriesInterval) ([]domain.BalancePoint, error)); ok {
+		return rf(ctx, uploadID, interval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SeriesInterval) []domain.BalancePoint); ok {
+		r0 = rf(ctx, uploadID, interval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BalancePoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.SeriesInterval) error); ok {
+		r1 = rf(ctx, uploadID, interval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetBalanceSeries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceSeries'
+type MockRepository_GetBalanceSeries_Call struct {
+	*mock.Call
+}
+
+// GetBalanceSeries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - interval domain.SeriesInterval
+func (_e *MockRepository_Expecter) GetBalanceSeries(ctx interface{}, uploadID interface{}, interval interface{}) *MockRepository_GetBalanceSeries_Call {
+	return &MockRepository_GetBalanceSeries_Call{Call: _e.mock.On("GetBalanceSeries", ctx, uploadID, interval)}
+}
+
+func (_c *MockRepository_GetBalanceSeries_Call) Run(run func(ctx context.Context, uploadID string, interval domain.SeriesInterval)) *MockRepository_GetBalanceSeries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.SeriesInterval))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetBalanceSeries_Call) Return(_a0 []domain.BalancePoint, _a1 error) *MockRepository_GetBalanceSeries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetBalanceSeries_Call) RunAndReturn(run func(context.Context, string, domain.SeriesInterval) ([]domain.BalancePoint, error)) *MockRepository_GetBalanceSeries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCategoryRule provides a mock function with given fields: ctx, ruleID
+func (_m *MockRepository) GetCategoryRule(ctx context.Context, ruleID string) (*domain.CategoryRule, error) {
+	ret := _m.Called(ctx, ruleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryRule")
+	}
+
+	var r0 *domain.CategoryRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.CategoryRule, error)); ok {
+		return rf(ctx, ruleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.CategoryRule); ok {
+		r0 = rf(ctx, ruleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CategoryRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ruleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetCategoryRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCategoryRule'
+type MockRepository_GetCategoryRule_Call struct {
+	*mock.Call
+}
+
+// GetCategoryRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ruleID string
+func (_e *MockRepository_Expecter) GetCategoryRule(ctx interface{}, ruleID interface{}) *MockRepository_GetCategoryRule_Call {
+	return &MockRepository_GetCategoryRule_Call{Call: _e.mock.On("GetCategoryRule", ctx, ruleID)}
+}
+
+func (_c *MockRepository_GetCategoryRule_Call) Run(run func(ctx context.Context, ruleID string)) *MockRepository_GetCategoryRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetCategoryRule_Call) Return(_a0 *domain.CategoryRule, _a1 error) *MockRepository_GetCategoryRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetCategoryRule_Call) RunAndReturn(run func(context.Context, string) (*domain.CategoryRule, error)) *MockRepository_GetCategoryRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCollection provides a mock function with given fields: ctx, collectionID
+func (_m *MockRepository) GetCollection(ctx context.Context, collectionID string) (*domain.UploadCollection, error) {
+	ret := _m.Called(ctx, collectionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCollection")
+	}
+
+	var r0 *domain.UploadCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.UploadCollection, error)); ok {
+		return rf(ctx, collectionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.UploadCollection); ok {
+		r0 = rf(ctx, collectionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UploadCollection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, collectionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCollection'
+type MockRepository_GetCollection_Call struct {
+	*mock.Call
+}
+
+// GetCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - collectionID string
+func (_e *MockRepository_Expecter) GetCollection(ctx interface{}, collectionID interface{}) *MockRepository_GetCollection_Call {
+	return &MockRepository_GetCollection_Call{Call: _e.mock.On("GetCollection", ctx, collectionID)}
+}
+
+func (_c *MockRepository_GetCollection_Call) Run(run func(ctx context.Context, collectionID string)) *MockRepository_GetCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetCollection_Call) Return(_a0 *domain.UploadCollection, _a1 error) *MockRepository_GetCollection_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetCollection_Call) RunAndReturn(run func(context.Context, string) (*domain.UploadCollection, error)) *MockRepository_GetCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConsolidatedBalance provides a mock function with given fields: ctx, uploadIDs
+func (_m *MockRepository) GetConsolidatedBalance(ctx context.Context, uploadIDs []string) (*domain.ConsolidatedBalance, error) {
+	ret := _m.Called(ctx, uploadIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConsolidatedBalance")
+	}
+
+	var r0 *domain.ConsolidatedBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (*domain.ConsolidatedBalance, error)); ok {
+		return rf(ctx, uploadIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) *domain.ConsolidatedBalance); ok {
+		r0 = rf(ctx, uploadIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ConsolidatedBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, uploadIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetConsolidatedBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConsolidatedBalance'
+type MockRepository_GetConsolidatedBalance_Call struct {
+	*mock.Call
+}
+
+// GetConsolidatedBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadIDs []string
+func (_e *MockRepository_Expecter) GetConsolidatedBalance(ctx interface{}, uploadIDs interface{}) *MockRepository_GetConsolidatedBalance_Call {
+	return &MockRepository_GetConsolidatedBalance_Call{Call: _e.mock.On("GetConsolidatedBalance", ctx, uploadIDs)}
+}
+
+func (_c *MockRepository_GetConsolidatedBalance_Call) Run(run func(ctx context.Context, uploadIDs []string)) *MockRepository_GetConsolidatedBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetConsolidatedBalance_Call) Return(_a0 *domain.ConsolidatedBalance, _a1 error) *MockRepository_GetConsolidatedBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetConsolidatedBalance_Call) RunAndReturn(run func(context.Context, []string) (*domain.ConsolidatedBalance, error)) *MockRepository_GetConsolidatedBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConsolidatedIssues provides a mock function with given fields: ctx, uploadIDs, page, perPage, status
+func (_m *MockRepository) GetConsolidatedIssues(ctx context.Context, uploadIDs []string, page int, perPage int, status *domain.TransactionStatus) ([]domain.ConsolidatedIssue, int, error) {
+	ret := _m.Called(ctx, uploadIDs, page, perPage, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConsolidatedIssues")
+	}
+
+	var r0 []domain.ConsolidatedIssue
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int, int, *domain.TransactionStatus) ([]domain.ConsolidatedIssue, int, error)); ok {
+		return rf(ctx, uploadIDs, page, perPage, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int, int, *domain.TransactionStatus) []domain.ConsolidatedIssue); ok {
+		r0 = rf(ctx, uploadIDs, page, perPage, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ConsolidatedIssue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, int, int, *domain.TransactionStatus) int); ok {
+		r1 = rf(ctx, uploadIDs, page, perPage, status)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []string, int, int, *domain.TransactionStatus) error); ok {
+		r2 = rf(ctx, uploadIDs, page, perPage, status)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRepository_GetConsolidatedIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConsolidatedIssues'
+type MockRepository_GetConsolidatedIssues_Call struct {
+	*mock.Call
+}
+
+// GetConsolidatedIssues is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadIDs []string
+//   - page int
+//   - perPage int
+//   - status *domain.TransactionStatus
+func (_e *MockRepository_Expecter) GetConsolidatedIssues(ctx interface{}, uploadIDs interface{}, page interface{}, perPage interface{}, status interface{}) *MockRepository_GetConsolidatedIssues_Call {
+	return &MockRepository_GetConsolidatedIssues_Call{Call: _e.mock.On("GetConsolidatedIssues", ctx, uploadIDs, page, perPage, status)}
+}
+
+func (_c *MockRepository_GetConsolidatedIssues_Call) Run(run func(ctx context.Context, uploadIDs []string, page int, perPage int, status *domain.TransactionStatus)) *MockRepository_GetConsolidatedIssues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(int), args[3].(int), args[4].(*domain.TransactionStatus))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetConsolidatedIssues_Call) Return(_a0 []domain.ConsolidatedIssue, _a1 int, _a2 error) *MockRepository_GetConsolidatedIssues_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRepository_GetConsolidatedIssues_Call) RunAndReturn(run func(context.Context, []string, int, int, *domain.TransactionStatus) ([]domain.ConsolidatedIssue, int, error)) *MockRepository_GetConsolidatedIssues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIssue provides a mock function with given fields: ctx, uploadID, lineNumber
+func (_m *MockRepository) GetIssue(ctx context.Context, uploadID string, lineNumber int) (*domain.IssueDetail, error) {
+	ret := _m.Called(ctx, uploadID, lineNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIssue")
+	}
+
+	var r0 *domain.IssueDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*domain.IssueDetail, error)); ok {
+		return rf(ctx, uploadID, lineNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *domain.IssueDetail); ok {
+		r0 = rf(ctx, uploadID, lineNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IssueDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, uploadID, lineNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetIssue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIssue'
+type MockRepository_GetIssue_Call struct {
+	*mock.Call
+}
+
+// GetIssue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - lineNumber int
+func (_e *MockRepository_Expecter) GetIssue(ctx interface{}, uploadID interface{}, lineNumber interface{}) *MockRepository_GetIssue_Call {
+	return &MockRepository_GetIssue_Call{Call: _e.mock.On("GetIssue", ctx, uploadID, lineNumber)}
+}
+
+func (_c *MockRepository_GetIssue_Call) Run(run func(ctx context.Context, uploadID string, lineNumber int)) *MockRepository_GetIssue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetIssue_Call) Return(_a0 *domain.IssueDetail, _a1 error) *MockRepository_GetIssue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetIssue_Call) RunAndReturn(run func(context.Context, string, int) (*domain.IssueDetail, error)) *MockRepository_GetIssue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIssues provides a mock function with given fields: ctx, uploadID, page, perPage, status
+func (_m *MockRepository) GetIssues(ctx context.Context, uploadID string, page int, perPage int, status *domain.TransactionStatus) ([]domain.IssueTransaction, int, error) {
+	ret := _m.Called(ctx, uploadID, page, perPage, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIssues")
+	}
+
+	var r0 []domain.IssueTransaction
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, *domain.TransactionStatus) ([]domain.IssueTransaction, int, error)); ok {
+		return rf(ctx, uploadID, page, perPage, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, *domain.TransactionStatus) []domain.IssueTransaction); ok {
+		r0 = rf(ctx, uploadID, page, perPage, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.IssueTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int, *domain.TransactionStatus) int); ok {
+		r1 = rf(ctx, uploadID, page, perPage, status)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int, *domain.TransactionStatus) error); ok {
+		r2 = rf(ctx, uploadID, page, perPage, status)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRepository_GetIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIssues'
+type MockRepository_GetIssues_Call struct {
+	*mock.Call
+}
+
+// GetIssues is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - page int
+//   - perPage int
+//   - status *domain.TransactionStatus
+func (_e *MockRepository_Expecter) GetIssues(ctx interface{}, uploadID interface{}, page interface{}, perPage interface{}, status interface{}) *MockRepository_GetIssues_Call {
+	return &MockRepository_GetIssues_Call{Call: _e.mock.On("GetIssues", ctx, uploadID, page, perPage, status)}
+}
+
+func (_c *MockRepository_GetIssues_Call) Run(run func(ctx context.Context, uploadID string, page int, perPage int, status *domain.TransactionStatus)) *MockRepository_GetIssues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int), args[4].(*domain.TransactionStatus))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetIssues_Call) Return(_a0 []domain.IssueTransaction, _a1 int, _a2 error) *MockRepository_GetIssues_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRepository_GetIssues_Call) RunAndReturn(run func(context.Context, string, int, int, *domain.TransactionStatus) ([]domain.IssueTransaction, int, error)) *MockRepository_GetIssues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReconciliationResults provides a mock function with given fields: ctx, query
+func (_m *MockRepository) GetReconciliationResults(ctx context.Context, query domain.ReconciliationResultQuery) ([]domain.ReconciliationResult, int, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReconciliationResults")
+	}
+
+	var r0 []domain.ReconciliationResult
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReconciliationResultQuery) ([]domain.ReconciliationResult, int, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReconciliationResultQuery) []domain.ReconciliationResult); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ReconciliationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ReconciliationResultQuery) int); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.ReconciliationResultQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRepository_GetReconciliationResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReconciliationResults'
+type MockRepository_GetReconciliationResults_Call struct {
+	*mock.Call
+}
+
+// GetReconciliationResults is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.ReconciliationResultQuery
+func (_e *MockRepository_Expecter) GetReconciliationResults(ctx interface{}, query interface{}) *MockRepository_GetReconciliationResults_Call {
+	return &MockRepository_GetReconciliationResults_Call{Call: _e.mock.On("GetReconciliationResults", ctx, query)}
+}
+
+func (_c *MockRepository_GetReconciliationResults_Call) Run(run func(ctx context.Context, query domain.ReconciliationResultQuery)) *MockRepository_GetReconciliationResults_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ReconciliationResultQuery))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetReconciliationResults_Call) Return(_a0 []domain.ReconciliationResult, _a1 int, _a2 error) *MockRepository_GetReconciliationResults_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRepository_GetReconciliationResults_Call) RunAndReturn(run func(context.Context, domain.ReconciliationResultQuery) ([]domain.ReconciliationResult, int, error)) *MockRepository_GetReconciliationResults_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReconciliationRun provides a mock function with given fields: ctx, runID
+func (_m *MockRepository) GetReconciliationRun(ctx context.Context, runID string) (*domain.ReconciliationRun, error) {
+	ret := _m.Called(ctx, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReconciliationRun")
+	}
+
+	var r0 *domain.ReconciliationRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.ReconciliationRun, error)); ok {
+		return rf(ctx, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.ReconciliationRun); ok {
+		r0 = rf(ctx, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ReconciliationRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetReconciliationRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReconciliationRun'
+type MockRepository_GetReconciliationRun_Call struct {
+	*mock.Call
+}
+
+// GetReconciliationRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID string
+func (_e *MockRepository_Expecter) GetReconciliationRun(ctx interface{}, runID interface{}) *MockRepository_GetReconciliationRun_Call {
+	return &MockRepository_GetReconciliationRun_Call{Call: _e.mock.On("GetReconciliationRun", ctx, runID)}
+}
+
+func (_c *MockRepository_GetReconciliationRun_Call) Run(run func(ctx context.Context, runID string)) *MockRepository_GetReconciliationRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetReconciliationRun_Call) Return(_a0 *domain.ReconciliationRun, _a1 error) *MockRepository_GetReconciliationRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetReconciliationRun_Call) RunAndReturn(run func(context.Context, string) (*domain.ReconciliationRun, error)) *MockRepository_GetReconciliationRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRejectedRows provides a mock function with given fields: ctx, uploadID, afterLine, limit
+func (_m *MockRepository) GetRejectedRows(ctx context.Context, uploadID string, afterLine int, limit int) ([]domain.RejectedRow, bool, error) {
+	ret := _m.Called(ctx, uploadID, afterLine, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRejectedRows")
+	}
+
+	var r0 []domain.RejectedRow
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]domain.RejectedRow, bool, error)); ok {
+		return rf(ctx, uploadID, afterLine, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.RejectedRow); ok {
+		r0 = rf(ctx, uploadID, afterLine, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RejectedRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) bool); ok {
+		r1 = rf(ctx, uploadID, afterLine, limit)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, uploadID, afterLine, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRepository_GetRejectedRows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRejectedRows'
+type MockRepository_GetRejectedRows_Call struct {
+	*mock.Call
+}
+
+// GetRejectedRows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - afterLine int
+//   - limit int
+func (_e *MockRepository_Expecter) GetRejectedRows(ctx interface{}, uploadID interface{}, afterLine interface{}, limit interface{}) *MockRepository_GetRejectedRows_Call {
+	return &MockRepository_GetRejectedRows_Call{Call: _e.mock.On("GetRejectedRows", ctx, uploadID, afterLine, limit)}
+}
+
+func (_c *MockRepository_GetRejectedRows_Call) Run(run func(ctx context.Context, uploadID string, afterLine int, limit int)) *MockRepository_GetRejectedRows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetRejectedRows_Call) Return(_a0 []domain.RejectedRow, _a1 bool, _a2 error) *MockRepository_GetRejectedRows_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRepository_GetRejectedRows_Call) RunAndReturn(run func(context.Context, string, int, int) ([]domain.RejectedRow, bool, error)) *MockRepository_GetRejectedRows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRuleSet provides a mock function with given fields: ctx
+func (_m *MockRepository) GetRuleSet(ctx context.Context) (*domain.RuleSet, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRuleSet")
+	}
+
+	var r0 *domain.RuleSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.RuleSet, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.RuleSet); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RuleSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetRuleSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRuleSet'
+type MockRepository_GetRuleSet_Call struct {
+	*mock.Call
+}
+
+// GetRuleSet is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) GetRuleSet(ctx interface{}) *MockRepository_GetRuleSet_Call {
+	return &MockRepository_GetRuleSet_Call{Call: _e.mock.On("GetRuleSet", ctx)}
+}
+
+func (_c *MockRepository_GetRuleSet_Call) Run(run func(ctx context.Context)) *MockRepository_GetRuleSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetRuleSet_Call) Return(_a0 *domain.RuleSet, _a1 error) *MockRepository_GetRuleSet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetRuleSet_Call) RunAndReturn(run func(context.Context) (*domain.RuleSet, error)) *MockRepository_GetRuleSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStatusHistory provides a mock function with given fields: ctx, uploadID, lineNumber
+func (_m *MockRepository) GetStatusHistory(ctx context.Context, uploadID string, lineNumber int) ([]domain.StatusChange, error) {
+	ret := _m.Called(ctx, uploadID, lineNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatusHistory")
+	}
+
+	var r0 []domain.StatusChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.StatusChange, error)); ok {
+		return rf(ctx, uploadID, lineNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.StatusChange); ok {
+		r0 = rf(ctx, uploadID, lineNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.StatusChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, uploadID, lineNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetStatusHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatusHistory'
+type MockRepository_GetStatusHistory_Call struct {
+	*mock.Call
+}
+
+// GetStatusHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - lineNumber int
+func (_e *MockRepository_Expecter) GetStatusHistory(ctx interface{}, uploadID interface{}, lineNumber interface{}) *MockRepository_GetStatusHistory_Call {
+	return &MockRepository_GetStatusHistory_Call{Call: _e.mock.On("GetStatusHistory", ctx, uploadID, lineNumber)}
+}
+
+func (_c *MockRepository_GetStatusHistory_Call) Run(run func(ctx context.Context, uploadID string, lineNumber int)) *MockRepository_GetStatusHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetStatusHistory_Call) Return(_a0 []domain.StatusChange, _a1 error) *MockRepository_GetStatusHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetStatusHistory_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.StatusChange, error)) *MockRepository_GetStatusHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUpload provides a mock function with given fields: ctx, uploadID
+func (_m *MockRepository) GetUpload(ctx context.Context, uploadID string) (*domain.Upload, error) {
+	ret := _m.Called(ctx, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpload")
+	}
+
+	var r0 *domain.Upload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Upload, error)); ok {
+		return rf(ctx, uploadID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Upload); ok {
+		r0 = rf(ctx, uploadID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Upload)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uploadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUpload'
+type MockRepository_GetUpload_Call struct {
+	*mock.Call
+}
+
+// GetUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+func (_e *MockRepository_Expecter) GetUpload(ctx interface{}, uploadID interface{}) *MockRepository_GetUpload_Call {
+	return &MockRepository_GetUpload_Call{Call: _e.mock.On("GetUpload", ctx, uploadID)}
+}
+
+func (_c *MockRepository_GetUpload_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_GetUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetUpload_Call) Return(_a0 *domain.Upload, _a1 error) *MockRepository_GetUpload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetUpload_Call) RunAndReturn(run func(context.Context, string) (*domain.Upload, error)) *MockRepository_GetUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWatchlist provides a mock function with given fields: ctx
+func (_m *MockRepository) GetWatchlist(ctx context.Context) (*domain.Watchlist, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWatchlist")
+	}
+
+	var r0 *domain.Watchlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.Watchlist, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.Watchlist); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Watchlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetWatchlist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWatchlist'
+type MockRepository_GetWatchlist_Call struct {
+	*mock.Call
+}
+
+// GetWatchlist is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) GetWatchlist(ctx interface{}) *MockRepository_GetWatchlist_Call {
+	return &MockRepository_GetWatchlist_Call{Call: _e.mock.On("GetWatchlist", ctx)}
+}
+
+func (_c *MockRepository_GetWatchlist_Call) Run(run func(ctx context.Context)) *MockRepository_GetWatchlist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetWatchlist_Call) Return(_a0 *domain.Watchlist, _a1 error) *MockRepository_GetWatchlist_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetWatchlist_Call) RunAndReturn(run func(context.Context) (*domain.Watchlist, error)) *MockRepository_GetWatchlist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhook provides a mock function with given fields: ctx, webhookID
+func (_m *MockRepository) GetWebhook(ctx context.Context, webhookID string) (*domain.WebhookSubscription, error) {
+	ret := _m.Called(ctx, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhook")
+	}
+
+	var r0 *domain.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.WebhookSubscription, error)); ok {
+		return rf(ctx, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.WebhookSubscription); ok {
+		r0 = rf(ctx, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhook'
+type MockRepository_GetWebhook_Call struct {
+	*mock.Call
+}
+
+// GetWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+func (_e *MockRepository_Expecter) GetWebhook(ctx interface{}, webhookID interface{}) *MockRepository_GetWebhook_Call {
+	return &MockRepository_GetWebhook_Call{Call: _e.mock.On("GetWebhook", ctx, webhookID)}
+}
+
+func (_c *MockRepository_GetWebhook_Call) Run(run func(ctx context.Context, webhookID string)) *MockRepository_GetWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetWebhook_Call) Return(_a0 *domain.WebhookSubscription, _a1 error) *MockRepository_GetWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetWebhook_Call) RunAndReturn(run func(context.Context, string) (*domain.WebhookSubscription, error)) *MockRepository_GetWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementProcessedRows provides a mock function with given fields: ctx, uploadID
+func (_m *MockRepository) IncrementProcessedRows(ctx context.Context, uploadID string) error {
+	ret := _m.Called(ctx, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementProcessedRows")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, uploadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_IncrementProcessedRows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementProcessedRows'
+type MockRepository_IncrementProcessedRows_Call struct {
+	*mock.Call
+}
+
+// IncrementProcessedRows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+func (_e *MockRepository_Expecter) IncrementProcessedRows(ctx interface{}, uploadID interface{}) *MockRepository_IncrementProcessedRows_Call {
+	return &MockRepository_IncrementProcessedRows_Call{Call: _e.mock.On("IncrementProcessedRows", ctx, uploadID)}
+}
+
+func (_c *MockRepository_IncrementProcessedRows_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_IncrementProcessedRows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_IncrementProcessedRows_Call) Return(_a0 error) *MockRepository_IncrementProcessedRows_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_IncrementProcessedRows_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_IncrementProcessedRows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsEventProcessed provides a mock function with given fields: ctx, eventID
+func (_m *MockRepository) IsEventProcessed(ctx context.Context, eventID string) (bool, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsEventProcessed")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_IsEventProcessed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsEventProcessed'
+type MockRepository_IsEventProcessed_Call struct {
+	*mock.Call
+}
+
+// IsEventProcessed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventID string
+func (_e *MockRepository_Expecter) IsEventProcessed(ctx interface{}, eventID interface{}) *MockRepository_IsEventProcessed_Call {
+	return &MockRepository_IsEventProcessed_Call{Call: _e.mock.On("IsEventProcessed", ctx, eventID)}
+}
+
+func (_c *MockRepository_IsEventProcessed_Call) Run(run func(ctx context.Context, eventID string)) *MockRepository_IsEventProcessed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_IsEventProcessed_Call) Return(_a0 bool, _a1 error) *MockRepository_IsEventProcessed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_IsEventProcessed_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockRepository_IsEventProcessed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccounts provides a mock function with given fields: ctx
+func (_m *MockRepository) ListAccounts(ctx context.Context) ([]domain.Account, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccounts")
+	}
+
+	var r0 []domain.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Account, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Account); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccounts'
+type MockRepository_ListAccounts_Call struct {
+	*mock.Call
+}
+
+// ListAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) ListAccounts(ctx interface{}) *MockRepository_ListAccounts_Call {
+	return &MockRepository_ListAccounts_Call{Call: _e.mock.On("ListAccounts", ctx)}
+}
+
+func (_c *MockRepository_ListAccounts_Call) Run(run func(ctx context.Context)) *MockRepository_ListAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListAccounts_Call) Return(_a0 []domain.Account, _a1 error) *MockRepository_ListAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListAccounts_Call) RunAndReturn(run func(context.Context) ([]domain.Account, error)) *MockRepository_ListAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAdjustments provides a mock function with given fields: ctx, uploadID, state
+func (_m *MockRepository) ListAdjustments(ctx context.Context, uploadID string, state *domain.AdjustmentState) ([]domain.Adjustment, error) {
+	ret := _m.Called(ctx, uploadID, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAdjustments")
+	}
+
+	var r0 []domain.Adjustment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.AdjustmentState) ([]domain.Adjustment, error)); ok {
+		return rf(ctx, uploadID, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.AdjustmentState) []domain.Adjustment); ok {
+		r0 = rf(ctx, uploadID, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Adjustment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.AdjustmentState) error); ok {
+		r1 = rf(ctx, uploadID, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListAdjustments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAdjustments'
+type MockRepository_ListAdjustments_Call struct {
+	*mock.Call
+}
+
+// ListAdjustments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - state *domain.AdjustmentState
+func (_e *MockRepository_Expecter) ListAdjustments(ctx interface{}, uploadID interface{}, state interface{}) *MockRepository_ListAdjustments_Call {
+	return &MockRepository_ListAdjustments_Call{Call: _e.mock.On("ListAdjustments", ctx, uploadID, state)}
+}
+
+func (_c *MockRepository_ListAdjustments_Call) Run(run func(ctx context.Context, uploadID string, state *domain.AdjustmentState)) *MockRepository_ListAdjustments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*domain.AdjustmentState))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListAdjustments_Call) Return(_a0 []domain.Adjustment, _a1 error) *MockRepository_ListAdjustments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListAdjustments_Call) RunAndReturn(run func(context.Context, string, *domain.AdjustmentState) ([]domain.Adjustment, error)) *MockRepository_ListAdjustments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAlertRules provides a mock function with given fields: ctx
+func (_m *MockRepository) ListAlertRules(ctx context.Context) ([]domain.AlertRule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAlertRules")
+	}
+
+	var r0 []domain.AlertRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.AlertRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.AlertRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AlertRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListAlertRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlertRules'
+type MockRepository_ListAlertRules_Call struct {
+	*mock.Call
+}
+
+// ListAlertRules is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) ListAlertRules(ctx interface{}) *MockRepository_ListAlertRules_Call {
+	return &MockRepository_ListAlertRules_Call{Call: _e.mock.On("ListAlertRules", ctx)}
+}
+
+func (_c *MockRepository_ListAlertRules_Call) Run(run func(ctx context.Context)) *MockRepository_ListAlertRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListAlertRules_Call) Return(_a0 []domain.AlertRule, _a1 error) *MockRepository_ListAlertRules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListAlertRules_Call) RunAndReturn(run func(context.Context) ([]domain.AlertRule, error)) *MockRepository_ListAlertRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAlerts provides a mock function with given fields: ctx, query
+func (_m *MockRepository) ListAlerts(ctx context.Context, query domain.AlertQuery) ([]domain.Alert, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAlerts")
+	}
+
+	var r0 []domain.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AlertQuery) ([]domain.Alert, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AlertQuery) []domain.Alert); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AlertQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlerts'
+type MockRepository_ListAlerts_Call struct {
+	*mock.Call
+}
+
+// ListAlerts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.AlertQuery
+func (_e *MockRepository_Expecter) ListAlerts(ctx interface{}, query interface{}) *MockRepository_ListAlerts_Call {
+	return &MockRepository_ListAlerts_Call{Call: _e.mock.On("ListAlerts", ctx, query)}
+}
+
+func (_c *MockRepository_ListAlerts_Call) Run(run func(ctx context.Context, query domain.AlertQuery)) *MockRepository_ListAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AlertQuery))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListAlerts_Call) Return(_a0 []domain.Alert, _a1 error) *MockRepository_ListAlerts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListAlerts_Call) RunAndReturn(run func(context.Context, domain.AlertQuery) ([]domain.Alert, error)) *MockRepository_ListAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAnomalies provides a mock function with given fields: ctx, query
+func (_m *MockRepository) ListAnomalies(ctx context.Context, query domain.AnomalyQuery) ([]domain.Anomaly, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAnomalies")
+	}
+
+	var r0 []domain.Anomaly
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AnomalyQuery) ([]domain.Anomaly, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AnomalyQuery) []domain.Anomaly); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Anomaly)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AnomalyQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListAnomalies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAnomalies'
+type MockRepository_ListAnomalies_Call struct {
+	*mock.Call
+}
+
+// ListAnomalies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.AnomalyQuery
+func (_e *MockRepository_Expecter) ListAnomalies(ctx interface{}, query interface{}) *MockRepository_ListAnomalies_Call {
+	return &MockRepository_ListAnomalies_Call{Call: _e.mock.On("ListAnomalies", ctx, query)}
+}
+
+func (_c *MockRepository_ListAnomalies_Call) Run(run func(ctx context.Context, query domain.AnomalyQuery)) *MockRepository_ListAnomalies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AnomalyQuery))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListAnomalies_Call) Return(_a0 []domain.Anomaly, _a1 error) *MockRepository_ListAnomalies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListAnomalies_Call) RunAndReturn(run func(context.Context, domain.AnomalyQuery) ([]domain.Anomaly, error)) *MockRepository_ListAnomalies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCategoryRules provides a mock function with given fields: ctx
+func (_m *MockRepository) ListCategoryRules(ctx context.Context) ([]domain.CategoryRule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCategoryRules")
+	}
+
+	var r0 []domain.CategoryRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.CategoryRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.CategoryRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CategoryRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListCategoryRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCategoryRules'
+type MockRepository_ListCategoryRules_Call struct {
+	*mock.Call
+}
+
+// ListCategoryRules is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) ListCategoryRules(ctx interface{}) *MockRepository_ListCategoryRules_Call {
+	return &MockRepository_ListCategoryRules_Call{Call: _e.mock.On("ListCategoryRules", ctx)}
+}
+
+func (_c *MockRepository_ListCategoryRules_Call) Run(run func(ctx context.Context)) *MockRepository_ListCategoryRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListCategoryRules_Call) Return(_a0 []domain.CategoryRule, _a1 error) *MockRepository_ListCategoryRules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListCategoryRules_Call) RunAndReturn(run func(context.Context) ([]domain.CategoryRule, error)) *MockRepository_ListCategoryRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCounterpartyAliases provides a mock function with given fields: ctx
+func (_m *MockRepository) ListCounterpartyAliases(ctx context.Context) ([]domain.CounterpartyAlias, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCounterpartyAliases")
+	}
+
+	var r0 []domain.CounterpartyAlias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.CounterpartyAlias, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.CounterpartyAlias); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CounterpartyAlias)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListCounterpartyAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCounterpartyAliases'
+type MockRepository_ListCounterpartyAliases_Call struct {
+	*mock.Call
+}
+
+// ListCounterpartyAliases is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) ListCounterpartyAliases(ctx interface{}) *MockRepository_ListCounterpartyAliases_Call {
+	return &MockRepository_ListCounterpartyAliases_Call{Call: _e.mock.On("ListCounterpartyAliases", ctx)}
+}
+
+func (_c *MockRepository_ListCounterpartyAliases_Call) Run(run func(ctx context.Context)) *MockRepository_ListCounterpartyAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListCounterpartyAliases_Call) Return(_a0 []domain.CounterpartyAlias, _a1 error) *MockRepository_ListCounterpartyAliases_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListCounterpartyAliases_Call) RunAndReturn(run func(context.Context) ([]domain.CounterpartyAlias, error)) *MockRepository_ListCounterpartyAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCounterpartyNames provides a mock function with given fields: ctx, uploadID
+func (_m *MockRepository) ListCounterpartyNames(ctx context.Context, uploadID string) ([]domain.CounterpartyName, error) {
+	ret := _m.Called(ctx, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCounterpartyNames")
+	}
+
+	var r0 []domain.CounterpartyName
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.CounterpartyName, error)); ok {
+		return rf(ctx, uploadID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.CounterpartyName); ok {
+		r0 = rf(ctx, uploadID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CounterpartyName)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uploadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListCounterpartyNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCounterpartyNames'
+type MockRepository_ListCounterpartyNames_Call struct {
+	*mock.Call
+}
+
+// ListCounterpartyNames is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+func (_e *MockRepository_Expecter) ListCounterpartyNames(ctx interface{}, uploadID interface{}) *MockRepository_ListCounterpartyNames_Call {
+	return &MockRepository_ListCounterpartyNames_Call{Call: _e.mock.On("ListCounterpartyNames", ctx, uploadID)}
+}
+
+func (_c *MockRepository_ListCounterpartyNames_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_ListCounterpartyNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListCounterpartyNames_Call) Return(_a0 []domain.CounterpartyName, _a1 error) *MockRepository_ListCounterpartyNames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListCounterpartyNames_Call) RunAndReturn(run func(context.Context, string) ([]domain.CounterpartyName, error)) *MockRepository_ListCounterpartyNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListScreeningResults provides a mock function with given fields: ctx, uploadID, state
+func (_m *MockRepository) ListScreeningResults(ctx context.Context, uploadID string, state *domain.ScreeningState) ([]domain.ScreeningResult, error) {
+	ret := _m.Called(ctx, uploadID, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScreeningResults")
+	}
+
+	var r0 []domain.ScreeningResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.ScreeningState) ([]domain.ScreeningResult, error)); ok {
+		return rf(ctx, uploadID, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.ScreeningState) []domain.ScreeningResult); ok {
+		r0 = rf(ctx, uploadID, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ScreeningResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.ScreeningState) error); ok {
+		r1 = rf(ctx, uploadID, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListScreeningResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScreeningResults'
+type MockRepository_ListScreeningResults_Call struct {
+	*mock.Call
+}
+
+// ListScreeningResults is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - state *domain.ScreeningState
+func (_e *MockRepository_Expecter) ListScreeningResults(ctx interface{}, uploadID interface{}, state interface{}) *MockRepository_ListScreeningResults_Call {
+	return &MockRepository_ListScreeningResults_Call{Call: _e.mock.On("ListScreeningResults", ctx, uploadID, state)}
+}
+
+func (_c *MockRepository_ListScreeningResults_Call) Run(run func(ctx context.Context, uploadID string, state *domain.ScreeningState)) *MockRepository_ListScreeningResults_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*domain.ScreeningState))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListScreeningResults_Call) Return(_a0 []domain.ScreeningResult, _a1 error) *MockRepository_ListScreeningResults_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListScreeningResults_Call) RunAndReturn(run func(context.Context, string, *domain.ScreeningState) ([]domain.ScreeningResult, error)) *MockRepository_ListScreeningResults_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWatchlistEntries provides a mock function with given fields: ctx
+func (_m *MockRepository) ListWatchlistEntries(ctx context.Context) ([]domain.WatchlistEntry, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWatchlistEntries")
+	}
+
+	var r0 []domain.WatchlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.WatchlistEntry, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.WatchlistEntry); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WatchlistEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListWatchlistEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWatchlistEntries'
+type MockRepository_ListWatchlistEntries_Call struct {
+	*mock.Call
+}
+
+// ListWatchlistEntries is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) ListWatchlistEntries(ctx interface{}) *MockRepository_ListWatchlistEntries_Call {
+	return &MockRepository_ListWatchlistEntries_Call{Call: _e.mock.On("ListWatchlistEntries", ctx)}
+}
+
+func (_c *MockRepository_ListWatchlistEntries_Call) Run(run func(ctx context.Context)) *MockRepository_ListWatchlistEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListWatchlistEntries_Call) Return(_a0 []domain.WatchlistEntry, _a1 error) *MockRepository_ListWatchlistEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListWatchlistEntries_Call) RunAndReturn(run func(context.Context) ([]domain.WatchlistEntry, error)) *MockRepository_ListWatchlistEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: ctx, query
+func (_m *MockRepository) ListWebhookDeliveries(ctx context.Context, query domain.WebhookDeliveryQuery) ([]domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 []domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookDeliveryQuery) ([]domain.WebhookDelivery, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookDeliveryQuery) []domain.WebhookDelivery); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.WebhookDeliveryQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type MockRepository_ListWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.WebhookDeliveryQuery
+func (_e *MockRepository_Expecter) ListWebhookDeliveries(ctx interface{}, query interface{}) *MockRepository_ListWebhookDeliveries_Call {
+	return &MockRepository_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, query)}
+}
+
+func (_c *MockRepository_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, query domain.WebhookDeliveryQuery)) *MockRepository_ListWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.WebhookDeliveryQuery))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListWebhookDeliveries_Call) Return(_a0 []domain.WebhookDelivery, _a1 error) *MockRepository_ListWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListWebhookDeliveries_Call) RunAndReturn(run func(context.Context, domain.WebhookDeliveryQuery) ([]domain.WebhookDelivery, error)) *MockRepository_ListWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhooks provides a mock function with given fields: ctx
+func (_m *MockRepository) ListWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooks")
+	}
+
+	var r0 []domain.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.WebhookSubscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.WebhookSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooks'
+type MockRepository_ListWebhooks_Call struct {
+	*mock.Call
+}
+
+// ListWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) ListWebhooks(ctx interface{}) *MockRepository_ListWebhooks_Call {
+	return &MockRepository_ListWebhooks_Call{Call: _e.mock.On("ListWebhooks", ctx)}
+}
+
+func (_c *MockRepository_ListWebhooks_Call) Run(run func(ctx context.Context)) *MockRepository_ListWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListWebhooks_Call) Return(_a0 []domain.WebhookSubscription, _a1 error) *MockRepository_ListWebhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListWebhooks_Call) RunAndReturn(run func(context.Context) ([]domain.WebhookSubscription, error)) *MockRepository_ListWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkEventProcessed provides a mock function with given fields: ctx, eventID
+func (_m *MockRepository) MarkEventProcessed(ctx context.Context, eventID string) error {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEventProcessed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_MarkEventProcessed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkEventProcessed'
+type MockRepository_MarkEventProcessed_Call struct {
+	*mock.Call
+}
+
+// MarkEventProcessed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventID string
+func (_e *MockRepository_Expecter) MarkEventProcessed(ctx interface{}, eventID interface{}) *MockRepository_MarkEventProcessed_Call {
+	return &MockRepository_MarkEventProcessed_Call{Call: _e.mock.On("MarkEventProcessed", ctx, eventID)}
+}
+
+func (_c *MockRepository_MarkEventProcessed_Call) Run(run func(ctx context.Context, eventID string)) *MockRepository_MarkEventProcessed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_MarkEventProcessed_Call) Return(_a0 error) *MockRepository_MarkEventProcessed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_MarkEventProcessed_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_MarkEventProcessed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkUploadReconciled provides a mock function with given fields: ctx, uploadID
+func (_m *MockRepository) MarkUploadReconciled(ctx context.Context, uploadID string) (bool, error) {
+	ret := _m.Called(ctx, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkUploadReconciled")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, uploadID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, uploadID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uploadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_MarkUploadReconciled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkUploadReconciled'
+type MockRepository_MarkUploadReconciled_Call struct {
+	*mock.Call
+}
+
+// MarkUploadReconciled is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+func (_e *MockRepository_Expecter) MarkUploadReconciled(ctx interface{}, uploadID interface{}) *MockRepository_MarkUploadReconciled_Call {
+	return &MockRepository_MarkUploadReconciled_Call{Call: _e.mock.On("MarkUploadReconciled", ctx, uploadID)}
+}
+
+func (_c *MockRepository_MarkUploadReconciled_Call) Run(run func(ctx context.Context, uploadID string)) *MockRepository_MarkUploadReconciled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_MarkUploadReconciled_Call) Return(_a0 bool, _a1 error) *MockRepository_MarkUploadReconciled_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_MarkUploadReconciled_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockRepository_MarkUploadReconciled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProjectBalance provides a mock function with given fields: ctx, query, recurring, now
+func (_m *MockRepository) ProjectBalance(ctx context.Context, query domain.ProjectionQuery, recurring domain.RecurringRules, now time.Time) (*domain.Projection, error) {
+	ret := _m.Called(ctx, query, recurring, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProjectBalance")
+	}
+
+	var r0 *domain.Projection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ProjectionQuery, domain.RecurringRules, time.Time) (*domain.Projection, error)); ok {
+		return rf(ctx, query, recurring, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ProjectionQuery, domain.RecurringRules, time.Time) *domain.Projection); ok {
+		r0 = rf(ctx, query, recurring, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Projection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ProjectionQuery, domain.RecurringRules, time.Time) error); ok {
+		r1 = rf(ctx, query, recurring, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ProjectBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProjectBalance'
+type MockRepository_ProjectBalance_Call struct {
+	*mock.Call
+}
+
+// ProjectBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.ProjectionQuery
+//   - recurring domain.RecurringRules
+//   - now time.Time
+func (_e *MockRepository_Expecter) ProjectBalance(ctx interface{}, query interface{}, recurring interface{}, now interface{}) *MockRepository_ProjectBalance_Call {
+	return &MockRepository_ProjectBalance_Call{Call: _e.mock.On("ProjectBalance", ctx, query, recurring, now)}
+}
+
+func (_c *MockRepository_ProjectBalance_Call) Run(run func(ctx context.Context, query domain.ProjectionQuery, recurring domain.RecurringRules, now time.Time)) *MockRepository_ProjectBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ProjectionQuery), args[2].(domain.RecurringRules), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ProjectBalance_Call) Return(_a0 *domain.Projection, _a1 error) *MockRepository_ProjectBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ProjectBalance_Call) RunAndReturn(run func(context.Context, domain.ProjectionQuery, domain.RecurringRules, time.Time) (*domain.Projection, error)) *MockRepository_ProjectBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryTransactions provides a mock function with given fields: ctx, query
+func (_m *MockRepository) QueryTransactions(ctx context.Context, query domain.TransactionQuery) ([]domain.IssueTransaction, int, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryTransactions")
+	}
+
+	var r0 []domain.IssueTransaction
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionQuery) ([]domain.IssueTransaction, int, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionQuery) []domain.IssueTransaction); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.IssueTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TransactionQuery) int); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.TransactionQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRepository_QueryTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryTransactions'
+type MockRepository_QueryTransactions_Call struct {
+	*mock.Call
+}
+
+// QueryTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.TransactionQuery
+func (_e *MockRepository_Expecter) QueryTransactions(ctx interface{}, query interface{}) *MockRepository_QueryTransactions_Call {
+	return &MockRepository_QueryTransactions_Call{Call: _e.mock.On("QueryTransactions", ctx, query)}
+}
+
+func (_c *MockRepository_QueryTransactions_Call) Run(run func(ctx context.Context, query domain.TransactionQuery)) *MockRepository_QueryTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.TransactionQuery))
+	})
+	return _c
+}
+
+func (_c *MockRepository_QueryTransactions_Call) Return(_a0 []domain.IssueTransaction, _a1 int, _a2 error) *MockRepository_QueryTransactions_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRepository_QueryTransactions_Call) RunAndReturn(run func(context.Context, domain.TransactionQuery) ([]domain.IssueTransaction, int, error)) *MockRepository_QueryTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReviewAdjustment provides a mock function with given fields: ctx, uploadID, adjustmentID, review
+func (_m *MockRepository) ReviewAdjustment(ctx context.Context, uploadID string, adjustmentID string, review domain.AdjustmentReview) (*domain.Adjustment, error) {
+	ret := _m.Called(ctx, uploadID, adjustmentID, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReviewAdjustment")
+	}
+
+	var r0 *domain.Adjustment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.AdjustmentReview) (*domain.Adjustment, error)); ok {
+		return rf(ctx, uploadID, adjustmentID, review)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.AdjustmentReview) *domain.Adjustment); ok {
+		r0 = rf(ctx, uploadID, adjustmentID, review)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Adjustment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, domain.AdjustmentReview) error); ok {
+		r1 = rf(ctx, uploadID, adjustmentID, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ReviewAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReviewAdjustment'
+type MockRepository_ReviewAdjustment_Call struct {
+	*mock.Call
+}
+
+// ReviewAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - adjustmentID string
+//   - review domain.AdjustmentReview
+func (_e *MockRepository_Expecter) ReviewAdjustment(ctx interface{}, uploadID interface{}, adjustmentID interface{}, review interface{}) *MockRepository_ReviewAdjustment_Call {
+	return &MockRepository_ReviewAdjustment_Call{Call: _e.mock.On("ReviewAdjustment", ctx, uploadID, adjustmentID, review)}
+}
+
+func (_c *MockRepository_ReviewAdjustment_Call) Run(run func(ctx context.Context, uploadID string, adjustmentID string, review domain.AdjustmentReview)) *MockRepository_ReviewAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(domain.AdjustmentReview))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ReviewAdjustment_Call) Return(_a0 *domain.Adjustment, _a1 error) *MockRepository_ReviewAdjustment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ReviewAdjustment_Call) RunAndReturn(run func(context.Context, string, string, domain.AdjustmentReview) (*domain.Adjustment, error)) *MockRepository_ReviewAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveReconciliationResults provides a mock function with given fields: ctx, runID, results
+func (_m *MockRepository) SaveReconciliationResults(ctx context.Context, runID string, results []domain.ReconciliationResult) error {
+	ret := _m.Called(ctx, runID, results)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveReconciliationResults")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []domain.ReconciliationResult) error); ok {
+		r0 = rf(ctx, runID, results)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SaveReconciliationResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveReconciliationResults'
+type MockRepository_SaveReconciliationResults_Call struct {
+	*mock.Call
+}
+
+// SaveReconciliationResults is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID string
+//   - results []domain.ReconciliationResult
+func (_e *MockRepository_Expecter) SaveReconciliationResults(ctx interface{}, runID interface{}, results interface{}) *MockRepository_SaveReconciliationResults_Call {
+	return &MockRepository_SaveReconciliationResults_Call{Call: _e.mock.On("SaveReconciliationResults", ctx, runID, results)}
+}
+
+func (_c *MockRepository_SaveReconciliationResults_Call) Run(run func(ctx context.Context, runID string, results []domain.ReconciliationResult)) *MockRepository_SaveReconciliationResults_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]domain.ReconciliationResult))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SaveReconciliationResults_Call) Return(_a0 error) *MockRepository_SaveReconciliationResults_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SaveReconciliationResults_Call) RunAndReturn(run func(context.Context, string, []domain.ReconciliationResult) error) *MockRepository_SaveReconciliationResults_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveWebhookDelivery provides a mock function with given fields: ctx, delivery
+func (_m *MockRepository) SaveWebhookDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveWebhookDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookDelivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SaveWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveWebhookDelivery'
+type MockRepository_SaveWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// SaveWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery domain.WebhookDelivery
+func (_e *MockRepository_Expecter) SaveWebhookDelivery(ctx interface{}, delivery interface{}) *MockRepository_SaveWebhookDelivery_Call {
+	return &MockRepository_SaveWebhookDelivery_Call{Call: _e.mock.On("SaveWebhookDelivery", ctx, delivery)}
+}
+
+func (_c *MockRepository_SaveWebhookDelivery_Call) Run(run func(ctx context.Context, delivery domain.WebhookDelivery)) *MockRepository_SaveWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SaveWebhookDelivery_Call) Return(_a0 error) *MockRepository_SaveWebhookDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SaveWebhookDelivery_Call) RunAndReturn(run func(context.Context, domain.WebhookDelivery) error) *MockRepository_SaveWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SeekTransactions provides a mock function with given fields: ctx, query, cursor
+func (_m *MockRepository) SeekTransactions(ctx context.Context, query domain.TransactionQuery, cursor *domain.Cursor) ([]domain.IssueTransaction, bool, error) {
+	ret := _m.Called(ctx, query, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SeekTransactions")
+	}
+
+	var r0 []domain.IssueTransaction
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionQuery, *domain.Cursor) ([]domain.IssueTransaction, bool, error)); ok {
+		return rf(ctx, query, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionQuery, *domain.Cursor) []domain.IssueTransaction); ok {
+		r0 = rf(ctx, query, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.IssueTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TransactionQuery, *domain.Cursor) bool); ok {
+		r1 = rf(ctx, query, cursor)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.TransactionQuery, *domain.Cursor) error); ok {
+		r2 = rf(ctx, query, cursor)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRepository_SeekTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SeekTransactions'
+type MockRepository_SeekTransactions_Call struct {
+	*mock.Call
+}
+
+// SeekTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.TransactionQuery
+//   - cursor *domain.Cursor
+func (_e *MockRepository_Expecter) SeekTransactions(ctx interface{}, query interface{}, cursor interface{}) *MockRepository_SeekTransactions_Call {
+	return &MockRepository_SeekTransactions_Call{Call: _e.mock.On("SeekTransactions", ctx, query, cursor)}
+}
+
+func (_c *MockRepository_SeekTransactions_Call) Run(run func(ctx context.Context, query domain.TransactionQuery, cursor *domain.Cursor)) *MockRepository_SeekTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.TransactionQuery), args[2].(*domain.Cursor))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SeekTransactions_Call) Return(_a0 []domain.IssueTransaction, _a1 bool, _a2 error) *MockRepository_SeekTransactions_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRepository_SeekTransactions_Call) RunAndReturn(run func(context.Context, domain.TransactionQuery, *domain.Cursor) ([]domain.IssueTransaction, bool, error)) *MockRepository_SeekTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetBalanceRules provides a mock function with given fields: ctx, rules
+func (_m *MockRepository) SetBalanceRules(ctx context.Context, rules *domain.BalanceRules) error {
+	ret := _m.Called(ctx, rules)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBalanceRules")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BalanceRules) error); ok {
+		r0 = rf(ctx, rules)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetBalanceRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBalanceRules'
+type MockRepository_SetBalanceRules_Call struct {
+	*mock.Call
+}
+
+// SetBalanceRules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rules *domain.BalanceRules
+func (_e *MockRepository_Expecter) SetBalanceRules(ctx interface{}, rules interface{}) *MockRepository_SetBalanceRules_Call {
+	return &MockRepository_SetBalanceRules_Call{Call: _e.mock.On("SetBalanceRules", ctx, rules)}
+}
+
+func (_c *MockRepository_SetBalanceRules_Call) Run(run func(ctx context.Context, rules *domain.BalanceRules)) *MockRepository_SetBalanceRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.BalanceRules))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetBalanceRules_Call) Return(_a0 error) *MockRepository_SetBalanceRules_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetBalanceRules_Call) RunAndReturn(run func(context.Context, *domain.BalanceRules) error) *MockRepository_SetBalanceRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCounterpartyAlias provides a mock function with given fields: ctx, alias
+func (_m *MockRepository) SetCounterpartyAlias(ctx context.Context, alias domain.CounterpartyAlias) (*domain.CounterpartyAlias, error) {
+	ret := _m.Called(ctx, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCounterpartyAlias")
+	}
+
+	var r0 *domain.CounterpartyAlias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CounterpartyAlias) (*domain.CounterpartyAlias, error)); ok {
+		return rf(ctx, alias)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CounterpartyAlias) *domain.CounterpartyAlias); ok {
+		r0 = rf(ctx, alias)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CounterpartyAlias)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CounterpartyAlias) error); ok {
+		r1 = rf(ctx, alias)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_SetCounterpartyAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCounterpartyAlias'
+type MockRepository_SetCounterpartyAlias_Call struct {
+	*mock.Call
+}
+
+// SetCounterpartyAlias is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alias domain.CounterpartyAlias
+func (_e *MockRepository_Expecter) SetCounterpartyAlias(ctx interface{}, alias interface{}) *MockRepository_SetCounterpartyAlias_Call {
+	return &MockRepository_SetCounterpartyAlias_Call{Call: _e.mock.On("SetCounterpartyAlias", ctx, alias)}
+}
+
+func (_c *MockRepository_SetCounterpartyAlias_Call) Run(run func(ctx context.Context, alias domain.CounterpartyAlias)) *MockRepository_SetCounterpartyAlias_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CounterpartyAlias))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetCounterpartyAlias_Call) Return(_a0 *domain.CounterpartyAlias, _a1 error) *MockRepository_SetCounterpartyAlias_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_SetCounterpartyAlias_Call) RunAndReturn(run func(context.Context, domain.CounterpartyAlias) (*domain.CounterpartyAlias, error)) *MockRepository_SetCounterpartyAlias_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUploadBalanceCheck provides a mock function with given fields: ctx, uploadID, check
+func (_m *MockRepository) SetUploadBalanceCheck(ctx context.Context, uploadID string, check domain.BalanceCheck) error {
+	ret := _m.Called(ctx, uploadID, check)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUploadBalanceCheck")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.BalanceCheck) error); ok {
+		r0 = rf(ctx, uploadID, check)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetUploadBalanceCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUploadBalanceCheck'
+type MockRepository_SetUploadBalanceCheck_Call struct {
+	*mock.Call
+}
+
+// SetUploadBalanceCheck is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - check domain.BalanceCheck
+func (_e *MockRepository_Expecter) SetUploadBalanceCheck(ctx interface{}, uploadID interface{}, check interface{}) *MockRepository_SetUploadBalanceCheck_Call {
+	return &MockRepository_SetUploadBalanceCheck_Call{Call: _e.mock.On("SetUploadBalanceCheck", ctx, uploadID, check)}
+}
+
+func (_c *MockRepository_SetUploadBalanceCheck_Call) Run(run func(ctx context.Context, uploadID string, check domain.BalanceCheck)) *MockRepository_SetUploadBalanceCheck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.BalanceCheck))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetUploadBalanceCheck_Call) Return(_a0 error) *MockRepository_SetUploadBalanceCheck_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetUploadBalanceCheck_Call) RunAndReturn(run func(context.Context, string, domain.BalanceCheck) error) *MockRepository_SetUploadBalanceCheck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUploadHoldForReview provides a mock function with given fields: ctx, uploadID, hold
+func (_m *MockRepository) SetUploadHoldForReview(ctx context.Context, uploadID string, hold bool) error {
+	ret := _m.Called(ctx, uploadID, hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUploadHoldForReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, uploadID, hold)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetUploadHoldForReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUploadHoldForReview'
+type MockRepository_SetUploadHoldForReview_Call struct {
+	*mock.Call
+}
+
+// SetUploadHoldForReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - hold bool
+func (_e *MockRepository_Expecter) SetUploadHoldForReview(ctx interface{}, uploadID interface{}, hold interface{}) *MockRepository_SetUploadHoldForReview_Call {
+	return &MockRepository_SetUploadHoldForReview_Call{Call: _e.mock.On("SetUploadHoldForReview", ctx, uploadID, hold)}
+}
+
+func (_c *MockRepository_SetUploadHoldForReview_Call) Run(run func(ctx context.Context, uploadID string, hold bool)) *MockRepository_SetUploadHoldForReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetUploadHoldForReview_Call) Return(_a0 error) *MockRepository_SetUploadHoldForReview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetUploadHoldForReview_Call) RunAndReturn(run func(context.Context, string, bool) error) *MockRepository_SetUploadHoldForReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUploadPeriod provides a mock function with given fields: ctx, uploadID, period
+func (_m *MockRepository) SetUploadPeriod(ctx context.Context, uploadID string, period domain.StatementPeriod) error {
+	ret := _m.Called(ctx, uploadID, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUploadPeriod")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.StatementPeriod) error); ok {
+		r0 = rf(ctx, uploadID, period)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetUploadPeriod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUploadPeriod'
+type MockRepository_SetUploadPeriod_Call struct {
+	*mock.Call
+}
+
+// SetUploadPeriod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - period domain.StatementPeriod
+func (_e *MockRepository_Expecter) SetUploadPeriod(ctx interface{}, uploadID interface{}, period interface{}) *MockRepository_SetUploadPeriod_Call {
+	return &MockRepository_SetUploadPeriod_Call{Call: _e.mock.On("SetUploadPeriod", ctx, uploadID, period)}
+}
+
+func (_c *MockRepository_SetUploadPeriod_Call) Run(run func(ctx context.Context, uploadID string, period domain.StatementPeriod)) *MockRepository_SetUploadPeriod_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.StatementPeriod))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetUploadPeriod_Call) Return(_a0 error) *MockRepository_SetUploadPeriod_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetUploadPeriod_Call) RunAndReturn(run func(context.Context, string, domain.StatementPeriod) error) *MockRepository_SetUploadPeriod_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUploadTotalRows provides a mock function with given fields: ctx, uploadID, total
+func (_m *MockRepository) SetUploadTotalRows(ctx context.Context, uploadID string, total int) error {
+	ret := _m.Called(ctx, uploadID, total)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUploadTotalRows")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, uploadID, total)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetUploadTotalRows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUploadTotalRows'
+type MockRepository_SetUploadTotalRows_Call struct {
+	*mock.Call
+}
+
+// SetUploadTotalRows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - total int
+func (_e *MockRepository_Expecter) SetUploadTotalRows(ctx interface{}, uploadID interface{}, total interface{}) *MockRepository_SetUploadTotalRows_Call {
+	return &MockRepository_SetUploadTotalRows_Call{Call: _e.mock.On("SetUploadTotalRows", ctx, uploadID, total)}
+}
+
+func (_c *MockRepository_SetUploadTotalRows_Call) Run(run func(ctx context.Context, uploadID string, total int)) *MockRepository_SetUploadTotalRows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetUploadTotalRows_Call) Return(_a0 error) *MockRepository_SetUploadTotalRows_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetUploadTotalRows_Call) RunAndReturn(run func(context.Context, string, int) error) *MockRepository_SetUploadTotalRows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SumByStatus provides a mock function with given fields: ctx, uploadID, status
+func (_m *MockRepository) SumByStatus(ctx context.Context, uploadID string, status domain.TransactionStatus) (domain.AmountTotals, error) {
+	ret := _m.Called(ctx, uploadID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumByStatus")
+	}
+
+	var r0 domain.AmountTotals
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.TransactionStatus) (domain.AmountTotals, error)); ok {
+		return rf(ctx, uploadID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.TransactionStatus) domain.AmountTotals); ok {
+		r0 = rf(ctx, uploadID, status)
+	} else {
+		r0 = ret.Get(0).(domain.AmountTotals)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.TransactionStatus) error); ok {
+		r1 = rf(ctx, uploadID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_SumByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumByStatus'
+type MockRepository_SumByStatus_Call struct {
+	*mock.Call
+}
+
+// SumByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - status domain.TransactionStatus
+func (_e *MockRepository_Expecter) SumByStatus(ctx interface{}, uploadID interface{}, status interface{}) *MockRepository_SumByStatus_Call {
+	return &MockRepository_SumByStatus_Call{Call: _e.mock.On("SumByStatus", ctx, uploadID, status)}
+}
+
+func (_c *MockRepository_SumByStatus_Call) Run(run func(ctx context.Context, uploadID string, status domain.TransactionStatus)) *MockRepository_SumByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.TransactionStatus))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SumByStatus_Call) Return(_a0 domain.AmountTotals, _a1 error) *MockRepository_SumByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_SumByStatus_Call) RunAndReturn(run func(context.Context, string, domain.TransactionStatus) (domain.AmountTotals, error)) *MockRepository_SumByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SummarizeByPeriod provides a mock function with given fields: ctx, uploadID, period, loc
+func (_m *MockRepository) SummarizeByPeriod(ctx context.Context, uploadID string, period domain.SummaryPeriod, loc *time.Location) ([]domain.PeriodSummary, error) {
+	ret := _m.Called(ctx, uploadID, period, loc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeByPeriod")
+	}
+
+	var r0 []domain.PeriodSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SummaryPeriod, *time.Location) ([]domain.PeriodSummary, error)); ok {
+		return rf(ctx, uploadID, period, loc)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SummaryPeriod, *time.Location) []domain.PeriodSummary); ok {
+		r0 = rf(ctx, uploadID, period, loc)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PeriodSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.SummaryPeriod, *time.Location) error); ok {
+		r1 = rf(ctx, uploadID, period, loc)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_SummarizeByPeriod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SummarizeByPeriod'
+type MockRepository_SummarizeByPeriod_Call struct {
+	*mock.Call
+}
+
+// SummarizeByPeriod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - period domain.SummaryPeriod
+//   - loc *time.Location
+func (_e *MockRepository_Expecter) SummarizeByPeriod(ctx interface{}, uploadID interface{}, period interface{}, loc interface{}) *MockRepository_SummarizeByPeriod_Call {
+	return &MockRepository_SummarizeByPeriod_Call{Call: _e.mock.On("SummarizeByPeriod", ctx, uploadID, period, loc)}
+}
+
+func (_c *MockRepository_SummarizeByPeriod_Call) Run(run func(ctx context.Context, uploadID string, period domain.SummaryPeriod, loc *time.Location)) *MockRepository_SummarizeByPeriod_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.SummaryPeriod), args[3].(*time.Location))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SummarizeByPeriod_Call) Return(_a0 []domain.PeriodSummary, _a1 error) *MockRepository_SummarizeByPeriod_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_SummarizeByPeriod_Call) RunAndReturn(run func(context.Context, string, domain.SummaryPeriod, *time.Location) ([]domain.PeriodSummary, error)) *MockRepository_SummarizeByPeriod_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAccount provides a mock function with given fields: ctx, account
+func (_m *MockRepository) UpdateAccount(ctx context.Context, account domain.Account) error {
+	ret := _m.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Account) error); ok {
+		r0 = rf(ctx, account)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdateAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAccount'
+type MockRepository_UpdateAccount_Call struct {
+	*mock.Call
+}
+
+// UpdateAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account domain.Account
+func (_e *MockRepository_Expecter) UpdateAccount(ctx interface{}, account interface{}) *MockRepository_UpdateAccount_Call {
+	return &MockRepository_UpdateAccount_Call{Call: _e.mock.On("UpdateAccount", ctx, account)}
+}
+
+func (_c *MockRepository_UpdateAccount_Call) Run(run func(ctx context.Context, account domain.Account)) *MockRepository_UpdateAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Account))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateAccount_Call) Return(_a0 error) *MockRepository_UpdateAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdateAccount_Call) RunAndReturn(run func(context.Context, domain.Account) error) *MockRepository_UpdateAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCategoryRule provides a mock function with given fields: ctx, rule
+func (_m *MockRepository) UpdateCategoryRule(ctx context.Context, rule domain.CategoryRule) error {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCategoryRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CategoryRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdateCategoryRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCategoryRule'
+type MockRepository_UpdateCategoryRule_Call struct {
+	*mock.Call
+}
+
+// UpdateCategoryRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rule domain.CategoryRule
+func (_e *MockRepository_Expecter) UpdateCategoryRule(ctx interface{}, rule interface{}) *MockRepository_UpdateCategoryRule_Call {
+	return &MockRepository_UpdateCategoryRule_Call{Call: _e.mock.On("UpdateCategoryRule", ctx, rule)}
+}
+
+func (_c *MockRepository_UpdateCategoryRule_Call) Run(run func(ctx context.Context, rule domain.CategoryRule)) *MockRepository_UpdateCategoryRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CategoryRule))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateCategoryRule_Call) Return(_a0 error) *MockRepository_UpdateCategoryRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdateCategoryRule_Call) RunAndReturn(run func(context.Context, domain.CategoryRule) error) *MockRepository_UpdateCategoryRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCollection provides a mock function with given fields: ctx, collection
+func (_m *MockRepository) UpdateCollection(ctx context.Context, collection domain.UploadCollection) error {
+	ret := _m.Called(ctx, collection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UploadCollection) error); ok {
+		r0 = rf(ctx, collection)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdateCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCollection'
+type MockRepository_UpdateCollection_Call struct {
+	*mock.Call
+}
+
+// UpdateCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - collection domain.UploadCollection
+func (_e *MockRepository_Expecter) UpdateCollection(ctx interface{}, collection interface{}) *MockRepository_UpdateCollection_Call {
+	return &MockRepository_UpdateCollection_Call{Call: _e.mock.On("UpdateCollection", ctx, collection)}
+}
+
+func (_c *MockRepository_UpdateCollection_Call) Run(run func(ctx context.Context, collection domain.UploadCollection)) *MockRepository_UpdateCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UploadCollection))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateCollection_Call) Return(_a0 error) *MockRepository_UpdateCollection_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdateCollection_Call) RunAndReturn(run func(context.Context, domain.UploadCollection) error) *MockRepository_UpdateCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateIssue provides a mock function with given fields: ctx, uploadID, lineNumber, update
+func (_m *MockRepository) UpdateIssue(ctx context.Context, uploadID string, lineNumber int, update domain.IssueUpdate) (*domain.IssueDetail, error) {
+	ret := _m.Called(ctx, uploadID, lineNumber, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIssue")
+	}
+
+	var r0 *domain.IssueDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, domain.IssueUpdate) (*domain.IssueDetail, error)); ok {
+		return rf(ctx, uploadID, lineNumber, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, domain.IssueUpdate) *domain.IssueDetail); ok {
+		r0 = rf(ctx, uploadID, lineNumber, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IssueDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, domain.IssueUpdate) error); ok {
+		r1 = rf(ctx, uploadID, lineNumber, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UpdateIssue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateIssue'
+type MockRepository_UpdateIssue_Call struct {
+	*mock.Call
+}
+
+// UpdateIssue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - lineNumber int
+//   - update domain.IssueUpdate
+func (_e *MockRepository_Expecter) UpdateIssue(ctx interface{}, uploadID interface{}, lineNumber interface{}, update interface{}) *MockRepository_UpdateIssue_Call {
+	return &MockRepository_UpdateIssue_Call{Call: _e.mock.On("UpdateIssue", ctx, uploadID, lineNumber, update)}
+}
+
+func (_c *MockRepository_UpdateIssue_Call) Run(run func(ctx context.Context, uploadID string, lineNumber int, update domain.IssueUpdate)) *MockRepository_UpdateIssue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(domain.IssueUpdate))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateIssue_Call) Return(_a0 *domain.IssueDetail, _a1 error) *MockRepository_UpdateIssue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UpdateIssue_Call) RunAndReturn(run func(context.Context, string, int, domain.IssueUpdate) (*domain.IssueDetail, error)) *MockRepository_UpdateIssue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateReconciliationRun provides a mock function with given fields: ctx, run
+func (_m *MockRepository) UpdateReconciliationRun(ctx context.Context, run domain.ReconciliationRun) error {
+	ret := _m.Called(ctx, run)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReconciliationRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReconciliationRun) error); ok {
+		r0 = rf(ctx, run)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdateReconciliationRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateReconciliationRun'
+type MockRepository_UpdateReconciliationRun_Call struct {
+	*mock.Call
+}
+
+// UpdateReconciliationRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - run domain.ReconciliationRun
+func (_e *MockRepository_Expecter) UpdateReconciliationRun(ctx interface{}, run interface{}) *MockRepository_UpdateReconciliationRun_Call {
+	return &MockRepository_UpdateReconciliationRun_Call{Call: _e.mock.On("UpdateReconciliationRun", ctx, run)}
+}
+
+func (_c *MockRepository_UpdateReconciliationRun_Call) Run(run func(ctx context.Context, run domain.ReconciliationRun)) *MockRepository_UpdateReconciliationRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ReconciliationRun))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateReconciliationRun_Call) Return(_a0 error) *MockRepository_UpdateReconciliationRun_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdateReconciliationRun_Call) RunAndReturn(run func(context.Context, domain.ReconciliationRun) error) *MockRepository_UpdateReconciliationRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTransactionStatus provides a mock function with given fields: ctx, uploadID, update
+func (_m *MockRepository) UpdateTransactionStatus(ctx context.Context, uploadID string, update domain.StatusUpdate) (*domain.IssueTransaction, error) {
+	ret := _m.Called(ctx, uploadID, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTransactionStatus")
+	}
+
+	var r0 *domain.IssueTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.StatusUpdate) (*domain.IssueTransaction, error)); ok {
+		return rf(ctx, uploadID, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.StatusUpdate) *domain.IssueTransaction); ok {
+		r0 = rf(ctx, uploadID, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IssueTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.StatusUpdate) error); ok {
+		r1 = rf(ctx, uploadID, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UpdateTransactionStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTransactionStatus'
+type MockRepository_UpdateTransactionStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateTransactionStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uploadID string
+//   - update domain.StatusUpdate
+func (_e *MockRepository_Expecter) UpdateTransactionStatus(ctx interface{}, uploadID interface{}, update interface{}) *MockRepository_UpdateTransactionStatus_Call {
+	return &MockRepository_UpdateTransactionStatus_Call{Call: _e.mock.On("UpdateTransactionStatus", ctx, uploadID, update)}
+}
+
+func (_c *MockRepository_UpdateTransactionStatus_Call) Run(run func(ctx context.Context, uploadID string, update domain.StatusUpdate)) *MockRepository_UpdateTransactionStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.StatusUpdate))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateTransactionStatus_Call) Return(_a0 *domain.IssueTransaction, _a1 error) *MockRepository_UpdateTransactionStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UpdateTransactionStatus_Call) RunAndReturn(run func(context.Context, string, domain.StatusUpdate) (*domain.IssueTransaction, error)) *MockRepository_UpdateTransactionStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -20,6 +20,18 @@ func WithMaxAttempts(attempts int) Option {
 	}
 }
 
+func WithBaseDelay(delay time.Duration) Option {
+	return func(c *Config) {
+		c.BaseDelay = delay
+	}
+}
+
+func WithMaxDelay(delay time.Duration) Option {
+	return func(c *Config) {
+		c.MaxDelay = delay
+	}
+}
+
 func Do(ctx context.Context, fn func() error, opts ...Option) error {
 	cfg := &Config{
 		MaxAttempts: 5,
@@ -1,14 +1,18 @@
 package integration
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -16,10 +20,12 @@ import (
 	"github.com/grachmannico95/flip-test-be/internal/domain"
 	"github.com/grachmannico95/flip-test-be/internal/eventbus"
 	"github.com/grachmannico95/flip-test-be/internal/handler"
+	"github.com/grachmannico95/flip-test-be/internal/notifier"
 	"github.com/grachmannico95/flip-test-be/internal/server"
 	"github.com/grachmannico95/flip-test-be/internal/service"
 	"github.com/grachmannico95/flip-test-be/internal/storage"
 	"github.com/grachmannico95/flip-test-be/pkg/logger"
+	"github.com/grachmannico95/flip-test-be/pkg/retry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -34,17 +40,76 @@ func setupTestServer(t *testing.T) (*httptest.Server, eventbus.EventBus) {
 	}
 	bus := eventbus.New(log, eventBusCfg)
 
-	reconciliationConsumer := eventbus.NewReconciliationConsumer(repo, log, 5)
+	reconciliationConsumer := eventbus.NewReconciliationConsumer(bus, repo, log, 5)
 	err := bus.Subscribe(eventbus.EventTypeReconciliation, reconciliationConsumer)
 	require.NoError(t, err)
 
+	anomalyConsumer := eventbus.NewAnomalyConsumer(repo, log, domain.DefaultAnomalyRules(), 5)
+	err = bus.Subscribe(eventbus.EventTypeTransactionStored, anomalyConsumer)
+	require.NoError(t, err)
+
+	balanceCheckConsumer := eventbus.NewBalanceCheckConsumer(bus, repo, log, 1)
+	err = bus.Subscribe(eventbus.EventTypeUploadReconciled, balanceCheckConsumer)
+	require.NoError(t, err)
+
+	webhookNotifier := notifier.NewWebhookNotifier(repo, log, time.Second,
+		retry.WithMaxAttempts(3),
+		retry.WithBaseDelay(10*time.Millisecond),
+	)
+
+	webhookQueue := notifier.NewWebhookQueue(webhookNotifier, log, 100, 2)
+	webhookQueue.Start(context.Background())
+
+	alertNotifier := notifier.NewMultiNotifier(notifier.NewLogNotifier(log), webhookQueue)
+	alertConsumer := eventbus.NewAlertConsumer(repo, alertNotifier, log, 5)
+	for _, eventType := range []eventbus.EventType{eventbus.EventTypeTransactionStored, eventbus.EventTypeUploadReconciled} {
+		err = bus.Subscribe(eventType, alertConsumer)
+		require.NoError(t, err)
+	}
+
+	webhookConsumer := eventbus.NewWebhookConsumer(repo, webhookNotifier, log, 5)
+	for _, eventType := range []eventbus.EventType{eventbus.EventTypeUploadChecked, eventbus.EventTypeUploadFailed} {
+		err = bus.Subscribe(eventType, webhookConsumer)
+		require.NoError(t, err)
+	}
+
 	err = bus.Start(context.Background())
 	require.NoError(t, err)
 
 	csvProcessor := service.NewCSVProcessor(bus, repo, log)
 	statementService := service.NewStatementService(repo, csvProcessor, log)
+	collectionService := service.NewCollectionService(repo, log)
+	accountService := service.NewAccountService(repo, log)
+	reconciliationService := service.NewReconciliationService(repo, log)
+	counterpartyService := service.NewCounterpartyService(repo, log)
+	categoryService := service.NewCategoryService(repo, log)
+	issueService := service.NewIssueService(repo, log)
+	settlementService := service.NewSettlementService(repo, log)
+	balanceRuleService := service.NewBalanceRuleService(repo, log)
+	adjustmentService := service.NewAdjustmentService(repo, log)
+	anomalyService := service.NewAnomalyService(repo, log)
+	recurringService := service.NewRecurringService(repo, log)
+	projectionService := service.NewProjectionService(repo, log, 0.8)
+	alertService := service.NewAlertService(repo, log)
+	watchlistService := service.NewWatchlistService(repo, log)
+	webhookService := service.NewWebhookService(repo, log)
 
 	statementHandler := handler.NewStatementHandler(statementService, log)
+	collectionHandler := handler.NewCollectionHandler(collectionService, log)
+	accountHandler := handler.NewAccountHandler(accountService, log)
+	reconciliationHandler := handler.NewReconciliationHandler(reconciliationService, log)
+	counterpartyHandler := handler.NewCounterpartyHandler(counterpartyService, log)
+	categoryHandler := handler.NewCategoryHandler(categoryService, log)
+	issueHandler := handler.NewIssueHandler(issueService, log)
+	settlementHandler := handler.NewSettlementHandler(settlementService, log)
+	balanceRuleHandler := handler.NewBalanceRuleHandler(balanceRuleService, log)
+	adjustmentHandler := handler.NewAdjustmentHandler(adjustmentService, log)
+	anomalyHandler := handler.NewAnomalyHandler(anomalyService, log)
+	recurringHandler := handler.NewRecurringHandler(recurringService, log)
+	projectionHandler := handler.NewProjectionHandler(projectionService, log)
+	alertHandler := handler.NewAlertHandler(alertService, log)
+	watchlistHandler := handler.NewWatchlistHandler(watchlistService, log)
+	webhookHandler := handler.NewWebhookHandler(webhookService, log)
 	healthHandler := handler.NewHealthHandler()
 
 	cfg := &config.Config{
@@ -54,7 +119,7 @@ func setupTestServer(t *testing.T) (*httptest.Server, eventbus.EventBus) {
 		},
 	}
 
-	srv := server.New(cfg, log, statementHandler, healthHandler)
+	srv := server.New(cfg, log, statementHandler, collectionHandler, accountHandler, reconciliationHandler, counterpartyHandler, categoryHandler, issueHandler, settlementHandler, balanceRuleHandler, adjustmentHandler, anomalyHandler, recurringHandler, projectionHandler, alertHandler, watchlistHandler, webhookHandler, healthHandler)
 
 	testServer := httptest.NewServer(srv.Handler())
 
@@ -140,6 +205,997 @@ func TestPagination(t *testing.T) {
 	assert.Equal(t, 1, len(issues))
 }
 
+func TestClosingBalanceValidation(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	csvContent := `1674507883,JOHN DOE,DEBIT,250000,SUCCESS,restaurant
+1674507884,JANE DOE,CREDIT,500000,SUCCESS,salary
+1674507885,BOB SMITH,DEBIT,100000,FAILED,invalid transaction`
+
+	matchedID := uploadCSVWithFields(t, srv.URL+"/statements", csvContent, map[string]string{
+		"opening_balance":          "1000000",
+		"expected_closing_balance": "1250000",
+	})
+	mismatchedID := uploadCSVWithFields(t, srv.URL+"/statements", csvContent, map[string]string{
+		"opening_balance":          "1000000",
+		"expected_closing_balance": "1300000",
+	})
+	time.Sleep(2 * time.Second)
+
+	check := getBalanceCheck(t, srv.URL+"/uploads/"+matchedID)
+	assert.Equal(t, float64(1250000), check["computed_closing_balance"])
+	assert.Equal(t, true, check["matched"])
+	assert.Equal(t, float64(0), check["difference"])
+
+	check = getBalanceCheck(t, srv.URL+"/uploads/"+mismatchedID)
+	assert.Equal(t, float64(1250000), check["computed_closing_balance"])
+	assert.Equal(t, false, check["matched"])
+	assert.Equal(t, float64(-50000), check["difference"])
+}
+
+func TestTransactionQuery(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	csvContent := `1674507883,JOHN DOE,DEBIT,250000,SUCCESS,restaurant
+1674507884,JANE DOE,CREDIT,500000,SUCCESS,salary
+1674507885,BOB SMITH,DEBIT,100000,FAILED,invalid transaction
+1674507886,ALICE WONDER,CREDIT,300000,PENDING,pending payment`
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", csvContent)
+	time.Sleep(2 * time.Second)
+
+	result := getJSON(t, srv.URL+"/transactions?upload_id="+uploadID+"&status=SUCCESS,PENDING&sort=amount&order=desc", http.StatusOK)
+	assert.Equal(t, float64(3), result["total"])
+	items := result["items"].([]interface{})
+	require.Len(t, items, 3)
+	assert.Equal(t, "JANE DOE", items[0].(map[string]interface{})["counterparty"])
+	assert.Equal(t, "JOHN DOE", items[2].(map[string]interface{})["counterparty"])
+
+	result = getJSON(t, srv.URL+"/transactions?upload_id="+uploadID+"&type=DEBIT&description=restaur", http.StatusOK)
+	assert.Equal(t, float64(1), result["total"])
+
+	getJSON(t, srv.URL+"/transactions?upload_id="+uploadID+"&sort=counterparty", http.StatusBadRequest)
+}
+
+func TestCursorPagination(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	csvContent := `1674507883,USER1,DEBIT,100000,FAILED,error1
+1674507884,USER2,DEBIT,100000,FAILED,error2
+1674507885,USER3,DEBIT,100000,SUCCESS,ok
+1674507886,USER4,DEBIT,100000,PENDING,error4
+1674507887,USER5,DEBIT,100000,FAILED,error5`
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", csvContent)
+	time.Sleep(2 * time.Second)
+
+	baseURL := srv.URL + "/transactions/issues?upload_id=" + uploadID + "&per_page=2&cursor="
+
+	result := getJSON(t, baseURL, http.StatusOK)
+	items := result["items"].([]interface{})
+	require.Len(t, items, 2)
+	assert.Equal(t, "USER1", items[0].(map[string]interface{})["counterparty"])
+	assert.Empty(t, result["prev_cursor"])
+	nextCursor := result["next_cursor"].(string)
+	require.NotEmpty(t, nextCursor)
+
+	result = getJSON(t, baseURL+nextCursor, http.StatusOK)
+	items = result["items"].([]interface{})
+	require.Len(t, items, 2)
+	assert.Equal(t, "USER4", items[0].(map[string]interface{})["counterparty"])
+	assert.Equal(t, "USER5", items[1].(map[string]interface{})["counterparty"])
+	assert.Empty(t, result["next_cursor"])
+	prevCursor := result["prev_cursor"].(string)
+	require.NotEmpty(t, prevCursor)
+
+	result = getJSON(t, baseURL+prevCursor, http.StatusOK)
+	items = result["items"].([]interface{})
+	require.Len(t, items, 2)
+	assert.Equal(t, "USER1", items[0].(map[string]interface{})["counterparty"])
+
+	getJSON(t, baseURL+"not-a-cursor", http.StatusBadRequest)
+}
+
+func TestExport(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	csvContent := `1674507883,JOHN DOE,DEBIT,250000,SUCCESS,restaurant
+1674507884,JANE DOE,CREDIT,500000,SUCCESS,salary
+1674507885,BOB SMITH,DEBIT,100000,FAILED,invalid transaction
+1674507886,ALICE WONDER,TRANSFER,300000,PENDING,unknown type
+1674507887,GRACE LEE,DEBIT,80000,PENDING,awaiting approval`
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", csvContent)
+	time.Sleep(2 * time.Second)
+
+	exportURL := srv.URL + "/uploads/" + uploadID + "/export"
+
+	// CSV issues export
+	resp, err := http.Get(exportURL + "?dataset=issues&format=csv")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="`+uploadID+`-issues.csv"`, resp.Header.Get("Content-Disposition"))
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "line_number", records[0][0])
+	assert.Equal(t, "BOB SMITH", records[1][2])
+	assert.Equal(t, "GRACE LEE", records[2][2])
+
+	// NDJSON transactions export with filters
+	resp, err = http.Get(exportURL + "?dataset=transactions&format=ndjson&type=DEBIT&filename=debits")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, `attachment; filename="debits.ndjson"`, resp.Header.Get("Content-Disposition"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	var lines int
+	for scanner.Scan() {
+		var tx map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &tx))
+		assert.Equal(t, "DEBIT", tx["type"])
+		lines++
+	}
+	assert.Equal(t, 3, lines)
+
+	// JSON rejections export
+	resp, err = http.Get(exportURL + "?dataset=rejections&format=json")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var rejections []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&rejections))
+	require.Len(t, rejections, 1)
+	assert.Equal(t, float64(4), rejections[0]["line_number"])
+	assert.Equal(t, "invalid transaction type: TRANSFER", rejections[0]["reason"])
+
+	getJSON(t, exportURL+"?dataset=issues&status=SUCCESS", http.StatusBadRequest)
+	getJSON(t, exportURL+"?format=xml", http.StatusBadRequest)
+	getJSON(t, srv.URL+"/uploads/nonexistent/export", http.StatusNotFound)
+}
+
+func TestConsolidatedBalance(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	january := uploadCSV(t, srv.URL+"/statements", `1674507883,JOHN DOE,DEBIT,250000,SUCCESS,restaurant
+1674507884,JANE DOE,CREDIT,500000,SUCCESS,salary`)
+	february := uploadCSV(t, srv.URL+"/statements", `1674507884,JANE DOE,CREDIT,500000,SUCCESS,salary
+1674507890,FRANK MILLER,CREDIT,200000,FAILED,refund`)
+	time.Sleep(2 * time.Second)
+
+	collection := postJSON(t, srv.URL+"/collections", map[string]interface{}{
+		"name":       "Q1",
+		"upload_ids": []string{january, february},
+	}, http.StatusCreated)
+	collectionID := collection["id"].(string)
+
+	result := getJSON(t, srv.URL+"/balance/consolidated?collection_id="+collectionID, http.StatusOK)
+	assert.Equal(t, float64(250000), result["balance"])
+	assert.Equal(t, float64(1), result["duplicates_skipped"])
+	assert.Len(t, result["uploads"], 2)
+
+	result = getJSON(t, srv.URL+"/transactions/issues/consolidated?upload_ids="+january+","+february, http.StatusOK)
+	assert.Equal(t, float64(1), result["total"])
+	items := result["items"].([]interface{})
+	assert.Equal(t, february, items[0].(map[string]interface{})["upload_id"])
+
+	getJSON(t, srv.URL+"/balance/consolidated?collection_id=nonexistent", http.StatusNotFound)
+	getJSON(t, srv.URL+"/balance/consolidated", http.StatusBadRequest)
+}
+
+func TestAccountStatements(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	checking := postJSON(t, srv.URL+"/accounts", map[string]interface{}{
+		"account_number": "1234567890",
+		"bank":           "BCA",
+		"currency":       "idr",
+		"owner":          "John Doe",
+	}, http.StatusCreated)
+	checkingID := checking["id"].(string)
+	assert.Equal(t, "IDR", checking["currency"])
+
+	savings := postJSON(t, srv.URL+"/accounts", map[string]interface{}{
+		"account_number": "9876543210",
+		"bank":           "BCA",
+		"currency":       "IDR",
+		"owner":          "John Doe",
+	}, http.StatusCreated)
+	savingsID := savings["id"].(string)
+
+	postJSON(t, srv.URL+"/accounts", map[string]interface{}{
+		"account_number": "1234567890",
+		"bank":           "BNI",
+		"currency":       "IDR",
+		"owner":          "Jane Doe",
+	}, http.StatusConflict)
+
+	// Rows without an account number stay on the upload's account
+	uploadCSVWithFields(t, srv.URL+"/statements", `1674507883,JOHN DOE,DEBIT,250000,SUCCESS,restaurant,
+1674507884,JANE DOE,CREDIT,500000,SUCCESS,salary,9876543210
+1674507885,BOB SMITH,DEBIT,100000,FAILED,invalid transaction,
+1674507886,ALICE WONDER,CREDIT,300000,PENDING,pending payment,9876543210
+1674507887,CHARLIE BROWN,DEBIT,50000,SUCCESS,groceries,0000000000`, map[string]string{
+		"account_id": checkingID,
+	})
+	time.Sleep(2 * time.Second)
+
+	result := getJSON(t, srv.URL+"/accounts/"+checkingID+"/balance", http.StatusOK)
+	assert.Equal(t, float64(-250000), result["balance"])
+
+	result = getJSON(t, srv.URL+"/accounts/"+savingsID+"/balance", http.StatusOK)
+	assert.Equal(t, float64(500000), result["balance"])
+
+	result = getJSON(t, srv.URL+"/accounts/"+savingsID+"/balance?as_of=1674507883", http.StatusOK)
+	assert.Equal(t, float64(0), result["balance"])
+
+	result = getJSON(t, srv.URL+"/accounts/"+savingsID+"/issues", http.StatusOK)
+	assert.Equal(t, float64(1), result["total"])
+
+	result = getJSON(t, srv.URL+"/accounts/"+checkingID+"/issues?to=1674507884", http.StatusOK)
+	assert.Equal(t, float64(0), result["total"])
+
+	getJSON(t, srv.URL+"/accounts/nonexistent/balance", http.StatusNotFound)
+
+	req, err := http.NewRequest(http.MethodDelete, srv.URL+"/accounts/"+savingsID, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestAccountCoverage(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	account := postJSON(t, srv.URL+"/accounts", map[string]interface{}{
+		"account_number": "1234567890",
+		"bank":           "BCA",
+		"currency":       "IDR",
+		"owner":          "John Doe",
+	}, http.StatusCreated)
+	accountID := account["id"].(string)
+
+	january := uploadCSVWithFields(t, srv.URL+"/statements", `1672531200,JOHN DOE,DEBIT,250000,SUCCESS,restaurant`, map[string]string{
+		"account_id":   accountID,
+		"period_start": "2023-01-01T00:00:00Z",
+		"period_end":   "2023-01-31T23:59:59Z",
+	})
+	march := uploadCSVWithFields(t, srv.URL+"/statements", `1677628800,JANE DOE,CREDIT,500000,SUCCESS,salary
+1680220800,JANE DOE,CREDIT,500000,SUCCESS,salary`, map[string]string{
+		"account_id": accountID,
+	})
+	time.Sleep(2 * time.Second)
+
+	result := getJSON(t, srv.URL+"/accounts/"+accountID+"/coverage", http.StatusOK)
+	assert.Equal(t, false, result["complete"])
+
+	gaps := result["gaps"].([]interface{})
+	require.Len(t, gaps, 1)
+	gap := gaps[0].(map[string]interface{})
+	assert.Equal(t, january, gap["after_upload_id"])
+	assert.Equal(t, march, gap["before_upload_id"])
+	assert.Equal(t, float64(1675209600), gap["start"])
+
+	getJSON(t, srv.URL+"/accounts/"+accountID+"/coverage?gap_tolerance=abc", http.StatusBadRequest)
+	getJSON(t, srv.URL+"/accounts/nonexistent/coverage", http.StatusNotFound)
+}
+
+func TestLedgerReconciliation(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", `1674507883,JOHN DOE,DEBIT,250000,SUCCESS,restaurant
+1674507884,JANE DOE,CREDIT,500000,SUCCESS,salary INV-0042
+1674507885,BOB SMITH,DEBIT,100000,FAILED,invalid transaction
+1674507887,CHARLIE BROWN,DEBIT,50000,SUCCESS,groceries`)
+	time.Sleep(2 * time.Second)
+
+	ledger := `1674594283,John Doe,DEBIT,250000,restaurant
+1674507884,Jane Doe,CREDIT,450000,payroll,INV-0042
+1674507999,DAVID CLARK,CREDIT,750000,bonus`
+
+	postFile(t, srv.URL+"/reconciliations", ledger, map[string]string{
+		"upload_id": "nonexistent",
+	}, http.StatusNotFound)
+
+	run := postFile(t, srv.URL+"/reconciliations", ledger, map[string]string{
+		"upload_id":      uploadID,
+		"date_tolerance": "48h",
+	}, http.StatusAccepted)
+	runID := run["id"].(string)
+	assert.Equal(t, "processing", run["status"])
+
+	time.Sleep(500 * time.Millisecond)
+
+	result := getJSON(t, srv.URL+"/reconciliations/"+runID, http.StatusOK)
+	assert.Equal(t, "completed", result["status"])
+	assert.Equal(t, map[string]interface{}{
+		"matched":          float64(1),
+		"unmatched_bank":   float64(1),
+		"unmatched_ledger": float64(1),
+		"amount_mismatch":  float64(1),
+	}, result["summary"])
+
+	result = getJSON(t, srv.URL+"/reconciliations/"+runID+"/results?classification=amount_mismatch", http.StatusOK)
+	assert.Equal(t, float64(1), result["total"])
+	item := result["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(50000), item["amount_difference"])
+
+	getJSON(t, srv.URL+"/reconciliations/"+runID+"/results?classification=partial", http.StatusBadRequest)
+	getJSON(t, srv.URL+"/reconciliations/nonexistent", http.StatusNotFound)
+}
+
+func TestCounterpartyAliases(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", `1674507883,JOHN DOE,DEBIT,250000,SUCCESS,restaurant
+1674507884,John  Doe,DEBIT,100000,SUCCESS,groceries
+1674507885,J. DOE PT,CREDIT,500000,SUCCESS,salary
+1674507886,BOB SMITH,DEBIT,50000,SUCCESS,coffee`)
+	time.Sleep(2 * time.Second)
+
+	result := getJSON(t, srv.URL+"/counterparties/merge-suggestions?upload_id="+uploadID, http.StatusOK)
+	require.Equal(t, float64(1), result["total"])
+	suggestion := result["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "JOHN DOE", suggestion["name"])
+	assert.Equal(t, "J DOE", suggestion["candidate"])
+
+	postJSON(t, srv.URL+"/counterparty-aliases", map[string]interface{}{
+		"alias":     suggestion["candidate"],
+		"canonical": suggestion["name"],
+	}, http.StatusOK)
+
+	result = getJSON(t, srv.URL+"/uploads/"+uploadID+"/counterparties", http.StatusOK)
+	assert.Equal(t, float64(2), result["total"])
+
+	result = getJSON(t, srv.URL+"/counterparties/merge-suggestions?upload_id="+uploadID, http.StatusOK)
+	assert.Equal(t, float64(0), result["total"])
+
+	getJSON(t, srv.URL+"/counterparties/merge-suggestions?threshold=2", http.StatusBadRequest)
+}
+
+func TestCategoryRules(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	postJSON(t, srv.URL+"/category-rules", map[string]interface{}{
+		"name":                "dining",
+		"description_pattern": "restaurant|coffee",
+		"type":                "DEBIT",
+		"category":            "Food",
+		"tags":                []string{"discretionary"},
+	}, http.StatusCreated)
+
+	postJSON(t, srv.URL+"/category-rules", map[string]interface{}{
+		"category": "broken",
+	}, http.StatusBadRequest)
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", `1674507883,JOHN DOE,DEBIT,250000,SUCCESS,restaurant
+1674507884,JANE DOE,CREDIT,500000,SUCCESS,salary
+1674507885,BOB SMITH,DEBIT,50000,SUCCESS,coffee`)
+	time.Sleep(2 * time.Second)
+
+	result := getJSON(t, srv.URL+"/transactions?upload_id="+uploadID+"&category=food", http.StatusOK)
+	assert.Equal(t, float64(2), result["total"])
+	item := result["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{"discretionary"}, item["tags"])
+
+	result = getJSON(t, srv.URL+"/transactions?upload_id="+uploadID+"&category=uncategorized", http.StatusOK)
+	assert.Equal(t, float64(1), result["total"])
+
+	// Rules added later only apply once re-run
+	postJSON(t, srv.URL+"/category-rules", map[string]interface{}{
+		"counterparty": "Jane Doe",
+		"type":         "CREDIT",
+		"category":     "salary",
+	}, http.StatusCreated)
+
+	result = postJSON(t, srv.URL+"/uploads/"+uploadID+"/categorize", nil, http.StatusOK)
+	assert.Equal(t, float64(1), result["changed"])
+
+	result = getJSON(t, srv.URL+"/uploads/"+uploadID+"/categories", http.StatusOK)
+	items := result["items"].([]interface{})
+	require.Len(t, items, 2)
+	food := items[0].(map[string]interface{})
+	assert.Equal(t, "food", food["category"])
+	assert.Equal(t, float64(300000), food["total_debit"])
+	assert.Equal(t, "salary", items[1].(map[string]interface{})["category"])
+
+	postJSON(t, srv.URL+"/uploads/nonexistent/categorize", nil, http.StatusNotFound)
+}
+
+func TestIssueWorkflow(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", `1674507883,JOHN DOE,DEBIT,250000,SUCCESS,restaurant
+1674507884,JANE DOE,CREDIT,500000,FAILED,salary
+1674507885,BOB SMITH,DEBIT,100000,PENDING,transfer`)
+	time.Sleep(2 * time.Second)
+
+	issueURL := srv.URL + "/transactions/issues/" + uploadID + "/2"
+
+	result := sendJSON(t, http.MethodPatch, issueURL, map[string]interface{}{
+		"state":    "investigating",
+		"assignee": "alice",
+		"note":     "asked the bank for a trace",
+		"actor":    "bob",
+	}, http.StatusOK)
+	issue := result["issue"].(map[string]interface{})
+	assert.Equal(t, "investigating", issue["state"])
+	assert.Equal(t, "alice", issue["assignee"])
+
+	sendJSON(t, http.MethodPatch, issueURL, map[string]interface{}{"state": "resolved", "actor": "alice"}, http.StatusOK)
+	sendJSON(t, http.MethodPatch, issueURL, map[string]interface{}{"state": "investigating"}, http.StatusConflict)
+	sendJSON(t, http.MethodPatch, issueURL, map[string]interface{}{"state": "done"}, http.StatusBadRequest)
+	sendJSON(t, http.MethodPatch, srv.URL+"/transactions/issues/"+uploadID+"/1", map[string]interface{}{"note": "x"}, http.StatusNotFound)
+
+	result = getJSON(t, issueURL, http.StatusOK)
+	assert.Len(t, result["history"].([]interface{}), 3)
+
+	result = getJSON(t, srv.URL+"/transactions/issues?upload_id="+uploadID+"&state=open", http.StatusOK)
+	assert.Equal(t, float64(1), result["total"])
+	assert.Equal(t, "BOB SMITH", result["items"].([]interface{})[0].(map[string]interface{})["counterparty"])
+
+	result = getJSON(t, srv.URL+"/transactions/issues?upload_id="+uploadID+"&state=resolved&assignee=alice", http.StatusOK)
+	assert.Equal(t, float64(1), result["total"])
+
+	getJSON(t, srv.URL+"/transactions/issues?upload_id="+uploadID+"&state=closed", http.StatusBadRequest)
+}
+
+func TestSettlePendingTransactions(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", `1674507883,JOHN DOE,CREDIT,500000,SUCCESS,salary
+1674507884,JANE DOE,DEBIT,100000,PENDING,transfer
+1674507885,BOB SMITH,DEBIT,50000,PENDING,coffee
+1674507886,ALICE WONDER,DEBIT,20000,PENDING,parking`)
+	time.Sleep(2 * time.Second)
+
+	assert.Equal(t, int64(500000), getBalance(t, srv.URL+"/balance", uploadID))
+
+	result := sendJSON(t, http.MethodPatch, srv.URL+"/uploads/"+uploadID+"/transactions/2/status", map[string]interface{}{
+		"status": "SUCCESS",
+		"reason": "cleared by bank",
+	}, http.StatusOK)
+	assert.Equal(t, "SUCCESS", result["status"])
+
+	assert.Equal(t, int64(400000), getBalance(t, srv.URL+"/balance", uploadID))
+
+	// SUCCESS cannot go back to PENDING or on to FAILED
+	sendJSON(t, http.MethodPatch, srv.URL+"/uploads/"+uploadID+"/transactions/2/status", map[string]interface{}{"status": "PENDING"}, http.StatusBadRequest)
+	sendJSON(t, http.MethodPatch, srv.URL+"/uploads/"+uploadID+"/transactions/2/status", map[string]interface{}{"status": "FAILED"}, http.StatusConflict)
+
+	result = postFile(t, srv.URL+"/uploads/"+uploadID+"/status-updates", "3,FAILED,insufficient funds\n4,SUCCESS\n2,FAILED\n9,SUCCESS\n", nil, http.StatusOK)
+	assert.Equal(t, float64(2), result["applied"])
+	assert.Equal(t, float64(2), result["rejected"])
+
+	assert.Equal(t, int64(380000), getBalance(t, srv.URL+"/balance", uploadID))
+
+	issues := getIssues(t, srv.URL+"/transactions/issues", uploadID, 1, 10, "")
+	require.Len(t, issues, 1)
+	assert.Equal(t, "BOB SMITH", issues[0]["counterparty"])
+	assert.Equal(t, "FAILED", issues[0]["status"])
+
+	result = getJSON(t, srv.URL+"/uploads/"+uploadID+"/transactions/2/status-history", http.StatusOK)
+	history := result["items"].([]interface{})
+	require.Len(t, history, 1)
+	assert.Equal(t, "PENDING", history[0].(map[string]interface{})["from_status"])
+	assert.Equal(t, "api", history[0].(map[string]interface{})["source"])
+
+	postFile(t, srv.URL+"/uploads/nonexistent/status-updates", "2,SUCCESS\n", nil, http.StatusNotFound)
+}
+
+func TestTransactionReferences(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	csvContent := `1674507883,JOHN DOE,CREDIT,500000,SUCCESS,salary,,TRX-001
+1674507884,JANE DOE,DEBIT,100000,PENDING,transfer,,TRX-002
+1674507885,BOB SMITH,DEBIT,50000,SUCCESS,coffee,,TRX-002
+1674507886,ALICE WONDER,DEBIT,20000,SUCCESS,parking,,`
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", csvContent)
+	time.Sleep(2 * time.Second)
+
+	result := getJSON(t, srv.URL+"/transactions/TRX-002", http.StatusOK)
+	assert.Equal(t, uploadID, result["upload_id"])
+	assert.Equal(t, "JANE DOE", result["counterparty"])
+	assert.Equal(t, float64(2), result["line_number"])
+
+	getJSON(t, srv.URL+"/transactions/TRX-404", http.StatusNotFound)
+
+	resp, err := http.Get(srv.URL + "/uploads/" + uploadID + "/export?dataset=rejections&format=json")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var rejections []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&rejections))
+	require.Len(t, rejections, 1)
+	assert.Equal(t, float64(3), rejections[0]["line_number"])
+	assert.Contains(t, rejections[0]["reason"], "duplicate reference in file")
+
+	// An overlapping statement keeps its own copy of every row, rows without
+	// a reference may leave the optional columns out
+	reordered := `1674507886,ALICE WONDER,DEBIT,20000,SUCCESS,parking
+1674507884,JANE DOE,DEBIT,100000,PENDING,transfer,,TRX-002
+1674507883,JOHN DOE,CREDIT,500000,SUCCESS,salary,,TRX-001`
+
+	secondID := uploadCSV(t, srv.URL+"/statements", reordered)
+	time.Sleep(2 * time.Second)
+
+	result = getJSON(t, srv.URL+"/transactions?upload_id="+secondID, http.StatusOK)
+	assert.Equal(t, float64(3), result["total"])
+
+	// Settling by reference reaches the newest copy
+	result = sendJSON(t, http.MethodPatch, srv.URL+"/transactions/TRX-002/status", map[string]interface{}{"status": "SUCCESS"}, http.StatusOK)
+	assert.Equal(t, secondID, result["upload_id"])
+	assert.Equal(t, int64(380000), getBalance(t, srv.URL+"/balance", secondID))
+	assert.Equal(t, int64(480000), getBalance(t, srv.URL+"/balance", uploadID))
+
+	// Consolidation counts the overlapping rows once
+	result = getJSON(t, srv.URL+"/balance/consolidated?upload_ids="+uploadID+","+secondID, http.StatusOK)
+	assert.Equal(t, float64(380000), result["balance"])
+	assert.Equal(t, float64(3), result["duplicates_skipped"])
+
+	result = postFile(t, srv.URL+"/uploads/"+secondID+"/status-updates", "TRX-404,FAILED\n", map[string]string{"match_by": "reference"}, http.StatusOK)
+	assert.Equal(t, float64(0), result["applied"])
+}
+
+func TestExtendedTypesAndBalanceRules(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	csvContent := `1674507883,JOHN DOE,CREDIT,500000,SUCCESS,salary,,TRX-001,
+1674507884,BANK,FEE,2500,SUCCESS,monthly fee,,TRX-002,
+1674507885,BANK,INTEREST,1000,SUCCESS,interest,,TRX-003,
+1674507886,JANE DOE,DEBIT,100000,SUCCESS,transfer,,TRX-004,
+1674507887,JANE DOE,REVERSAL,100000,SUCCESS,transfer returned,,TRX-005,TRX-004
+1674507888,BOB SMITH,DEBIT,50000,REFUNDED,coffee,,TRX-006,
+1674507889,BOB SMITH,CREDIT,1000,SUCCESS,bad reversal,,TRX-007,TRX-001`
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", csvContent)
+	time.Sleep(2 * time.Second)
+
+	// The reversal cancels the DEBIT, the REFUNDED row is left out
+	assert.Equal(t, int64(500000-2500+1000), getBalance(t, srv.URL+"/balance", uploadID))
+
+	result := getJSON(t, srv.URL+"/transactions/TRX-004", http.StatusOK)
+	reversedBy := result["reversed_by"].(map[string]interface{})
+	assert.Equal(t, "TRX-005", reversedBy["reference"])
+	assert.Equal(t, float64(5), reversedBy["line_number"])
+
+	resp, err := http.Get(srv.URL + "/uploads/" + uploadID + "/export?dataset=rejections&format=json")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var rejections []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&rejections))
+	require.Len(t, rejections, 1)
+	assert.Equal(t, float64(7), rejections[0]["line_number"])
+
+	// Settled rows can still be refunded, refunded rows are final
+	sendJSON(t, http.MethodPatch, srv.URL+"/transactions/TRX-003/status", map[string]interface{}{"status": "REFUNDED"}, http.StatusOK)
+	sendJSON(t, http.MethodPatch, srv.URL+"/transactions/TRX-003/status", map[string]interface{}{"status": "SUCCESS"}, http.StatusConflict)
+	assert.Equal(t, int64(500000-2500), getBalance(t, srv.URL+"/balance", uploadID))
+
+	result = getJSON(t, srv.URL+"/balance-rules", http.StatusOK)
+	assert.Equal(t, float64(24), result["total"])
+
+	sendJSON(t, http.MethodPut, srv.URL+"/balance-rules", map[string]interface{}{
+		"rules": []map[string]interface{}{
+			{"type": "FEE", "status": "SUCCESS", "effect": "subtract", "include": false},
+		},
+	}, http.StatusOK)
+	assert.Equal(t, int64(500000), getBalance(t, srv.URL+"/balance", uploadID))
+
+	sendJSON(t, http.MethodPut, srv.URL+"/balance-rules", map[string]interface{}{
+		"rules": []map[string]interface{}{
+			{"type": "DEBIT", "status": "SUCCESS", "effect": "inverse", "include": true},
+		},
+	}, http.StatusBadRequest)
+}
+
+func TestAdjustmentApproval(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	csvContent := `1674507883,JOHN DOE,CREDIT,500000,SUCCESS,salary
+1674507884,JANE DOE,DEBIT,100000,SUCCESS,transfer`
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", csvContent)
+	time.Sleep(2 * time.Second)
+
+	adjustmentsURL := srv.URL + "/uploads/" + uploadID + "/adjustments"
+	proposal := map[string]interface{}{
+		"timestamp":    1674507890,
+		"counterparty": "BANK",
+		"type":         "FEE",
+		"amount":       2500,
+		"description":  "monthly fee missing from export",
+		"proposed_by":  "alice",
+	}
+
+	fee := postJSON(t, adjustmentsURL, proposal, http.StatusCreated)
+	assert.Equal(t, "proposed", fee["state"])
+	assert.Equal(t, int64(400000), getBalance(t, srv.URL+"/balance", uploadID))
+
+	// Nobody approves their own entry
+	feeURL := adjustmentsURL + "/" + fee["id"].(string)
+	postJSON(t, feeURL+"/approve", map[string]interface{}{"actor": "alice"}, http.StatusForbidden)
+
+	result := postJSON(t, feeURL+"/approve", map[string]interface{}{"actor": "bob"}, http.StatusOK)
+	assert.Equal(t, "approved", result["state"])
+	assert.Equal(t, float64(3), result["line_number"])
+	assert.Equal(t, int64(397500), getBalance(t, srv.URL+"/balance", uploadID))
+
+	postJSON(t, feeURL+"/reject", map[string]interface{}{"actor": "bob", "reason": "late"}, http.StatusConflict)
+
+	other := postJSON(t, adjustmentsURL, proposal, http.StatusCreated)
+	otherURL := adjustmentsURL + "/" + other["id"].(string)
+	postJSON(t, otherURL+"/reject", map[string]interface{}{"actor": "bob"}, http.StatusBadRequest)
+	result = postJSON(t, otherURL+"/reject", map[string]interface{}{"actor": "bob", "reason": "duplicate"}, http.StatusOK)
+	assert.Equal(t, "rejected", result["state"])
+	assert.Equal(t, int64(397500), getBalance(t, srv.URL+"/balance", uploadID))
+
+	result = getJSON(t, srv.URL+"/transactions?upload_id="+uploadID+"&source=adjustment", http.StatusOK)
+	assert.Equal(t, float64(1), result["total"])
+	item := result["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "adjustment", item["source"])
+	assert.Equal(t, fee["id"], item["adjustment_id"])
+
+	result = getJSON(t, srv.URL+"/transactions?upload_id="+uploadID+"&source=statement", http.StatusOK)
+	assert.Equal(t, float64(2), result["total"])
+
+	result = getJSON(t, adjustmentsURL+"?state=rejected", http.StatusOK)
+	assert.Equal(t, float64(1), result["total"])
+}
+
+func TestAnomalyDetection(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	base := time.Now().Add(-time.Hour).Unix()
+	csvContent := fmt.Sprintf("%d,JOHN DOE,CREDIT,500000,SUCCESS,salary\n", base)
+	for i := int64(0); i < 5; i++ {
+		csvContent += fmt.Sprintf("%d,JANE DOE,DEBIT,1500,SUCCESS,top up\n", base+600+i)
+	}
+	csvContent += fmt.Sprintf("%d,BOB SMITH,DEBIT,20000,PENDING,parking\n", time.Now().Add(72*time.Hour).Unix())
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", csvContent)
+	time.Sleep(2 * time.Second)
+
+	result := getJSON(t, srv.URL+"/uploads/"+uploadID+"/anomalies", http.StatusOK)
+	assert.Equal(t, float64(6), result["total"])
+
+	result = getJSON(t, srv.URL+"/uploads/"+uploadID+"/anomalies?kind=future_timestamp", http.StatusOK)
+	require.Equal(t, float64(1), result["total"])
+	anomaly := result["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(7), anomaly["line_number"])
+	assert.NotEmpty(t, anomaly["reason"])
+
+	getJSON(t, srv.URL+"/uploads/"+uploadID+"/anomalies?kind=velocity", http.StatusBadRequest)
+
+	// Flagged rows are issues of their own kind, whatever their status
+	result = getJSON(t, srv.URL+"/transactions/issues?upload_id="+uploadID+"&kind=anomaly&per_page=20", http.StatusOK)
+	assert.Equal(t, float64(6), result["total"])
+
+	result = getJSON(t, srv.URL+"/transactions/issues?upload_id="+uploadID+"&kind=status", http.StatusOK)
+	assert.Equal(t, float64(1), result["total"])
+
+	assert.Equal(t, int64(500000-5*1500), getBalance(t, srv.URL+"/balance", uploadID))
+}
+
+func TestRecurringSeries(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	csvContent := ""
+	for month := time.January; month <= time.May; month++ {
+		if month == time.March {
+			continue
+		}
+		ts := time.Date(2024, month, 1, 8, 0, 0, 0, time.UTC).Unix()
+		csvContent += fmt.Sprintf("%d,LANDLORD,DEBIT,4000000,SUCCESS,rent\n", ts)
+	}
+	csvContent += fmt.Sprintf("%d,JOHN DOE,CREDIT,250000,SUCCESS,refund\n", time.Date(2024, time.May, 20, 8, 0, 0, 0, time.UTC).Unix())
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", csvContent)
+	time.Sleep(2 * time.Second)
+
+	result := getJSON(t, srv.URL+"/uploads/"+uploadID+"/recurring", http.StatusOK)
+	require.Equal(t, float64(1), result["total"])
+	series := result["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "LANDLORD", series["counterparty"])
+	assert.Equal(t, "monthly", series["interval"])
+	assert.Equal(t, float64(time.Date(2024, time.June, 1, 8, 0, 0, 0, time.UTC).Unix()), series["next_expected"])
+
+	exceptions := series["exceptions"].([]interface{})
+	require.Len(t, exceptions, 1)
+	assert.Equal(t, "missed", exceptions[0].(map[string]interface{})["kind"])
+
+	getJSON(t, srv.URL+"/uploads/"+uploadID+"/recurring?as_of=yesterday", http.StatusBadRequest)
+	getJSON(t, srv.URL+"/uploads/nonexistent/recurring", http.StatusNotFound)
+}
+
+func TestBalanceProjection(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	day := func(month time.Month, d int) int64 {
+		return time.Date(2024, month, d, 8, 0, 0, 0, time.UTC).Unix()
+	}
+
+	csvContent := fmt.Sprintf("%d,JOHN DOE,CREDIT,1000000,SUCCESS,opening\n", day(time.January, 2))
+	for month := time.January; month <= time.March; month++ {
+		csvContent += fmt.Sprintf("%d,LANDLORD,DEBIT,300000,SUCCESS,rent\n", day(month, 5))
+	}
+	csvContent += fmt.Sprintf("%d,CAR DEALER,DEBIT,200000,PENDING,deposit\n", day(time.March, 10))
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", csvContent)
+	time.Sleep(2 * time.Second)
+
+	result := getJSON(t, srv.URL+"/uploads/"+uploadID+"/projection?horizon=30d&settle_probability=1", http.StatusOK)
+	assert.Equal(t, float64(100000), result["start_balance"])
+	assert.Equal(t, float64(1), result["settle_probability"])
+
+	points := result["points"].([]interface{})
+	require.Len(t, points, 31)
+	assert.Equal(t, float64(-100000), points[1].(map[string]interface{})["balance"])
+	assert.Equal(t, true, points[1].(map[string]interface{})["negative"])
+
+	// The next rent falls on April 5, 26 days after the statement ends
+	assert.Equal(t, float64(-400000), points[26].(map[string]interface{})["balance"])
+	assert.Equal(t, "2024-04-05", result["lowest_on"])
+	assert.Len(t, result["negative_dates"], 30)
+
+	// The configured probability weights the PENDING row by default
+	result = getJSON(t, srv.URL+"/uploads/"+uploadID+"/projection", http.StatusOK)
+	assert.Equal(t, 0.8, result["settle_probability"])
+	points = result["points"].([]interface{})
+	assert.Equal(t, float64(-60000), points[1].(map[string]interface{})["balance"])
+
+	getJSON(t, srv.URL+"/uploads/"+uploadID+"/projection?horizon=500d", http.StatusBadRequest)
+	getJSON(t, srv.URL+"/uploads/"+uploadID+"/projection?horizon=soon", http.StatusBadRequest)
+	getJSON(t, srv.URL+"/uploads/nonexistent/projection", http.StatusNotFound)
+}
+
+func TestAlertRules(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	rules := []map[string]interface{}{
+		{"name": "overdraft", "kind": "balance_below", "amount": 0},
+		{"name": "large debit", "kind": "debit_above", "amount": 1000000},
+		{"name": "sanctioned", "kind": "counterparty_watchlist", "counterparties": []string{"Shady Trading"}},
+		{"name": "failures", "kind": "failed_ratio_above", "ratio": 0.5},
+	}
+	for _, rule := range rules {
+		postJSON(t, srv.URL+"/alert-rules", rule, http.StatusCreated)
+	}
+	postJSON(t, srv.URL+"/alert-rules", map[string]interface{}{"name": "broken", "kind": "debit_above"}, http.StatusBadRequest)
+
+	result := getJSON(t, srv.URL+"/alert-rules", http.StatusOK)
+	assert.Equal(t, float64(4), result["total"])
+
+	csvContent := `1000,JOHN DOE,CREDIT,500000,SUCCESS,salary
+2000,PT SHADY TRADING,DEBIT,2000000,SUCCESS,invoice
+3000,JANE DOE,CREDIT,3000000,SUCCESS,refund
+4000,BOB SMITH,DEBIT,100000,FAILED,parking`
+
+	uploadID := uploadCSV(t, srv.URL+"/statements", csvContent)
+	time.Sleep(2 * time.Second)
+
+	result = getJSON(t, srv.URL+"/alerts?upload_id="+uploadID, http.StatusOK)
+	assert.Equal(t, float64(3), result["total"])
+
+	result = getJSON(t, srv.URL+"/alerts?upload_id="+uploadID+"&kind=balance_below", http.StatusOK)
+	require.Equal(t, float64(1), result["total"])
+	alert := result["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{float64(2)}, alert["line_numbers"])
+	assert.Equal(t, float64(-1500000), alert["value"])
+
+	result = getJSON(t, srv.URL+"/alerts?kind=debit_above,counterparty_watchlist", http.StatusOK)
+	assert.Equal(t, float64(2), result["total"])
+
+	getJSON(t, srv.URL+"/alerts?kind=velocity", http.StatusBadRequest)
+	getJSON(t, srv.URL+"/alert-rules/nonexistent", http.StatusNotFound)
+}
+
+func TestWatchlistScreening(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	postJSON(t, srv.URL+"/watchlist", map[string]interface{}{"name": "Shady Trading", "reason": "sanctions"}, http.StatusCreated)
+	postJSON(t, srv.URL+"/watchlist", map[string]interface{}{"name": "shady trading"}, http.StatusConflict)
+	postJSON(t, srv.URL+"/watchlist", map[string]interface{}{"name": " "}, http.StatusBadRequest)
+
+	result := postFile(t, srv.URL+"/watchlist/import", "name,reason\nGrey Imports,adverse media\nSHADY TRADING,duplicate\n", nil, http.StatusOK)
+	assert.Equal(t, float64(1), result["imported"])
+	assert.Equal(t, float64(1), result["skipped"])
+
+	result = getJSON(t, srv.URL+"/watchlist", http.StatusOK)
+	assert.Equal(t, float64(2), result["total"])
+
+	csvContent := `1000,JOHN DOE,CREDIT,500000,SUCCESS,salary
+2000,PT SHADY TRADING,DEBIT,200000,SUCCESS,invoice
+3000,GREY IMPORT,DEBIT,100000,SUCCESS,goods`
+
+	uploadID := uploadCSVWithFields(t, srv.URL+"/statements", csvContent, map[string]string{"hold_for_review": "true"})
+	time.Sleep(2 * time.Second)
+
+	upload := getJSON(t, srv.URL+"/uploads/"+uploadID, http.StatusOK)
+	assert.Equal(t, "needs_review", upload["status"])
+
+	result = getJSON(t, srv.URL+"/uploads/"+uploadID+"/screening?state=open", http.StatusOK)
+	require.Equal(t, float64(2), result["total"])
+	first := result["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(2), first["line_number"])
+
+	postJSON(t, srv.URL+"/uploads/"+uploadID+"/screening/clear", map[string]interface{}{"note": "no reviewer"}, http.StatusBadRequest)
+	postJSON(t, srv.URL+"/uploads/"+uploadID+"/screening/clear", map[string]interface{}{"reviewer": "alice", "line_numbers": []int{1}}, http.StatusNotFound)
+
+	result = postJSON(t, srv.URL+"/uploads/"+uploadID+"/screening/clear", map[string]interface{}{"reviewer": "alice", "note": "different entities"}, http.StatusOK)
+	assert.Equal(t, float64(2), result["total"])
+	assert.Equal(t, "completed", result["upload_status"])
+
+	upload = getJSON(t, srv.URL+"/uploads/"+uploadID, http.StatusOK)
+	assert.Equal(t, "completed", upload["status"])
+
+	// Uploads without the hold still record hits but complete as usual
+	uploadID = uploadCSV(t, srv.URL+"/statements", csvContent)
+	time.Sleep(2 * time.Second)
+
+	upload = getJSON(t, srv.URL+"/uploads/"+uploadID, http.StatusOK)
+	assert.Equal(t, "completed", upload["status"])
+
+	result = getJSON(t, srv.URL+"/uploads/"+uploadID+"/screening", http.StatusOK)
+	assert.Equal(t, float64(2), result["total"])
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	srv, bus := setupTestServer(t)
+	defer srv.Close()
+	defer bus.Shutdown(context.Background())
+
+	type received struct {
+		event     domain.WebhookEvent
+		signature string
+		delivery  string
+	}
+
+	var (
+		mu       sync.Mutex
+		events   []received
+		requests int
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// The first attempt fails so the delivery is retried
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var event domain.WebhookEvent
+		assert.NoError(t, json.Unmarshal(body, &event))
+		assert.True(t, domain.VerifyWebhook("s3cret", body, r.Header.Get(domain.WebhookSignatureHeader)))
+		assert.Equal(t, string(event.Type), r.Header.Get("X-Webhook-Event"))
+
+		events = append(events, received{event: event, signature: r.Header.Get(domain.WebhookSignatureHeader), delivery: r.Header.Get("X-Webhook-Delivery")})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	webhook := postJSON(t, srv.URL+"/webhooks", map[string]interface{}{
+		"url":         receiver.URL,
+		"secret":      "s3cret",
+		"event_types": []string{"upload.completed", "upload.failed", "alert.triggered"},
+	}, http.StatusCreated)
+	webhookID := webhook["id"].(string)
+	assert.NotContains(t, webhook, "secret")
+
+	postJSON(t, srv.URL+"/webhooks", map[string]interface{}{"url": receiver.URL, "event_types": []string{"upload.completed"}}, http.StatusBadRequest)
+	postJSON(t, srv.URL+"/alert-rules", map[string]interface{}{"name": "large debit", "kind": "debit_above", "amount": 1000000}, http.StatusCreated)
+
+	csvContent := `1000,JOHN DOE,CREDIT,500000,SUCCESS,salary
+2000,JANE DOE,DEBIT,2000000,SUCCESS,rent`
+
+	uploadID := uploadCSVWithFields(t, srv.URL+"/statements", csvContent, map[string]string{
+		"opening_balance":          "2000000",
+		"expected_closing_balance": "500000",
+	})
+	failedID := uploadCSV(t, srv.URL+"/statements", "1000,JOHN DOE,CREDIT,abc,SUCCESS,salary")
+	time.Sleep(2 * time.Second)
+
+	mu.Lock()
+	types := make(map[domain.WebhookEventType]string)
+	for _, r := range events {
+		types[r.event.Type] = r.event.UploadID
+		assert.NotEmpty(t, r.signature)
+		assert.NotEmpty(t, r.delivery)
+
+		// upload.completed is sent once the balance check is stored
+		if r.event.Type == domain.WebhookEventUploadCompleted {
+			check := r.event.Data.(map[string]interface{})["balance_check"].(map[string]interface{})
+			assert.Equal(t, float64(500000), check["computed_closing_balance"])
+			assert.Equal(t, true, check["matched"])
+		}
+	}
+	mu.Unlock()
+
+	assert.Equal(t, map[domain.WebhookEventType]string{
+		domain.WebhookEventUploadCompleted: uploadID,
+		domain.WebhookEventUploadFailed:    failedID,
+		domain.WebhookEventAlertTriggered:  uploadID,
+	}, types)
+
+	result := getJSON(t, srv.URL+"/webhooks/"+webhookID+"/deliveries?status=delivered", http.StatusOK)
+	require.Equal(t, float64(3), result["total"])
+
+	attempts := 0
+	for _, item := range result["items"].([]interface{}) {
+		delivery := item.(map[string]interface{})
+		attempts += int(delivery["attempts"].(float64))
+		assert.Equal(t, float64(http.StatusNoContent), delivery["status_code"])
+	}
+	assert.Equal(t, 4, attempts)
+
+	result = getJSON(t, srv.URL+"/webhooks/"+webhookID+"/deliveries?event_type=upload.failed", http.StatusOK)
+	assert.Equal(t, float64(1), result["total"])
+
+	getJSON(t, srv.URL+"/webhooks/"+webhookID+"/deliveries?status=sent", http.StatusBadRequest)
+	getJSON(t, srv.URL+"/webhooks/nonexistent/deliveries", http.StatusNotFound)
+
+	req, err := http.NewRequest(http.MethodDelete, srv.URL+"/webhooks/"+webhookID, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	getJSON(t, srv.URL+"/webhooks/"+webhookID, http.StatusNotFound)
+}
+
 func TestUploadNotFound(t *testing.T) {
 	srv, bus := setupTestServer(t)
 	defer srv.Close()
@@ -153,9 +1209,27 @@ func TestUploadNotFound(t *testing.T) {
 }
 
 func uploadCSV(t *testing.T, url, csvContent string) string {
+	return uploadCSVWithFields(t, url, csvContent, nil)
+}
+
+func uploadCSVWithFields(t *testing.T, url, csvContent string, fields map[string]string) string {
+	result := postFile(t, url, csvContent, fields, http.StatusAccepted)
+
+	uploadID, ok := result["upload_id"].(string)
+	require.True(t, ok)
+
+	return uploadID
+}
+
+func postFile(t *testing.T, url, csvContent string, fields map[string]string, expectedStatus int) map[string]interface{} {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
+	for key, value := range fields {
+		err := writer.WriteField(key, value)
+		require.NoError(t, err)
+	}
+
 	part, err := writer.CreateFormFile("file", "test.csv")
 	require.NoError(t, err)
 
@@ -174,16 +1248,38 @@ func uploadCSV(t *testing.T, url, csvContent string) string {
 	require.NoError(t, err)
 	defer resp.Body.Close()
 
-	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, expectedStatus, resp.StatusCode)
 
 	var result map[string]interface{}
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	require.NoError(t, err)
 
-	uploadID, ok := result["upload_id"].(string)
-	require.True(t, ok)
+	return result
+}
 
-	return uploadID
+func postJSON(t *testing.T, url string, payload interface{}, expectedStatus int) map[string]interface{} {
+	return sendJSON(t, http.MethodPost, url, payload, expectedStatus)
+}
+
+func sendJSON(t *testing.T, method, url string, payload interface{}, expectedStatus int) map[string]interface{} {
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+
+	var result map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	require.NoError(t, err)
+
+	return result
 }
 
 func getBalance(t *testing.T, url, uploadID string) int64 {
@@ -203,6 +1299,37 @@ func getBalance(t *testing.T, url, uploadID string) int64 {
 	return int64(balance)
 }
 
+func getJSON(t *testing.T, url string, expectedStatus int) map[string]interface{} {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+
+	var result map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	require.NoError(t, err)
+
+	return result
+}
+
+func getBalanceCheck(t *testing.T, url string) map[string]interface{} {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	require.NoError(t, err)
+
+	check, ok := result["balance_check"].(map[string]interface{})
+	require.True(t, ok)
+
+	return check
+}
+
 func getIssues(t *testing.T, url, uploadID string, page, perPage int, status string) []map[string]interface{} {
 	reqURL := url + "?upload_id=" + uploadID
 	if page > 0 {